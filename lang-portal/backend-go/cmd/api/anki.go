@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"backend-go/internal/service"
+)
+
+// runAnkiImport imports an Anki .apkg or .colpkg file, one group per deck.
+//
+//	api anki-import [-kanji-field F] [-romaji-field F] [-english-field F]
+//	                [-parts-fields "Field:key,..."] [-history=false] FILE
+func runAnkiImport(ankiService *service.AnkiService, args []string) error {
+	mapping := service.DefaultAnkiFieldMapping()
+	fs := flag.NewFlagSet("anki-import", flag.ExitOnError)
+	fs.StringVar(&mapping.Kanji, "kanji-field", mapping.Kanji, "note field holding the kanji")
+	fs.StringVar(&mapping.Romaji, "romaji-field", mapping.Romaji, "note field holding the romaji")
+	fs.StringVar(&mapping.English, "english-field", mapping.English, "note field holding the english translation")
+	partsFields := fs.String("parts-fields", "", `comma separated "Field" or "Field:key" note fields stored in parts (default: every other field)`)
+	history := fs.Bool("history", true, "turn the review log into study sessions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: anki-import [flags] FILE")
+	}
+
+	for _, entry := range strings.Split(*partsFields, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		field, key, _ := strings.Cut(entry, ":")
+		mapping.Parts = append(mapping.Parts, service.AnkiPartsField{Field: strings.TrimSpace(field), Key: strings.TrimSpace(key)})
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	result, err := ankiService.ImportAnki(context.Background(), service.AnkiImportParams{
+		Reader:         file,
+		Mapping:        mapping,
+		IncludeHistory: *history,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, deck := range result.Decks {
+		log.Printf("Deck %q -> group %d: %d notes, %d words created, %d existing, %d invalid, %d study sessions, %d reviews",
+			deck.Deck, deck.GroupID, deck.Notes, deck.Created, deck.Existing, deck.Invalid, deck.Sessions, deck.Reviews)
+	}
+	for _, noteErr := range result.Errors {
+		log.Printf("  skipped note %s in %q: %s", noteErr.GUID, noteErr.Deck, noteErr.Error)
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+//go:build sqlite_fts5
+
+package main
+
+// checkFTS5 reports whether the binary can run the words_fts index, which it
+// can when built with the sqlite_fts5 tag
+func checkFTS5() error {
+	return nil
+}
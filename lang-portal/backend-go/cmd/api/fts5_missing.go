@@ -0,0 +1,12 @@
+//go:build !sqlite_fts5
+
+package main
+
+import "errors"
+
+// checkFTS5 refuses to run a binary built without the sqlite_fts5 tag, whose
+// SQLite lacks the FTS5 module migration 000002_words_fts and word search
+// need
+func checkFTS5() error {
+	return errors.New("this binary was built without SQLite FTS5, which word search needs: build it with -tags sqlite_fts5, as the mage tasks do")
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"backend-go/internal/service"
+)
+
+// runJMdictImport loads a JMdict file into the dictionary. The file may be
+// gzipped, as it is distributed.
+//
+//	api jmdict-import FILE
+func runJMdictImport(dictionaryService *service.DictionaryService, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: jmdict-import FILE")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := maybeGzipped(file)
+	if err != nil {
+		return err
+	}
+
+	result, err := dictionaryService.ImportJMdict(context.Background(), reader)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Imported %d dictionary entries", result.Entries)
+	return nil
+}
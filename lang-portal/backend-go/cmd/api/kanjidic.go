@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"backend-go/internal/service"
+)
+
+// runKanjidicImport loads a KANJIDIC2 file into the kanji dictionary. The
+// file may be gzipped, as it is distributed.
+//
+//	api kanjidic-import FILE
+func runKanjidicImport(kanjiService *service.KanjiService, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: kanjidic-import FILE")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := maybeGzipped(file)
+	if err != nil {
+		return err
+	}
+
+	result, err := kanjiService.ImportKanjidic(context.Background(), reader)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Imported %d kanji", result.Characters)
+	return nil
+}
@@ -1,14 +1,24 @@
 package main
 
 import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"flag"
+	"io"
+	"io/fs"
 	"log"
-	"path/filepath"
+	"os"
 
 	"backend-go/internal/api/router"
+	"backend-go/internal/domain/models"
 	"backend-go/internal/repository/sqlite"
 	"backend-go/internal/repository/sqlite/implementations"
 	"backend-go/internal/service"
+	"backend-go/internal/transliteration"
+	"backend-go/migrations"
 	"backend-go/pkg/config"
+	"backend-go/seeds"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,6 +31,20 @@ func main() {
 	// Override the port from config with 5000
 	cfg.ServerPort = "5000"
 
+	// Migrations and seeds are embedded in the binary; these flags load them
+	// from disk instead while developing
+	flag.StringVar(&cfg.MigrationsDir, "migrations", cfg.MigrationsDir, "load migrations from this directory instead of the embedded copies")
+	flag.StringVar(&cfg.SeedsDir, "seeds", cfg.SeedsDir, "load seed data from this directory instead of the embedded copies")
+	flag.Parse()
+	args := flag.Args()
+
+	if err := checkFTS5(); err != nil {
+		log.Fatal(err)
+	}
+
+	migrationFiles := filesOrEmbedded(cfg.MigrationsDir, migrations.FS)
+	seedFiles := filesOrEmbedded(cfg.SeedsDir, seeds.FS)
+
 	// Initialize database
 	db, err := sqlite.New(cfg.DBPath)
 	if err != nil {
@@ -28,8 +52,17 @@ func main() {
 	}
 	defer db.Close()
 
+	// The migrate command manages the schema itself, so it runs before the
+	// automatic upgrade below
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := runMigrate(db, migrationFiles, args[1:]); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+		return
+	}
+
 	// Run migrations
-	if err := db.RunMigrations(filepath.Join(".", "migrations")); err != nil {
+	if err := db.RunMigrations(migrationFiles); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
@@ -43,15 +76,97 @@ func main() {
 	groupRepo := implementations.NewGroupRepository(db)
 	activityRepo := implementations.NewStudyActivityRepository(db)
 	sessionRepo := implementations.NewStudySessionRepository(db)
+	scheduleRepo := implementations.NewScheduleRepository(db)
+	groupSettingsRepo := implementations.NewGroupSettingsRepository(db)
+	sentenceRepo := implementations.NewSentenceRepository(db)
+	kanjiRepo := implementations.NewKanjiRepository(db)
+	dictionaryRepo := implementations.NewDictionaryRepository(db)
+	languageRepo := implementations.NewLanguageRepository(db)
+	revisionRepo := implementations.NewRevisionRepository(db)
+	trashRepo := implementations.NewTrashRepository(db)
+
+	romajiOptions, err := romajiOptions(cfg)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	retentionDays, err := trashRetention(cfg)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	languagePacks := service.NewLanguagePacks(service.NewJapanesePack(romajiOptions), service.SpanishPack{})
 
 	// Initialize services
-	wordService := service.NewWordService(wordRepo)
-	groupService := service.NewGroupService(groupRepo)
-	activityService := service.NewStudyActivityService(activityRepo, sessionRepo)
-	sessionService := service.NewStudySessionService(sessionRepo, groupRepo)
+	wordService := service.NewWordService(wordRepo, sentenceRepo, languageRepo, revisionRepo, languagePacks)
+	groupService := service.NewGroupService(db, groupRepo, wordRepo, languageRepo, revisionRepo)
+	activityService := service.NewStudyActivityService(activityRepo, sessionRepo, revisionRepo)
+	schedulingService := service.NewSchedulingService(db, scheduleRepo, groupSettingsRepo, groupRepo)
+	sessionService := service.NewStudySessionService(db, sessionRepo, groupRepo, schedulingService)
+	importService := service.NewImportService(db, wordRepo, groupRepo, languagePacks)
+	ankiService := service.NewAnkiService(db, wordRepo, groupRepo, activityRepo, sessionRepo, scheduleRepo, schedulingService, languagePacks)
+	conjugationService := service.NewConjugationService(wordRepo, groupRepo, sessionRepo, languagePacks)
+	sentenceService := service.NewSentenceService(sentenceRepo, wordRepo)
+	kanjiService := service.NewKanjiService(db, kanjiRepo, wordRepo)
+	dictionaryService := service.NewDictionaryService(db, dictionaryRepo, wordRepo, languagePacks)
+	languageService := service.NewLanguageService(languageRepo)
+	trashService := service.NewTrashService(trashRepo, wordRepo, groupRepo, activityRepo)
+	seedService := service.NewSeedService(seedFiles, wordRepo, groupRepo, activityRepo, sessionRepo, schedulingService, languagePacks)
+
+	// Words stored before readings were tracked get theirs filled in where
+	// they can be derived. The word histories name the server as the author.
+	backfill := models.WithChange(context.Background(), models.Change{Author: "system", Reason: "fill in missing reading"})
+	if backfilled, err := wordService.BackfillReadings(backfill); err != nil {
+		log.Fatalf("Failed to backfill word readings: %v", err)
+	} else if backfilled > 0 {
+		log.Printf("Filled in the reading of %d words", backfilled)
+	}
+	// Sort keys are recomputed so that changes to a language pack's
+	// collation, and the readings filled in above, take effect
+	if updated, err := wordService.BackfillSortKeys(context.Background()); err != nil {
+		log.Fatalf("Failed to update word sort keys: %v", err)
+	} else if updated > 0 {
+		log.Printf("Updated the sort key of %d words", updated)
+	}
+
+	// Run a maintenance command instead of the server when one is given
+	if len(args) > 0 {
+		switch args[0] {
+		case "replay":
+			if err := runReplay(schedulingService, args[1:]); err != nil {
+				log.Fatalf("Failed to replay review history: %v", err)
+			}
+		case "seed":
+			if err := runSeed(seedService, args[1:]); err != nil {
+				log.Fatalf("Failed to load seed data: %v", err)
+			}
+		case "anki-import":
+			if err := runAnkiImport(ankiService, args[1:]); err != nil {
+				log.Fatalf("Failed to import Anki package: %v", err)
+			}
+		case "kanjidic-import":
+			if err := runKanjidicImport(kanjiService, args[1:]); err != nil {
+				log.Fatalf("Failed to import KANJIDIC2: %v", err)
+			}
+		case "jmdict-import":
+			if err := runJMdictImport(dictionaryService, args[1:]); err != nil {
+				log.Fatalf("Failed to import JMdict: %v", err)
+			}
+		case "purge":
+			if err := runPurge(trashService, retentionDays, args[1:]); err != nil {
+				log.Fatalf("Failed to purge trash: %v", err)
+			}
+		default:
+			log.Fatalf("Unknown command %q", args[0])
+		}
+		return
+	}
 
 	// Initialize router with services
-	r := router.SetupRouter(wordService, groupService, activityService, sessionService)
+	r := router.SetupRouter(wordService, groupService, activityService, sessionService, schedulingService, seedService, importService, ankiService, conjugationService, sentenceService, kanjiService, dictionaryService, languageService, trashService)
+
+	// Items deleted longer ago than the retention are purged from the trash
+	if retentionDays > 0 {
+		startPurger(trashService, retentionDays)
+	}
 
 	// Basic health check endpoint
 	r.GET("/health", func(c *gin.Context) {
@@ -64,4 +179,34 @@ func main() {
 	if err := r.Run(":" + cfg.ServerPort); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
-} 
\ No newline at end of file
+}
+
+// filesOrEmbedded returns dir as a filesystem when set, or the embedded files otherwise
+func filesOrEmbedded(dir string, embedded fs.FS) fs.FS {
+	if dir != "" {
+		return os.DirFS(dir)
+	}
+	return embedded
+}
+
+// maybeGzipped decompresses r when it starts with the gzip magic number
+func maybeGzipped(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(buffered)
+	}
+	return buffered, nil
+}
+
+func romajiOptions(cfg *config.Config) (service.RomajiOptions, error) {
+	validation, err := service.ParseRomajiValidation(cfg.RomajiValidation)
+	if err != nil {
+		return service.RomajiOptions{}, err
+	}
+	system, err := transliteration.ParseSystem(cfg.RomajiSystem)
+	if err != nil {
+		return service.RomajiOptions{}, err
+	}
+	return service.RomajiOptions{Validation: validation, System: system}, nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"backend-go/internal/repository/sqlite"
+)
+
+// runMigrate manages the schema by hand instead of the automatic upgrade the
+// server performs on start.
+//
+//	api migrate up
+//	api migrate down [N]
+//	api migrate status
+//	api migrate to VERSION
+func runMigrate(db *sqlite.Database, migrationFiles fs.FS, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up | down [N] | status | to VERSION")
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := db.MigrateUp(migrationFiles)
+		logMigrations("Applied", applied)
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := db.MigrateDown(migrationFiles, steps)
+		logMigrations("Reverted", reverted)
+		return err
+
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: migrate to VERSION")
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid migration version %q", args[1])
+		}
+		ran, err := db.MigrateTo(migrationFiles, version)
+		logMigrations("Ran", ran)
+		return err
+
+	case "status":
+		statuses, err := db.MigrationStatus(migrationFiles)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(statuses)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+}
+
+func logMigrations(verb string, migrations []sqlite.Migration) {
+	if len(migrations) == 0 {
+		log.Printf("%s no migrations", verb)
+		return
+	}
+	for _, migration := range migrations {
+		log.Printf("%s %06d_%s", verb, migration.Version, migration.Name)
+	}
+}
+
+func printMigrationStatus(statuses []sqlite.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case status.Missing:
+			state = "applied, file missing"
+		case status.Drifted:
+			state = "applied, checksum drift"
+		case status.Applied:
+			state = "applied"
+		}
+
+		appliedAt := ""
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/service"
+	"backend-go/pkg/config"
+)
+
+// purgeInterval is how often the server purges the trash
+const purgeInterval = 24 * time.Hour
+
+// trashRetention reads how many days deleted items stay in the trash. Zero
+// disables the purge.
+func trashRetention(cfg *config.Config) (int, error) {
+	days, err := strconv.Atoi(cfg.TrashRetentionDays)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid trash retention %q: want a number of days", cfg.TrashRetentionDays)
+	}
+	return days, nil
+}
+
+// runPurge permanently removes the items that have been in the trash for
+// longer than the retention, which -days overrides. -days 0 empties the
+// trash.
+//
+//	api purge [-days N]
+func runPurge(trashService *service.TrashService, retentionDays int, args []string) error {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := fs.Int("days", retentionDays, "purge the items deleted more than this many days ago")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days < 0 {
+		return fmt.Errorf("invalid -days %d", *days)
+	}
+
+	return purgeTrash(trashService, time.Duration(*days)*24*time.Hour)
+}
+
+// startPurger purges the trash now and then once a purge interval for as
+// long as the server runs
+func startPurger(trashService *service.TrashService, retentionDays int) {
+	retention := time.Duration(retentionDays) * 24 * time.Hour
+	go func() {
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+		for {
+			if err := purgeTrash(trashService, retention); err != nil {
+				log.Printf("Failed to purge trash: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+func purgeTrash(trashService *service.TrashService, retention time.Duration) error {
+	ctx := models.WithChange(context.Background(), models.Change{Author: "system", Reason: "purge trash"})
+	result, err := trashService.Purge(ctx, retention)
+	if err != nil {
+		return err
+	}
+	if result.Words+result.Groups+result.StudyActivities > 0 {
+		log.Printf("Purged %d words, %d groups and %d study activities from the trash", result.Words, result.Groups, result.StudyActivities)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"backend-go/internal/service"
+)
+
+// runReplay recomputes word schedules from the review history, either for
+// a single group (-group ID) or for every reviewed word.
+//
+//	api replay [-group ID]
+func runReplay(schedulingService *service.SchedulingService, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	groupID := fs.Int64("group", 0, "only replay the words of this group")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	var result *service.ReplayResult
+	var err error
+	if *groupID != 0 {
+		result, err = schedulingService.ReplayGroup(ctx, *groupID)
+	} else {
+		result, err = schedulingService.ReplayAll(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Replayed %d reviews, rescheduled %d words", result.ReviewsReplayed, result.WordsRescheduled)
+	return nil
+}
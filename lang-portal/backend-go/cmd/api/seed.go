@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"backend-go/internal/service"
+)
+
+// runSeed loads a seed profile, named by -profile or the only argument, or
+// lists the available ones with -list.
+//
+//	api seed [-list] [-profile NAME | NAME]
+func runSeed(seedService *service.SeedService, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	profile := fs.String("profile", service.DefaultSeedProfile, "seed profile to load")
+	list := fs.Bool("list", false, "list the available seed profiles")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		profileSet := false
+		fs.Visit(func(f *flag.Flag) { profileSet = profileSet || f.Name == "profile" })
+		if fs.NArg() > 1 || profileSet {
+			return fmt.Errorf("usage: seed [-list] [-profile NAME | NAME]")
+		}
+		*profile = fs.Arg(0)
+	}
+
+	if *list {
+		profiles, err := seedService.Profiles()
+		if err != nil {
+			return err
+		}
+		log.Printf("Seed profiles: %s", strings.Join(profiles, ", "))
+		return nil
+	}
+
+	report, err := seedService.LoadProfile(context.Background(), *profile)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Loaded seed profile %s (packs: %s)", report.Profile, strings.Join(report.Packs, ", "))
+	for _, line := range []struct {
+		name   string
+		counts service.SeedCounts
+	}{
+		{"study activities", report.Activities},
+		{"words", report.Words},
+		{"groups", report.Groups},
+		{"group words", report.GroupWords},
+		{"study sessions", report.Sessions},
+		{"word reviews", report.Reviews},
+	} {
+		log.Printf("  %-16s %d inserted, %d updated, %d already present",
+			line.name, line.counts.Inserted, line.counts.Updated, line.counts.AlreadyPresent)
+	}
+	return nil
+}
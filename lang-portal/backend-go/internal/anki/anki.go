@@ -0,0 +1,124 @@
+// Package anki reads and writes Anki deck packages (.apkg): a zip archive
+// holding a SQLite collection (collection.anki2, schema version 11) and a
+// JSON media manifest.
+package anki
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CollectionFile is the name of the SQLite collection inside a package
+	CollectionFile = "collection.anki2"
+	// MediaFile is the name of the media manifest inside a package
+	MediaFile = "media"
+
+	// fieldSeparator joins the fields of a note
+	fieldSeparator = "\x1f"
+)
+
+// Card types and queues, as stored in cards.type and cards.queue
+const (
+	CardTypeNew    = 0
+	CardTypeLearn  = 1
+	CardTypeReview = 2
+)
+
+// Review types, as stored in revlog.type
+const (
+	ReviewTypeLearn   = 0
+	ReviewTypeReview  = 1
+	ReviewTypeRelearn = 2
+)
+
+// Answer buttons, as stored in revlog.ease
+const (
+	EaseAgain = 1
+	EaseHard  = 2
+	EaseGood  = 3
+	EaseEasy  = 4
+)
+
+// Deck is one deck of notes. For writing, every note uses a single note type
+// with the given Fields; the first field is the sort field and the front of
+// the card. Decks read from a package leave Fields empty and name the fields
+// on each note instead.
+type Deck struct {
+	ID          int64
+	Name        string
+	Description string
+	ModelID     int64
+	ModelName   string
+	Fields      []string
+	Notes       []Note
+}
+
+// Note is one note with its single card
+type Note struct {
+	GUID string
+	// NoteType and FieldNames are only set on notes read from a package
+	NoteType   string
+	FieldNames []string
+	Fields     []string
+	Tags       []string
+	Card       Card
+	Reviews    []Review
+}
+
+// Field returns the value of the named field of a note read from a package.
+// Names match case-insensitively.
+func (n Note) Field(name string) (string, bool) {
+	for i, fieldName := range n.FieldNames {
+		if strings.EqualFold(fieldName, name) && i < len(n.Fields) {
+			return n.Fields[i], true
+		}
+	}
+	return "", false
+}
+
+// Card is the scheduling state of a note's card. The zero value is a new card.
+type Card struct {
+	Type     int
+	Due      time.Time
+	Interval int
+	Factor   int
+	Reps     int
+	Lapses   int
+}
+
+// Review is one revlog entry
+type Review struct {
+	Time         time.Time
+	Ease         int
+	Interval     int
+	LastInterval int
+	Factor       int
+	Type         int
+}
+
+// GUID derives a stable note guid from a key, so exporting the same word
+// again lets Anki update the note instead of adding a duplicate
+func GUID(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return base64.RawStdEncoding.EncodeToString(sum[:])[:10]
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// checksum is the notes.csum of a note: the first 32 bits of the SHA-1 of
+// its first field with HTML removed
+func checksum(field string) int64 {
+	sum := sha1.Sum([]byte(stripHTML(field)))
+	value, _ := strconv.ParseInt(hex.EncodeToString(sum[:4]), 16, 64)
+	return value
+}
+
+func stripHTML(field string) string {
+	return strings.TrimSpace(htmlTagPattern.ReplaceAllString(field, ""))
+}
@@ -0,0 +1,277 @@
+package anki
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedPackage is returned for files that are not Anki packages, or
+// packages in a format this reader cannot open
+var ErrUnsupportedPackage = errors.New("unsupported Anki package")
+
+// Collection file names, newest legacy format first. collection.anki21b is
+// the zstd-compressed format of Anki 2.1.50+, which is only readable when
+// the package was exported with "Support older Anki versions".
+var collectionFiles = []string{"collection.anki21", CollectionFile}
+
+const compressedCollectionFile = "collection.anki21b"
+
+// Package is the content of an .apkg or .colpkg file: every deck that holds
+// at least one note, in name order
+type Package struct {
+	Decks []Deck
+}
+
+// ReadPackage reads an .apkg or .colpkg file. The archive is spooled to a
+// temporary directory, which is removed before returning.
+//
+// A note is listed in the deck of its first card; the reviews of all its
+// cards are merged in time order. FieldNames is set on every note since one
+// deck may mix note types, and fields are converted from HTML to plain text.
+func ReadPackage(r io.Reader) (*Package, error) {
+	dir, err := os.MkdirTemp("", "apkg-*")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	archivePath := filepath.Join(dir, "package.zip")
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("error spooling package: %v", err)
+	}
+	_, err = io.Copy(archiveFile, r)
+	archiveFile.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error spooling package: %v", err)
+	}
+
+	collectionPath := filepath.Join(dir, CollectionFile)
+	if err := extractCollection(archivePath, collectionPath); err != nil {
+		return nil, err
+	}
+	return readCollection(collectionPath)
+}
+
+func extractCollection(archivePath, collectionPath string) error {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("%w: not a zip archive", ErrUnsupportedPackage)
+	}
+	defer archive.Close()
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	for _, name := range collectionFiles {
+		file, ok := files[name]
+		if !ok {
+			continue
+		}
+		src, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("%w: error opening %s: %v", ErrUnsupportedPackage, name, err)
+		}
+		defer src.Close()
+
+		dst, err := os.Create(collectionPath)
+		if err != nil {
+			return fmt.Errorf("error extracting collection: %v", err)
+		}
+		defer dst.Close()
+		if _, err := io.Copy(dst, src); err != nil {
+			return fmt.Errorf("error extracting collection: %v", err)
+		}
+		return dst.Close()
+	}
+
+	if _, ok := files[compressedCollectionFile]; ok {
+		return fmt.Errorf("%w: the package uses the compressed format of Anki 2.1.50+; export it again with \"Support older Anki versions\" checked", ErrUnsupportedPackage)
+	}
+	return fmt.Errorf("%w: no collection in the archive", ErrUnsupportedPackage)
+}
+
+type noteTypeJSON struct {
+	Name   string `json:"name"`
+	Fields []struct {
+		Name string `json:"name"`
+		Ord  int    `json:"ord"`
+	} `json:"flds"`
+}
+
+type deckJSON struct {
+	Name        string `json:"name"`
+	Description string `json:"desc"`
+}
+
+func readCollection(path string) (*Package, error) {
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("error opening collection: %v", err)
+	}
+	defer db.Close()
+
+	var crt int64
+	var modelsJSON, decksJSON string
+	err = db.QueryRow("SELECT crt, models, decks FROM col").Scan(&crt, &modelsJSON, &decksJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading collection: %v", ErrUnsupportedPackage, err)
+	}
+
+	var noteTypes map[string]noteTypeJSON
+	if err := json.Unmarshal([]byte(modelsJSON), &noteTypes); err != nil {
+		return nil, fmt.Errorf("%w: error reading note types: %v", ErrUnsupportedPackage, err)
+	}
+	var deckInfo map[string]deckJSON
+	if err := json.Unmarshal([]byte(decksJSON), &deckInfo); err != nil {
+		return nil, fmt.Errorf("%w: error reading decks: %v", ErrUnsupportedPackage, err)
+	}
+
+	fieldNames := make(map[int64][]string, len(noteTypes))
+	modelNames := make(map[int64]string, len(noteTypes))
+	for id, noteType := range noteTypes {
+		modelID, _ := strconv.ParseInt(id, 10, 64)
+		names := make([]string, len(noteType.Fields))
+		for _, field := range noteType.Fields {
+			if field.Ord >= 0 && field.Ord < len(names) {
+				names[field.Ord] = field.Name
+			}
+		}
+		fieldNames[modelID] = names
+		modelNames[modelID] = noteType.Name
+	}
+
+	decks := make(map[int64]*Deck)
+	notes := make(map[int64]*Note)
+	noteDeck := make(map[int64]int64)
+	var noteOrder []int64
+
+	rows, err := db.Query(`
+		SELECT n.id, n.guid, n.mid, n.tags, n.flds, c.id, c.did, c.type, c.due, c.ivl, c.factor, c.reps, c.lapses
+		FROM notes n
+		JOIN cards c ON c.nid = n.id
+		ORDER BY n.id, c.ord, c.id`)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading notes: %v", ErrUnsupportedPackage, err)
+	}
+	defer rows.Close()
+
+	cardNote := make(map[int64]int64)
+	for rows.Next() {
+		var noteID, modelID, cardID, deckID, due int64
+		var guid, tags, fields string
+		var card Card
+		if err := rows.Scan(&noteID, &guid, &modelID, &tags, &fields, &cardID, &deckID,
+			&card.Type, &due, &card.Interval, &card.Factor, &card.Reps, &card.Lapses); err != nil {
+			return nil, fmt.Errorf("error reading note: %v", err)
+		}
+		cardNote[cardID] = noteID
+		if _, seen := notes[noteID]; seen {
+			continue
+		}
+
+		switch card.Type {
+		case CardTypeLearn:
+			card.Due = time.Unix(due, 0).UTC()
+		case CardTypeReview:
+			card.Due = time.Unix(crt, 0).UTC().AddDate(0, 0, int(due))
+		case CardTypeNew:
+		default:
+			// Relearning cards (type 3 in the v3 scheduler) count as learning
+			card.Type = CardTypeLearn
+			card.Due = time.Unix(due, 0).UTC()
+		}
+
+		values := strings.Split(fields, fieldSeparator)
+		for i, value := range values {
+			values[i] = PlainText(value)
+		}
+		notes[noteID] = &Note{
+			GUID:       guid,
+			NoteType:   modelNames[modelID],
+			FieldNames: fieldNames[modelID],
+			Fields:     values,
+			Tags:       strings.Fields(tags),
+			Card:       card,
+		}
+		noteDeck[noteID] = deckID
+		noteOrder = append(noteOrder, noteID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading notes: %v", err)
+	}
+
+	reviews, err := db.Query("SELECT id, cid, ease, ivl, lastIvl, factor, type FROM revlog ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading review log: %v", ErrUnsupportedPackage, err)
+	}
+	defer reviews.Close()
+	for reviews.Next() {
+		var id, cardID int64
+		var review Review
+		if err := reviews.Scan(&id, &cardID, &review.Ease, &review.Interval, &review.LastInterval, &review.Factor, &review.Type); err != nil {
+			return nil, fmt.Errorf("error reading review: %v", err)
+		}
+		note, ok := notes[cardNote[cardID]]
+		if !ok {
+			continue
+		}
+		review.Time = time.UnixMilli(id).UTC()
+		note.Reviews = append(note.Reviews, review)
+	}
+	if err := reviews.Err(); err != nil {
+		return nil, fmt.Errorf("error reading review log: %v", err)
+	}
+
+	for _, noteID := range noteOrder {
+		deckID := noteDeck[noteID]
+		deck, ok := decks[deckID]
+		if !ok {
+			info := deckInfo[strconv.FormatInt(deckID, 10)]
+			name := info.Name
+			if name == "" {
+				name = fmt.Sprintf("Deck %d", deckID)
+			}
+			deck = &Deck{ID: deckID, Name: name, Description: PlainText(info.Description)}
+			decks[deckID] = deck
+		}
+		deck.Notes = append(deck.Notes, *notes[noteID])
+	}
+
+	pkg := &Package{}
+	for _, deck := range decks {
+		pkg.Decks = append(pkg.Decks, *deck)
+	}
+	sort.Slice(pkg.Decks, func(i, j int) bool { return pkg.Decks[i].Name < pkg.Decks[j].Name })
+	return pkg, nil
+}
+
+var (
+	lineBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>`)
+	soundPattern     = regexp.MustCompile(`\[sound:[^\]]*\]`)
+)
+
+// PlainText converts an Anki field from HTML to plain text: line breaks
+// become spaces, tags and [sound:...] references are dropped and entities
+// are decoded
+func PlainText(field string) string {
+	field = lineBreakPattern.ReplaceAllString(field, " ")
+	field = soundPattern.ReplaceAllString(field, "")
+	field = htmlTagPattern.ReplaceAllString(field, "")
+	field = html.UnescapeString(field)
+	return strings.Join(strings.Fields(field), " ")
+}
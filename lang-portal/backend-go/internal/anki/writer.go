@@ -0,0 +1,427 @@
+package anki
+
+import (
+	"archive/zip"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// schema is the collection schema of Anki 2.1 in legacy (version 11) mode,
+// which every Anki release can import
+const schema = `
+CREATE TABLE col (
+	id integer primary key,
+	crt integer not null,
+	mod integer not null,
+	scm integer not null,
+	ver integer not null,
+	dty integer not null,
+	usn integer not null,
+	ls integer not null,
+	conf text not null,
+	models text not null,
+	decks text not null,
+	dconf text not null,
+	tags text not null
+);
+CREATE TABLE notes (
+	id integer primary key,
+	guid text not null,
+	mid integer not null,
+	mod integer not null,
+	usn integer not null,
+	tags text not null,
+	flds text not null,
+	sfld integer not null,
+	csum integer not null,
+	flags integer not null,
+	data text not null
+);
+CREATE TABLE cards (
+	id integer primary key,
+	nid integer not null,
+	did integer not null,
+	ord integer not null,
+	mod integer not null,
+	usn integer not null,
+	type integer not null,
+	queue integer not null,
+	due integer not null,
+	ivl integer not null,
+	factor integer not null,
+	reps integer not null,
+	lapses integer not null,
+	left integer not null,
+	odue integer not null,
+	odid integer not null,
+	flags integer not null,
+	data text not null
+);
+CREATE TABLE revlog (
+	id integer primary key,
+	cid integer not null,
+	usn integer not null,
+	ease integer not null,
+	ivl integer not null,
+	lastIvl integer not null,
+	factor integer not null,
+	time integer not null,
+	type integer not null
+);
+CREATE TABLE graves (
+	usn integer not null,
+	oid integer not null,
+	type integer not null
+);
+CREATE INDEX ix_notes_usn ON notes (usn);
+CREATE INDEX ix_cards_usn ON cards (usn);
+CREATE INDEX ix_revlog_usn ON revlog (usn);
+CREATE INDEX ix_cards_nid ON cards (nid);
+CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+CREATE INDEX ix_revlog_cid ON revlog (cid);
+CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+// defaultDeckID is the id of the "Default" deck every collection has
+const defaultDeckID = 1
+
+// WritePackage writes deck as an .apkg package. Fields hold plain text and
+// are HTML-escaped on the way out. The collection is built in a temporary
+// file, which is removed before returning.
+func WritePackage(w io.Writer, deck Deck) error {
+	if len(deck.Fields) == 0 {
+		return fmt.Errorf("deck %q has no fields", deck.Name)
+	}
+
+	dir, err := os.MkdirTemp("", "apkg-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, CollectionFile)
+	if err := writeCollection(path, deck, time.Now()); err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	entry, err := archive.Create(CollectionFile)
+	if err != nil {
+		return fmt.Errorf("error writing package: %v", err)
+	}
+	collection, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error reading collection: %v", err)
+	}
+	defer collection.Close()
+	if _, err := io.Copy(entry, collection); err != nil {
+		return fmt.Errorf("error writing package: %v", err)
+	}
+
+	media, err := archive.Create(MediaFile)
+	if err != nil {
+		return fmt.Errorf("error writing package: %v", err)
+	}
+	if _, err := io.WriteString(media, "{}"); err != nil {
+		return fmt.Errorf("error writing package: %v", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("error writing package: %v", err)
+	}
+	return nil
+}
+
+func writeCollection(path string, deck Deck, now time.Time) error {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return fmt.Errorf("error creating collection: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("error creating collection schema: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	deckID := deck.ID
+	if deckID == 0 {
+		deckID = stableID("deck", deck.Name)
+	}
+	modelID := deck.ModelID
+	if modelID == 0 {
+		modelID = stableID("model", deck.ModelName, strings.Join(deck.Fields, fieldSeparator))
+	}
+	modelName := deck.ModelName
+	if modelName == "" {
+		modelName = deck.Name
+	}
+
+	crt := collectionCreated(deck, now)
+	mod := now.Unix()
+
+	conf, _ := json.Marshal(collectionConf(deckID, modelID, len(deck.Notes)))
+	models, _ := json.Marshal(map[string]any{fmt.Sprint(modelID): noteType(modelID, modelName, deckID, deck.Fields, mod)})
+	decks, _ := json.Marshal(map[string]any{
+		fmt.Sprint(defaultDeckID): deckConf(defaultDeckID, "Default", "", mod),
+		fmt.Sprint(deckID):        deckConf(deckID, deck.Name, deck.Description, mod),
+	})
+	dconf, _ := json.Marshal(map[string]any{"1": optionsGroup()})
+
+	_, err = tx.Exec(`
+		INSERT INTO col (id, crt, mod, scm, ver, dty, usn, ls, conf, models, decks, dconf, tags)
+		VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		crt.Unix(), now.UnixMilli(), now.UnixMilli(), string(conf), string(models), string(decks), string(dconf))
+	if err != nil {
+		return fmt.Errorf("error writing collection: %v", err)
+	}
+
+	// Note and card ids are creation times in milliseconds; consecutive ids
+	// from the export time keep them unique
+	baseID := now.UnixMilli()
+	usedReviewIDs := make(map[int64]bool)
+	for i, note := range deck.Notes {
+		if len(note.Fields) != len(deck.Fields) {
+			return fmt.Errorf("note %d has %d fields, expected %d", i, len(note.Fields), len(deck.Fields))
+		}
+
+		fields := make([]string, len(note.Fields))
+		for j, field := range note.Fields {
+			fields[j] = html.EscapeString(field)
+		}
+		guid := note.GUID
+		if guid == "" {
+			guid = GUID(strings.Join(note.Fields, fieldSeparator))
+		}
+
+		noteID := baseID + int64(i)
+		_, err := tx.Exec(`
+			INSERT INTO notes (id, guid, mid, mod, usn, tags, flds, sfld, csum, flags, data)
+			VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			noteID, guid, modelID, mod, formatTags(note.Tags),
+			strings.Join(fields, fieldSeparator), stripHTML(fields[0]), checksum(fields[0]))
+		if err != nil {
+			return fmt.Errorf("error writing note %d: %v", i, err)
+		}
+
+		card := note.Card
+		due := int64(i + 1) // new cards are due in note order
+		switch card.Type {
+		case CardTypeLearn:
+			due = card.Due.Unix()
+		case CardTypeReview:
+			due = int64(card.Due.Sub(crt).Hours() / 24)
+		}
+		cardID := noteID
+		_, err = tx.Exec(`
+			INSERT INTO cards (id, nid, did, ord, mod, usn, type, queue, due, ivl, factor, reps, lapses, left, odue, odid, flags, data)
+			VALUES (?, ?, ?, 0, ?, -1, ?, ?, ?, ?, ?, ?, ?, 0, 0, 0, 0, '')`,
+			cardID, noteID, deckID, mod, card.Type, card.Type, due,
+			card.Interval, card.Factor, card.Reps, card.Lapses)
+		if err != nil {
+			return fmt.Errorf("error writing card %d: %v", i, err)
+		}
+
+		for _, review := range note.Reviews {
+			// Review ids are the review times in milliseconds
+			reviewID := review.Time.UnixMilli()
+			for usedReviewIDs[reviewID] {
+				reviewID++
+			}
+			usedReviewIDs[reviewID] = true
+
+			_, err := tx.Exec(`
+				INSERT INTO revlog (id, cid, usn, ease, ivl, lastIvl, factor, time, type)
+				VALUES (?, ?, -1, ?, ?, ?, ?, 0, ?)`,
+				reviewID, cardID, review.Ease, review.Interval, review.LastInterval, review.Factor, review.Type)
+			if err != nil {
+				return fmt.Errorf("error writing review of card %d: %v", i, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error writing collection: %v", err)
+	}
+	return nil
+}
+
+// collectionCreated is the start of the day the collection counts review due
+// dates from: the day of the earliest review or due date, or today
+func collectionCreated(deck Deck, now time.Time) time.Time {
+	earliest := now
+	for _, note := range deck.Notes {
+		if note.Card.Type == CardTypeReview && note.Card.Due.Before(earliest) {
+			earliest = note.Card.Due
+		}
+		for _, review := range note.Reviews {
+			if review.Time.Before(earliest) {
+				earliest = review.Time
+			}
+		}
+	}
+	return earliest.UTC().Truncate(24 * time.Hour)
+}
+
+// stableID derives a positive id from names, so the same deck or note type
+// exported twice is recognized by Anki as the same one
+func stableID(parts ...string) int64 {
+	hash := fnv.New64a()
+	for _, part := range parts {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	// Keep ids in the range of millisecond timestamps Anki itself generates
+	return int64(hash.Sum64()%(1<<40)) + 1<<40
+}
+
+func formatTags(tags []string) string {
+	var cleaned []string
+	for _, tag := range tags {
+		if tag = strings.Join(strings.Fields(tag), "_"); tag != "" {
+			cleaned = append(cleaned, tag)
+		}
+	}
+	if len(cleaned) == 0 {
+		return ""
+	}
+	return " " + strings.Join(cleaned, " ") + " "
+}
+
+func collectionConf(deckID, modelID int64, notes int) map[string]any {
+	return map[string]any{
+		"nextPos":       notes + 1,
+		"estTimes":      true,
+		"activeDecks":   []int64{deckID},
+		"sortType":      "noteFld",
+		"timeLim":       0,
+		"sortBackwards": false,
+		"addToCur":      true,
+		"curDeck":       deckID,
+		"newSpread":     0,
+		"dueCounts":     true,
+		"curModel":      modelID,
+		"collapseTime":  1200,
+	}
+}
+
+// noteType is a basic note type with one card: the first field on the front
+// and every other field on the back, skipping empty ones
+func noteType(id int64, name string, deckID int64, fieldNames []string, mod int64) map[string]any {
+	fields := make([]map[string]any, len(fieldNames))
+	var back strings.Builder
+	back.WriteString("{{FrontSide}}\n\n<hr id=answer>\n\n")
+	for i, field := range fieldNames {
+		fields[i] = map[string]any{
+			"name":   field,
+			"ord":    i,
+			"sticky": false,
+			"rtl":    false,
+			"font":   "Arial",
+			"size":   20,
+			"media":  []string{},
+		}
+		if i > 0 {
+			fmt.Fprintf(&back, "{{#%[1]s}}<div class=%[2]q>{{%[1]s}}</div>{{/%[1]s}}\n", field, strings.ToLower(strings.Join(strings.Fields(field), "-")))
+		}
+	}
+
+	return map[string]any{
+		"id":    id,
+		"name":  name,
+		"type":  0,
+		"mod":   mod,
+		"usn":   -1,
+		"sortf": 0,
+		"did":   deckID,
+		"tmpls": []map[string]any{{
+			"name":  "Card 1",
+			"ord":   0,
+			"qfmt":  fmt.Sprintf("{{%s}}", fieldNames[0]),
+			"afmt":  back.String(),
+			"did":   nil,
+			"bqfmt": "",
+			"bafmt": "",
+		}},
+		"flds":      fields,
+		"css":       ".card {\n font-family: arial;\n font-size: 20px;\n text-align: center;\n color: black;\n background-color: white;\n}\n",
+		"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+		"tags":      []string{},
+		"vers":      []int{},
+		"req":       []any{[]any{0, "all", []int{0}}},
+	}
+}
+
+func deckConf(id int64, name, description string, mod int64) map[string]any {
+	return map[string]any{
+		"id":        id,
+		"name":      name,
+		"desc":      description,
+		"mod":       mod,
+		"usn":       -1,
+		"collapsed": false,
+		"newToday":  []int{0, 0},
+		"revToday":  []int{0, 0},
+		"lrnToday":  []int{0, 0},
+		"timeToday": []int{0, 0},
+		"dyn":       0,
+		"conf":      1,
+		"extendNew": 10,
+		"extendRev": 50,
+	}
+}
+
+func optionsGroup() map[string]any {
+	return map[string]any{
+		"id":       1,
+		"name":     "Default",
+		"mod":      0,
+		"usn":      0,
+		"dyn":      false,
+		"maxTaken": 60,
+		"timer":    0,
+		"autoplay": true,
+		"replayq":  true,
+		"new": map[string]any{
+			"delays":        []int{1, 10},
+			"ints":          []int{1, 4, 7},
+			"initialFactor": 2500,
+			"order":         1,
+			"perDay":        20,
+			"bury":          true,
+			"separate":      true,
+		},
+		"rev": map[string]any{
+			"perDay":   200,
+			"ease4":    1.3,
+			"fuzz":     0.05,
+			"maxIvl":   36500,
+			"minSpace": 1,
+			"ivlFct":   1,
+			"bury":     true,
+		},
+		"lapse": map[string]any{
+			"delays":      []int{10},
+			"mult":        0,
+			"minInt":      1,
+			"leechFails":  8,
+			"leechAction": 0,
+		},
+	}
+}
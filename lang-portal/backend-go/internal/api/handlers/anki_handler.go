@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type AnkiHandler struct {
+	ankiService *service.AnkiService
+}
+
+func NewAnkiHandler(ankiService *service.AnkiService) *AnkiHandler {
+	return &AnkiHandler{
+		ankiService: ankiService,
+	}
+}
+
+// ImportAnki handles POST /api/groups/import.apkg. The body is the .apkg or
+// .colpkg file itself, or a multipart form with the file in the "file" field.
+// Every deck becomes a group named after it.
+//
+// Query parameters:
+//   - kanji_field, romaji_field, english_field: note fields holding the word
+//     fields (default Kanji, Romaji, English)
+//   - parts_fields: comma separated "Field" or "Field:key" note fields stored
+//     in parts (default: every other field, keyed by its name)
+//   - include_history: turn the review log into study sessions (default true)
+func (h *AnkiHandler) ImportAnki(c *gin.Context) {
+	mapping := service.DefaultAnkiFieldMapping()
+	mapping.Kanji = c.DefaultQuery("kanji_field", mapping.Kanji)
+	mapping.Romaji = c.DefaultQuery("romaji_field", mapping.Romaji)
+	mapping.English = c.DefaultQuery("english_field", mapping.English)
+	for _, column := range parsePartsColumns(c.Query("parts_fields")) {
+		mapping.Parts = append(mapping.Parts, service.AnkiPartsField{Field: column.Column, Key: column.Key})
+	}
+
+	includeHistory, err := strconv.ParseBool(c.DefaultQuery("include_history", "true"))
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid include_history value")
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	body, err := uploadBody(c, mediaType)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.ankiService.ImportAnki(c.Request.Context(), service.AnkiImportParams{
+		Reader:         body,
+		Mapping:        mapping,
+		IncludeHistory: includeHistory,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidImport):
+			responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrSmartGroup):
+			responses.ErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to import package")
+		}
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, result)
+}
+
+// ExportGroupAnki handles GET /api/group/:id/export.apkg
+//
+// Query parameters:
+//   - parts: comma separated parts keys added as note fields after kanji,
+//     romaji and english
+//   - include_history: carry the review history over as Anki's review log
+func (h *AnkiHandler) ExportGroupAnki(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+	includeHistory, err := strconv.ParseBool(c.DefaultQuery("include_history", "false"))
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid include_history value")
+		return
+	}
+
+	params := service.AnkiExportParams{IncludeHistory: includeHistory}
+	for _, key := range strings.Split(c.Query("parts"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			params.PartsFields = append(params.PartsFields, key)
+		}
+	}
+
+	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="group-%d.apkg"`, groupID))
+
+	err = h.ankiService.ExportGroupAnki(c.Request.Context(), groupID, params, c.Writer)
+	if err == nil {
+		return
+	}
+	if c.Writer.Written() {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidExport):
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to export group")
+	}
+}
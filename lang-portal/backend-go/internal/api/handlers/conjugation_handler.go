@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type ConjugationHandler struct {
+	conjugationService *service.ConjugationService
+}
+
+func NewConjugationHandler(conjugationService *service.ConjugationService) *ConjugationHandler {
+	return &ConjugationHandler{
+		conjugationService: conjugationService,
+	}
+}
+
+// ConjugationReviewRequest records a drill answer. Either answer, which is
+// checked against the expected form, or correct must be given.
+type ConjugationReviewRequest struct {
+	WordID  int64  `json:"word_id" binding:"required"`
+	Form    string `json:"form" binding:"required"`
+	Answer  string `json:"answer"`
+	Correct *bool  `json:"correct"`
+}
+
+// GetWordConjugations handles GET /api/words/:id/conjugations
+func (h *ConjugationHandler) GetWordConjugations(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid word ID")
+		return
+	}
+
+	conjugations, err := h.conjugationService.GetWordConjugations(c.Request.Context(), wordID)
+	if err != nil {
+		conjugationError(c, err, "Failed to conjugate word")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, conjugations)
+}
+
+// GetConjugationDrill handles GET /api/group/:id/conjugation_drill
+func (h *ConjugationHandler) GetConjugationDrill(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	params := service.DrillParams{Count: parseInt(c.Query("count"), 0)}
+	if forms := c.Query("forms"); forms != "" {
+		params.Forms = strings.Split(forms, ",")
+	}
+
+	exercises, err := h.conjugationService.Drill(c.Request.Context(), groupID, params)
+	if err != nil {
+		conjugationError(c, err, "Failed to build conjugation drill")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"exercises": exercises,
+	})
+}
+
+// AddConjugationReview handles POST /api/study_sessions/:id/conjugation_review
+func (h *ConjugationHandler) AddConjugationReview(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	var req ConjugationReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Answer == "" && req.Correct == nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "answer or correct is required")
+		return
+	}
+
+	params := service.ConjugationReviewParams{
+		WordID: req.WordID,
+		Form:   req.Form,
+		Answer: req.Answer,
+	}
+	if req.Correct != nil {
+		params.Correct = *req.Correct
+	}
+
+	result, err := h.conjugationService.RecordReview(c.Request.Context(), sessionID, params)
+	if err != nil {
+		conjugationError(c, err, "Failed to record conjugation review")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusCreated, result)
+}
+
+// GetConjugationStats handles GET /api/group/:id/conjugation_stats
+func (h *ConjugationHandler) GetConjugationStats(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	stats, err := h.conjugationService.GroupFormStats(c.Request.Context(), groupID)
+	if err != nil {
+		conjugationError(c, err, "Failed to fetch conjugation stats")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"forms": stats,
+	})
+}
+
+func conjugationError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrWordNotFound),
+		errors.Is(err, service.ErrGroupNotFound),
+		errors.Is(err, service.ErrSessionNotFound):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNotConjugable):
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		responses.ErrorResponse(c, http.StatusInternalServerError, message)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type DictionaryHandler struct {
+	dictionaryService *service.DictionaryService
+}
+
+func NewDictionaryHandler(dictionaryService *service.DictionaryService) *DictionaryHandler {
+	return &DictionaryHandler{
+		dictionaryService: dictionaryService,
+	}
+}
+
+// WordFromDictionaryRequest optionally picks the spelling, reading and senses
+// of a word created from a dictionary entry
+type WordFromDictionaryRequest struct {
+	Kanji   string `json:"kanji"`
+	Reading string `json:"reading"`
+	Senses  []int  `json:"senses"`
+}
+
+// LookupDictionary handles GET /api/dictionary
+func (h *DictionaryHandler) LookupDictionary(c *gin.Context) {
+	params := service.LookupDictionaryParams{
+		Query:    c.Query("q"),
+		Page:     parseInt(c.Query("page"), 1),
+		PageSize: parseInt(c.Query("page_size"), 10),
+	}
+	if strings.TrimSpace(params.Query) == "" {
+		responses.ErrorResponse(c, http.StatusBadRequest, "query parameter q is required")
+		return
+	}
+
+	result, err := h.dictionaryService.LookupDictionary(c.Request.Context(), params)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to search dictionary")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"entries": result.Entries,
+		"pagination": responses.Pagination{
+			CurrentPage:  result.CurrentPage,
+			TotalPages:   result.TotalPages,
+			TotalItems:   result.TotalItems,
+			ItemsPerPage: params.PageSize,
+		},
+	})
+}
+
+// CreateWordFromDictionary handles POST /api/words/from_dictionary/:entry_id.
+// The request body is optional.
+func (h *DictionaryHandler) CreateWordFromDictionary(c *gin.Context) {
+	entryID, err := strconv.ParseInt(c.Param("entry_id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid dictionary entry ID")
+		return
+	}
+
+	var req WordFromDictionaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		responses.ErrorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	word, warnings, err := h.dictionaryService.CreateWordFromDictionary(c.Request.Context(), entryID, service.WordFromDictionaryParams{
+		Kanji:   req.Kanji,
+		Reading: req.Reading,
+		Senses:  req.Senses,
+	})
+	switch {
+	case errors.Is(err, service.ErrDictionaryEntryNotFound):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, service.ErrWordExists):
+		responses.ErrorResponse(c, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, wordWriteResponse(word, warnings))
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -29,8 +31,9 @@ func NewGroupHandler(groupService *service.GroupService) *GroupHandler {
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Items per page (default: 10)"
-// @Param sort_by query string false "Sort field (name, words_count)"
+// @Param sort_by query string false "Sort field (name, language, words_count)"
 // @Param order query string false "Sort order (asc, desc)"
+// @Param language query string false "Only groups of this language, e.g. ja or es"
 // @Success 200 {object} ListGroupsResponse
 // @Router /api/groups [get]
 func (h *GroupHandler) ListGroups(c *gin.Context) {
@@ -39,6 +42,7 @@ func (h *GroupHandler) ListGroups(c *gin.Context) {
 		PageSize: parseInt(c.Query("page_size"), 10),
 		SortBy:   c.Query("sort_by"),
 		Order:    c.Query("order"),
+		Language: c.Query("language"),
 	}
 
 	result, err := h.groupService.ListGroups(c.Request.Context(), params)
@@ -89,22 +93,32 @@ func (h *GroupHandler) GetGroup(c *gin.Context) {
 
 // CreateGroup godoc
 // @Summary Create a new group
-// @Description Create a new word group
+// @Description Create an empty word group, or a smart group whose words are those its filter matches whenever it is read. Group names are unique. A group given a parent_id comes last under that group, which must be in its language.
 // @Tags groups
 // @Accept json
 // @Produce json
 // @Param group body CreateGroupRequest true "Group object"
 // @Success 201 {object} GroupResponse
+// @Failure 400 {object} map[string]string "Invalid filter, or a filter naming a group under the parent"
+// @Failure 404 {object} map[string]string "Unknown parent"
+// @Failure 409 {object} map[string]string "A group with that name exists, or the parent is in another language"
 // @Router /api/groups [post]
 func (h *GroupHandler) CreateGroup(c *gin.Context) {
-	var group models.Group
-	if err := c.ShouldBindJSON(&group); err != nil {
+	var req CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
 
-	if err := h.groupService.CreateGroup(c.Request.Context(), &group); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	created := models.Group{Name: req.Name, Language: req.Language, Filter: req.Filter, ParentID: req.ParentID}
+	if err := h.groupService.CreateGroup(c.Request.Context(), &created); err != nil {
+		groupError(c, err, "Failed to create group")
+		return
+	}
+
+	group, err := h.groupService.GetGroup(c.Request.Context(), created.ID)
+	if err != nil {
+		groupError(c, err, "Failed to fetch group")
 		return
 	}
 
@@ -114,15 +128,18 @@ func (h *GroupHandler) CreateGroup(c *gin.Context) {
 }
 
 // UpdateGroup godoc
-// @Summary Update a group
-// @Description Update an existing word group
+// @Summary Rename a group
+// @Description Rename an existing word group, or change the filter of a smart group. The change is recorded in its history.
 // @Tags groups
 // @Accept json
 // @Produce json
 // @Param id path int true "Group ID"
 // @Param group body UpdateGroupRequest true "Group object"
 // @Success 200 {object} GroupResponse
-// @Router /api/groups/{id} [put]
+// @Failure 400 {object} map[string]string "Invalid filter, or a filter for a group that is not smart"
+// @Failure 404 {object} map[string]string "Unknown group"
+// @Failure 409 {object} map[string]string "Another group has that name"
+// @Router /api/group/{id} [put]
 func (h *GroupHandler) UpdateGroup(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
@@ -130,15 +147,20 @@ func (h *GroupHandler) UpdateGroup(c *gin.Context) {
 		return
 	}
 
-	var group models.Group
-	if err := c.ShouldBindJSON(&group); err != nil {
+	var req UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
-	group.ID = id
 
-	if err := h.groupService.UpdateGroup(c.Request.Context(), &group); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.groupService.UpdateGroup(c.Request.Context(), &models.Group{ID: id, Name: req.Name, Filter: req.Filter}); err != nil {
+		groupError(c, err, "Failed to update group")
+		return
+	}
+
+	group, err := h.groupService.GetGroup(c.Request.Context(), id)
+	if err != nil {
+		groupError(c, err, "Failed to fetch group")
 		return
 	}
 
@@ -149,13 +171,15 @@ func (h *GroupHandler) UpdateGroup(c *gin.Context) {
 
 // DeleteGroup godoc
 // @Summary Delete a group
-// @Description Delete a word group
+// @Description Move a word group to the trash. Its words are kept. A group with groups under it is not deleted.
 // @Tags groups
 // @Accept json
 // @Produce json
 // @Param id path int true "Group ID"
 // @Success 204 "No Content"
-// @Router /api/groups/{id} [delete]
+// @Failure 404 {object} map[string]string "Unknown group"
+// @Failure 409 {object} map[string]string "The group has groups under it"
+// @Router /api/group/{id} [delete]
 func (h *GroupHandler) DeleteGroup(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
@@ -164,13 +188,332 @@ func (h *GroupHandler) DeleteGroup(c *gin.Context) {
 	}
 
 	if err := h.groupService.DeleteGroup(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		groupError(c, err, "Failed to delete group")
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// MoveGroup godoc
+// @Summary Move a group in the tree
+// @Description Put a group under another group of its language, or at the top for a null parent_id, at a position among the groups there. The groups under it move with it.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param parent body MoveGroupRequest true "Parent and position"
+// @Success 200 {object} GroupResponse
+// @Failure 400 {object} map[string]string "Negative position, or a parent the group holds"
+// @Failure 404 {object} map[string]string "Unknown group or parent"
+// @Failure 409 {object} map[string]string "The parent is in another language"
+// @Router /api/group/{id}/parent [put]
+func (h *GroupHandler) MoveGroup(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	var req MoveGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	group, err := h.groupService.MoveGroup(c.Request.Context(), id, req.ParentID, req.Position)
+	if err != nil {
+		groupError(c, err, "Failed to move group")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": group,
+	})
+}
+
+// GetGroupTree godoc
+// @Summary Get a group with the groups under it
+// @Description Get a group and, in order, the groups under it down to the last level, each with its stats rolled up through the groups under it
+// @Tags groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string "Unknown group"
+// @Router /api/group/{id}/tree [get]
+func (h *GroupHandler) GetGroupTree(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	tree, err := h.groupService.GetGroupTree(c.Request.Context(), id)
+	if err != nil {
+		groupError(c, err, "Failed to fetch group tree")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tree,
+	})
+}
+
+// AddGroupWords godoc
+// @Summary Add words to a group
+// @Description Add words of the group's language to a group, all or none of them, last or in the order given at a position. Words already in the group are skipped.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param words body GroupWordsRequest true "Word IDs and position"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "No words, or a negative position"
+// @Failure 404 {object} map[string]string "Unknown group or word"
+// @Failure 409 {object} map[string]string "A word is in another language, or the group is smart"
+// @Router /api/group/{id}/words [post]
+func (h *GroupHandler) AddGroupWords(c *gin.Context) {
+	id, req, ok := bindGroupWords(c)
+	if !ok {
+		return
+	}
+
+	var added int
+	var err error
+	if req.Position != nil {
+		added, err = h.groupService.InsertWordsIntoGroup(c.Request.Context(), id, req.WordIDs, *req.Position)
+	} else {
+		added, err = h.groupService.AddWordsToGroup(c.Request.Context(), id, req.WordIDs)
+	}
+	if err != nil {
+		groupError(c, err, "Failed to add words to group")
+		return
+	}
+
+	h.membershipResponse(c, gin.H{"added": added}, id)
+}
+
+// RemoveGroupWords godoc
+// @Summary Remove words from a group
+// @Description Remove words from a group. Words not in the group are skipped.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param words body GroupWordsRequest true "Word IDs"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string "Unknown group"
+// @Failure 409 {object} map[string]string "The group is smart"
+// @Router /api/group/{id}/words [delete]
+func (h *GroupHandler) RemoveGroupWords(c *gin.Context) {
+	id, req, ok := bindGroupWords(c)
+	if !ok {
+		return
+	}
+
+	removed, err := h.groupService.RemoveWordsFromGroup(c.Request.Context(), id, req.WordIDs)
+	if err != nil {
+		groupError(c, err, "Failed to remove words from group")
+		return
+	}
+
+	h.membershipResponse(c, gin.H{"removed": removed}, id)
+}
+
+// ReorderGroupWords godoc
+// @Summary Reorder the words of a group
+// @Description Put words of a group first, in the order given, followed by its other words in the order they had. Every word must have been added to the group itself.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param words body GroupWordsRequest true "Word IDs in their new order"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string "No words, or a word listed twice"
+// @Failure 404 {object} map[string]string "Unknown group, or a word not in the group"
+// @Failure 409 {object} map[string]string "The group is smart"
+// @Router /api/group/{id}/words/order [put]
+func (h *GroupHandler) ReorderGroupWords(c *gin.Context) {
+	id, req, ok := bindGroupWords(c)
+	if !ok {
+		return
+	}
+
+	reordered, err := h.groupService.ReorderWords(c.Request.Context(), id, req.WordIDs)
+	if err != nil {
+		groupError(c, err, "Failed to reorder group words")
+		return
+	}
+
+	h.membershipResponse(c, gin.H{"reordered": reordered}, id)
+}
+
+// MoveGroupWords godoc
+// @Summary Move words to another group
+// @Description Move words of a group to another group of the same language. Every word must be in the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Source group ID"
+// @Param words body TransferWordsRequest true "Target group and word IDs"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string "Unknown group or word, or a word not in the group"
+// @Failure 409 {object} map[string]string "The target group is in another language, or either group is smart"
+// @Router /api/group/{id}/words/move [post]
+func (h *GroupHandler) MoveGroupWords(c *gin.Context) {
+	h.transferWords(c, h.groupService.MoveWords, "moved", "Failed to move words")
+}
+
+// CopyGroupWords godoc
+// @Summary Copy words to another group
+// @Description Add words of a group to another group of the same language, leaving them in the group. Every word must be in the group.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path int true "Source group ID"
+// @Param words body TransferWordsRequest true "Target group and word IDs"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string "Unknown group or word, or a word not in the group"
+// @Failure 409 {object} map[string]string "The target group is in another language or smart"
+// @Router /api/group/{id}/words/copy [post]
+func (h *GroupHandler) CopyGroupWords(c *gin.Context) {
+	h.transferWords(c, h.groupService.CopyWords, "copied", "Failed to copy words")
+}
+
+func (h *GroupHandler) transferWords(c *gin.Context, transfer func(ctx context.Context, sourceID, targetID int64, wordIDs []int64) (int, error), key, message string) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+	var req TransferWordsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	count, err := transfer(c.Request.Context(), id, req.TargetGroupID, req.WordIDs)
+	if err != nil {
+		groupError(c, err, message)
+		return
+	}
+
+	h.membershipResponse(c, gin.H{key: count}, id, req.TargetGroupID)
+}
+
+// bindGroupWords reads the group ID and word IDs of a membership change
+func bindGroupWords(c *gin.Context) (int64, GroupWordsRequest, bool) {
+	var req GroupWordsRequest
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return 0, req, false
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return 0, req, false
+	}
+	return id, req, true
+}
+
+// membershipResponse answers a membership change with its counts and the
+// groups it changed, source first, with their updated words counts
+func (h *GroupHandler) membershipResponse(c *gin.Context, data gin.H, groupIDs ...int64) {
+	groups := make([]*models.Group, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		group, err := h.groupService.GetGroup(c.Request.Context(), id)
+		if err != nil {
+			groupError(c, err, "Failed to fetch group")
+			return
+		}
+		groups = append(groups, group)
+	}
+	data["groups"] = groups
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+	})
+}
+
+// groupError reports a failed group change: 404 for an unknown group or
+// word, 409 for a change conflicting with another group or a word's
+// language, 400 for an invalid one
+func groupError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrGroupNotFound),
+		errors.Is(err, service.ErrWordNotFound),
+		errors.Is(err, service.ErrWordNotInGroup):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrGroupExists),
+		errors.Is(err, service.ErrGroupLanguageMismatch),
+		errors.Is(err, service.ErrSmartGroup),
+		errors.Is(err, service.ErrGroupHasChildren):
+		responses.ErrorResponse(c, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidGroup),
+		errors.Is(err, service.ErrInvalidLanguage),
+		errors.Is(err, service.ErrInvalidFilter):
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		responses.ErrorResponse(c, http.StatusInternalServerError, message)
+	}
+}
+
+// GetGroupHistory godoc
+// @Summary List the revisions of a group
+// @Description Get the changes made to a group, newest first, with the group before and after each change and who made it and why
+// @Tags groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Items per page (default: 10)"
+// @Success 200 {object} RevisionsResponse
+// @Router /api/group/{id}/history [get]
+func (h *GroupHandler) GetGroupHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+	page := parseInt(c.Query("page"), 1)
+	pageSize := parseInt(c.Query("page_size"), 10)
+
+	result, err := h.groupService.GetGroupHistory(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		historyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": revisionsPage(result, pageSize),
+	})
+}
+
+// RevertGroup godoc
+// @Summary Revert a group to a revision
+// @Description Restore the name of a group to the one a revision left it with. The revert is recorded as a revision of its own.
+// @Tags groups
+// @Produce json
+// @Param id path int true "Group ID"
+// @Param revision_id path int true "Revision ID"
+// @Success 200 {object} GroupResponse
+// @Router /api/group/{id}/revert/{revision_id} [post]
+func (h *GroupHandler) RevertGroup(c *gin.Context) {
+	id, revisionID, ok := parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	group, err := h.groupService.RevertGroup(c.Request.Context(), id, revisionID)
+	if err != nil {
+		revertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": group,
+	})
+}
+
 // GetGroupWords handles GET /group/:id/words
 func (h *GroupHandler) GetGroupWords(c *gin.Context) {
 	// Get group ID from URL parameter
@@ -183,12 +526,23 @@ func (h *GroupHandler) GetGroupWords(c *gin.Context) {
 	// Get pagination parameters
 	page := c.DefaultQuery("page", "1")
 	pageNum, _ := strconv.Atoi(page)
-	sortBy := c.DefaultQuery("sort_by", "kanji")
+	sortBy := c.DefaultQuery("sort_by", "position")
 	order := c.DefaultQuery("order", "asc")
 
+	// The remaining parameters filter the words, as on /api/words
+	filter, err := service.ParseWordFilter(c.Request.URL.Query(), "page", "sort_by", "order", "group_id")
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Get words from service
-	words, pagination, err := h.groupService.GetGroupWords(c.Request.Context(), groupID, pageNum, sortBy, order)
+	words, pagination, err := h.groupService.GetGroupWords(c.Request.Context(), groupID, pageNum, sortBy, order, filter)
 	if err != nil {
+		if errors.Is(err, service.ErrGroupNotFound) {
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
 		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch group words")
 		return
 	}
@@ -224,7 +578,7 @@ func (h *GroupHandler) GetGroupStudySessions(c *gin.Context) {
 	// Get study sessions from service
 	sessions, err := h.groupService.GetGroupStudySessions(c.Request.Context(), groupID, pageNum, pageSizeNum)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch group study sessions")
+		groupError(c, err, "Failed to fetch group study sessions")
 		return
 	}
 
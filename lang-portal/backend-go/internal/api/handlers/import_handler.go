@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+func NewImportHandler(importService *service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportGroupWords handles POST /api/groups/:id/import. The body is the JSON
+// array of words produced by the vocab-importer.
+func (h *ImportHandler) ImportGroupWords(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	var words []service.ImportWord
+	if err := c.ShouldBindJSON(&words); err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Request body must be a JSON array of words")
+		return
+	}
+
+	result, err := h.importService.ImportGroupWords(c.Request.Context(), groupID, words)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGroupNotFound):
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrSmartGroup):
+			responses.ErrorResponse(c, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrInvalidImport):
+			responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to import words")
+		}
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, result)
+}
+
+// ImportWordsCSV handles POST /api/words/import.csv. The body is the CSV or
+// TSV file itself, or a multipart form with the file in the "file" field; it
+// is parsed as it streams in.
+//
+// Query parameters:
+//   - kanji_column, romaji_column, english_column: header names of the word
+//     fields (default kanji, romaji, english)
+//   - parts_columns: comma separated "Header" or "Header:key" columns stored in
+//     parts (default: every other column, keyed by its header)
+//   - delimiter: comma, tab or semicolon (default tab for
+//     text/tab-separated-values bodies, comma otherwise)
+//   - group_id: add the imported words to this group
+//   - dry_run: parse and check the rows without writing anything
+func (h *ImportHandler) ImportWordsCSV(c *gin.Context) {
+	mapping := service.DefaultCSVColumnMapping()
+	mapping.Kanji = c.DefaultQuery("kanji_column", mapping.Kanji)
+	mapping.Romaji = c.DefaultQuery("romaji_column", mapping.Romaji)
+	mapping.English = c.DefaultQuery("english_column", mapping.English)
+	mapping.Parts = parsePartsColumns(c.Query("parts_columns"))
+
+	groupID, err := strconv.ParseInt(c.DefaultQuery("group_id", "0"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid dry_run value")
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	delimiter, err := parseDelimiter(c.Query("delimiter"), mediaType == "text/tab-separated-values")
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	body, err := uploadBody(c, mediaType)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.importService.ImportCSV(c.Request.Context(), service.ImportCSVParams{
+		Reader:    body,
+		Delimiter: delimiter,
+		Mapping:   mapping,
+		GroupID:   groupID,
+		DryRun:    dryRun,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGroupNotFound):
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrSmartGroup):
+			responses.ErrorResponse(c, http.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrInvalidImport):
+			responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to import words")
+		}
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, result)
+}
+
+// ExportGroupCSV handles GET /api/groups/:id/export.csv. Pass delimiter=tab
+// for TSV.
+func (h *ImportHandler) ExportGroupCSV(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+	delimiter, err := parseDelimiter(c.Query("delimiter"), false)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	contentType, extension := "text/csv; charset=utf-8", "csv"
+	if delimiter == '\t' {
+		contentType, extension = "text/tab-separated-values; charset=utf-8", "tsv"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="group-%d.%s"`, groupID, extension))
+
+	err = h.importService.ExportGroupCSV(c.Request.Context(), groupID, delimiter, c.Writer)
+	if err == nil {
+		return
+	}
+	if c.Writer.Written() {
+		// The rows are already streaming; all we can do is cut the response short
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	if errors.Is(err, service.ErrGroupNotFound) {
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to export group")
+}
+
+// uploadBody returns the uploaded file of a multipart request, or the
+// request body itself
+func uploadBody(c *gin.Context, mediaType string) (io.Reader, error) {
+	if mediaType != "multipart/form-data" {
+		return c.Request.Body, nil
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("invalid multipart body")
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("multipart body has no file field")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body")
+		}
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
+
+func parseDelimiter(value string, tabByDefault bool) (rune, error) {
+	switch strings.ToLower(value) {
+	case "":
+		if tabByDefault {
+			return '\t', nil
+		}
+		return ',', nil
+	case "comma", ",":
+		return ',', nil
+	case "tab", "\\t":
+		return '\t', nil
+	case "semicolon", ";":
+		return ';', nil
+	default:
+		return 0, fmt.Errorf("unsupported delimiter %q", value)
+	}
+}
+
+// parsePartsColumns parses "Header" and "Header:key" entries separated by commas
+func parsePartsColumns(value string) []service.CSVPartsColumn {
+	var columns []service.CSVPartsColumn
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		column, key, _ := strings.Cut(entry, ":")
+		columns = append(columns, service.CSVPartsColumn{
+			Column: strings.TrimSpace(column),
+			Key:    strings.TrimSpace(key),
+		})
+	}
+	return columns
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type KanjiHandler struct {
+	kanjiService *service.KanjiService
+}
+
+func NewKanjiHandler(kanjiService *service.KanjiService) *KanjiHandler {
+	return &KanjiHandler{
+		kanjiService: kanjiService,
+	}
+}
+
+// GetKanji handles GET /api/kanji/:char
+func (h *KanjiHandler) GetKanji(c *gin.Context) {
+	pageSize := parseInt(c.Query("page_size"), 10)
+	result, err := h.kanjiService.GetKanji(c.Request.Context(), c.Param("char"), parseInt(c.Query("page"), 1), pageSize)
+	if err != nil {
+		kanjiError(c, err, "Failed to fetch kanji")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"kanji": result.Kanji,
+		"words": result.Words,
+		"pagination": responses.Pagination{
+			CurrentPage:  result.CurrentPage,
+			TotalPages:   result.TotalPages,
+			TotalItems:   result.TotalItems,
+			ItemsPerPage: pageSize,
+		},
+	})
+}
+
+// GetWordKanji handles GET /api/words/:id/kanji
+func (h *KanjiHandler) GetWordKanji(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid word ID")
+		return
+	}
+
+	kanji, err := h.kanjiService.ListWordKanji(c.Request.Context(), wordID)
+	if err != nil {
+		kanjiError(c, err, "Failed to fetch word kanji")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{"kanji": kanji})
+}
+
+func kanjiError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrKanjiNotFound), errors.Is(err, service.ErrWordNotFound):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidKanji):
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		responses.ErrorResponse(c, http.StatusInternalServerError, message)
+	}
+}
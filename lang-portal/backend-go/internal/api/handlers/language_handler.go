@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type LanguageHandler struct {
+	languageService *service.LanguageService
+}
+
+func NewLanguageHandler(languageService *service.LanguageService) *LanguageHandler {
+	return &LanguageHandler{
+		languageService: languageService,
+	}
+}
+
+// ListLanguages handles GET /api/languages
+func (h *LanguageHandler) ListLanguages(c *gin.Context) {
+	languages, err := h.languageService.ListLanguages(c.Request.Context())
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch languages")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"languages": languages,
+	})
+}
@@ -21,15 +21,19 @@ type WordResponse struct {
 }
 
 type CreateWordRequest struct {
-	Kanji   string         `json:"kanji" binding:"required"`
-	Romaji  string         `json:"romaji" binding:"required"`
+	Kanji   string               `json:"kanji" binding:"required"`
+	Reading string               `json:"reading"` // kana; derived from the kanji, ruby or romaji when left out
+	Ruby    []models.RubySegment `json:"ruby"`    // laid out from the reading when left out
+	Romaji  string               `json:"romaji"`  // filled in from the kana reading when left out
 	English string         `json:"english" binding:"required"`
 	Parts   map[string]any `json:"parts" binding:"required"`
 }
 
 type UpdateWordRequest struct {
-	Kanji   string         `json:"kanji" binding:"required"`
-	Romaji  string         `json:"romaji" binding:"required"`
+	Kanji   string               `json:"kanji" binding:"required"`
+	Reading string               `json:"reading"`
+	Ruby    []models.RubySegment `json:"ruby"`
+	Romaji  string               `json:"romaji" binding:"required"`
 	English string         `json:"english" binding:"required"`
 	Parts   map[string]any `json:"parts" binding:"required"`
 }
@@ -52,10 +56,40 @@ type GroupResponse struct {
 
 type CreateGroupRequest struct {
 	Name string `json:"name" binding:"required"`
+	// Language defaults to ja
+	Language string `json:"language"`
+	// Filter, when given, makes a smart group of the words it matches
+	Filter *models.WordFilter `json:"filter"`
+	// ParentID, when given, puts the group last under another group
+	ParentID *int64 `json:"parent_id"`
 }
 
 type UpdateGroupRequest struct {
 	Name string `json:"name" binding:"required"`
+	// Filter replaces the filter of a smart group
+	Filter *models.WordFilter `json:"filter"`
+}
+
+// MoveGroupRequest places a group under another group, or at the top for
+// a null parent_id
+type MoveGroupRequest struct {
+	ParentID *int64 `json:"parent_id"`
+	// Position among the groups there, from 0; last when left out
+	Position *int `json:"position"`
+}
+
+// GroupWordsRequest lists the words to add to, remove from or reorder in a
+// group
+type GroupWordsRequest struct {
+	WordIDs []int64 `json:"word_ids" binding:"required"`
+	// Position, when adding, inserts the words there from 0 instead of last
+	Position *int `json:"position"`
+}
+
+// TransferWordsRequest lists the words to move or copy to another group
+type TransferWordsRequest struct {
+	TargetGroupID int64   `json:"target_group_id" binding:"required"`
+	WordIDs       []int64 `json:"word_ids" binding:"required"`
 }
 
 type ListActivitiesResponse struct {
@@ -88,6 +122,18 @@ type ListSessionsResponse struct {
 	} `json:"data"`
 }
 
+type RevisionsResponse struct {
+	Data struct {
+		Revisions  []*models.Revision `json:"revisions"`
+		Pagination struct {
+			CurrentPage  int `json:"current_page"`
+			TotalPages   int `json:"total_pages"`
+			TotalItems   int `json:"total_items"`
+			ItemsPerPage int `json:"items_per_page"`
+		} `json:"pagination"`
+	} `json:"data"`
+}
+
 type SessionResponse struct {
 	Data models.StudySession `json:"data"`
 }
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+// RecordChange attributes the writes of a request to the author named by
+// its X-Author header, for the reason given by X-Change-Reason. Both end up
+// in the revisions the writes record.
+func RecordChange() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		change := models.Change{
+			Author: strings.TrimSpace(c.GetHeader("X-Author")),
+			Reason: strings.TrimSpace(c.GetHeader("X-Change-Reason")),
+		}
+		if change.Author != "" || change.Reason != "" {
+			c.Request = c.Request.WithContext(models.WithChange(c.Request.Context(), change))
+		}
+		c.Next()
+	}
+}
+
+// parseRevisionParams reads the :id and :revision_id path parameters of a
+// revert
+func parseRevisionParams(c *gin.Context) (int64, int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return 0, 0, false
+	}
+	revisionID, err := strconv.ParseInt(c.Param("revision_id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid revision ID")
+		return 0, 0, false
+	}
+	return id, revisionID, true
+}
+
+func revisionsPage(result *service.ListRevisionsResult, pageSize int) gin.H {
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	return gin.H{
+		"revisions": result.Revisions,
+		"pagination": responses.Pagination{
+			CurrentPage:  result.CurrentPage,
+			TotalPages:   result.TotalPages,
+			TotalItems:   result.TotalItems,
+			ItemsPerPage: pageSize,
+		},
+	}
+}
+
+// historyError reports a failed history lookup
+func historyError(c *gin.Context, err error) {
+	if isNotFound(err) {
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+		return
+	}
+	responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch history")
+}
+
+// revertError reports a failed revert. A revert to a name another group has
+// taken since is a conflict, and one whose restored state no longer
+// validates a bad request.
+func revertError(c *gin.Context, err error) {
+	switch {
+	case isNotFound(err):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrNothingToRevert), errors.Is(err, service.ErrGroupExists):
+		responses.ErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, service.ErrRevisionNotFound) ||
+		errors.Is(err, service.ErrWordNotFound) ||
+		errors.Is(err, service.ErrGroupNotFound) ||
+		errors.Is(err, service.ErrActivityNotFound)
+}
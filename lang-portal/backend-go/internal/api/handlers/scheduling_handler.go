@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type SchedulingHandler struct {
+	schedulingService *service.SchedulingService
+}
+
+func NewSchedulingHandler(schedulingService *service.SchedulingService) *SchedulingHandler {
+	return &SchedulingHandler{
+		schedulingService: schedulingService,
+	}
+}
+
+type SetGroupSchedulerRequest struct {
+	Scheduler string `json:"scheduler" binding:"required"`
+}
+
+// GetReviewQueue handles GET /api/review_queue
+func (h *SchedulingHandler) GetReviewQueue(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.DefaultQuery("group_id", "0"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+	limit := parseInt(c.Query("limit"), 0)
+
+	words, err := h.schedulingService.GetReviewQueue(c.Request.Context(), groupID, c.Query("language"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrGroupNotFound) {
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch review queue")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"words": words,
+	})
+}
+
+// GetGroupScheduler handles GET /api/settings/groups/:id/scheduler
+func (h *SchedulingHandler) GetGroupScheduler(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	scheduler, err := h.schedulingService.GetGroupScheduler(c.Request.Context(), groupID)
+	if err != nil {
+		if errors.Is(err, service.ErrGroupNotFound) {
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch group scheduler")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"group_id":  groupID,
+		"scheduler": scheduler,
+		"available": h.schedulingService.Schedulers(),
+	})
+}
+
+// SetGroupScheduler handles PUT /api/settings/groups/:id/scheduler
+func (h *SchedulingHandler) SetGroupScheduler(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	var req SetGroupSchedulerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.schedulingService.SetGroupScheduler(c.Request.Context(), groupID, req.Scheduler)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGroupNotFound):
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrUnknownScheduler):
+			responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to update group scheduler")
+		}
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, result)
+}
+
+// ReplayGroup handles POST /api/settings/groups/:id/replay
+func (h *SchedulingHandler) ReplayGroup(c *gin.Context) {
+	groupID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid group ID")
+		return
+	}
+
+	result, err := h.schedulingService.ReplayGroup(c.Request.Context(), groupID)
+	if err != nil {
+		if errors.Is(err, service.ErrGroupNotFound) {
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to replay review history")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, result)
+}
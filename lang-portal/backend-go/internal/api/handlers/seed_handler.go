@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type SeedHandler struct {
+	seedService *service.SeedService
+}
+
+func NewSeedHandler(seedService *service.SeedService) *SeedHandler {
+	return &SeedHandler{
+		seedService: seedService,
+	}
+}
+
+type LoadSeedDataRequest struct {
+	Profile string `json:"profile"`
+}
+
+// LoadSeedData handles POST /api/settings/load_seed_data. The profile comes
+// from the JSON body or the profile query parameter and defaults to core-jlpt5.
+func (h *SeedHandler) LoadSeedData(c *gin.Context) {
+	var req LoadSeedDataRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			responses.ErrorResponse(c, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	if req.Profile == "" {
+		req.Profile = c.Query("profile")
+	}
+
+	report, err := h.seedService.LoadProfile(c.Request.Context(), req.Profile)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownSeedProfile) {
+			responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to load seed data: "+err.Error())
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Seed data has been loaded",
+		"summary": report,
+	})
+}
+
+// ListSeedProfiles handles GET /api/settings/seed_profiles
+func (h *SeedHandler) ListSeedProfiles(c *gin.Context) {
+	profiles, err := h.seedService.Profiles()
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to list seed profiles")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"profiles": profiles,
+		"default":  service.DefaultSeedProfile,
+	})
+}
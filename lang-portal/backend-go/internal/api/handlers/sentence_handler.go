@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type SentenceHandler struct {
+	sentenceService *service.SentenceService
+}
+
+func NewSentenceHandler(sentenceService *service.SentenceService) *SentenceHandler {
+	return &SentenceHandler{
+		sentenceService: sentenceService,
+	}
+}
+
+// SentenceRequest creates or replaces a sentence and the words it is linked to
+type SentenceRequest struct {
+	Japanese string  `json:"japanese" binding:"required"`
+	Reading  string  `json:"reading"`
+	English  string  `json:"english" binding:"required"`
+	Source   string  `json:"source"`
+	WordIDs  []int64 `json:"word_ids"`
+}
+
+func (r SentenceRequest) sentence() *models.Sentence {
+	return &models.Sentence{
+		Japanese: r.Japanese,
+		Reading:  r.Reading,
+		English:  r.English,
+		Source:   r.Source,
+		WordIDs:  r.WordIDs,
+	}
+}
+
+// ListSentences handles GET /api/sentences
+func (h *SentenceHandler) ListSentences(c *gin.Context) {
+	params := service.ListSentencesParams{
+		Query:    c.Query("q"),
+		Page:     parseInt(c.Query("page"), 1),
+		PageSize: parseInt(c.Query("page_size"), 10),
+	}
+
+	result, err := h.sentenceService.ListSentences(c.Request.Context(), params)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch sentences")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, sentencesPage(result, params.PageSize))
+}
+
+// GetWordSentences handles GET /api/words/:id/sentences
+func (h *SentenceHandler) GetWordSentences(c *gin.Context) {
+	wordID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid word ID")
+		return
+	}
+	pageSize := parseInt(c.Query("page_size"), 10)
+
+	result, err := h.sentenceService.ListWordSentences(c.Request.Context(), wordID, parseInt(c.Query("page"), 1), pageSize)
+	if err != nil {
+		sentenceError(c, err, "Failed to fetch word sentences")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, sentencesPage(result, pageSize))
+}
+
+// GetSentence handles GET /api/sentences/:id
+func (h *SentenceHandler) GetSentence(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid sentence ID")
+		return
+	}
+
+	sentence, err := h.sentenceService.GetSentence(c.Request.Context(), id)
+	if err != nil {
+		sentenceError(c, err, "Failed to fetch sentence")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, sentence)
+}
+
+// CreateSentence handles POST /api/sentences
+func (h *SentenceHandler) CreateSentence(c *gin.Context) {
+	var req SentenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	sentence := req.sentence()
+	if err := h.sentenceService.CreateSentence(c.Request.Context(), sentence); err != nil {
+		sentenceError(c, err, "Failed to create sentence")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusCreated, sentence)
+}
+
+// UpdateSentence handles PUT /api/sentences/:id
+func (h *SentenceHandler) UpdateSentence(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid sentence ID")
+		return
+	}
+
+	var req SentenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	sentence := req.sentence()
+	sentence.ID = id
+	if err := h.sentenceService.UpdateSentence(c.Request.Context(), sentence); err != nil {
+		sentenceError(c, err, "Failed to update sentence")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, sentence)
+}
+
+// DeleteSentence handles DELETE /api/sentences/:id
+func (h *SentenceHandler) DeleteSentence(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid sentence ID")
+		return
+	}
+
+	if err := h.sentenceService.DeleteSentence(c.Request.Context(), id); err != nil {
+		sentenceError(c, err, "Failed to delete sentence")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func sentencesPage(result *service.ListSentencesResult, pageSize int) gin.H {
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	return gin.H{
+		"sentences": result.Sentences,
+		"pagination": responses.Pagination{
+			CurrentPage:  result.CurrentPage,
+			TotalPages:   result.TotalPages,
+			TotalItems:   result.TotalItems,
+			ItemsPerPage: pageSize,
+		},
+	}
+}
+
+func sentenceError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrSentenceNotFound), errors.Is(err, service.ErrWordNotFound):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidSentence):
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		responses.ErrorResponse(c, http.StatusInternalServerError, message)
+	}
+}
@@ -152,6 +152,62 @@ func (h *StudyActivityHandler) DeleteActivity(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// GetActivityHistory godoc
+// @Summary List the revisions of a study activity
+// @Description Get the changes made to a study activity, newest first, with the activity before and after each change and who made it and why
+// @Tags study-activities
+// @Produce json
+// @Param id path int true "Activity ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Items per page (default: 10)"
+// @Success 200 {object} RevisionsResponse
+// @Router /api/study_activity/{id}/history [get]
+func (h *StudyActivityHandler) GetActivityHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity ID"})
+		return
+	}
+	page := parseInt(c.Query("page"), 1)
+	pageSize := parseInt(c.Query("page_size"), 10)
+
+	result, err := h.activityService.GetActivityHistory(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		historyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": revisionsPage(result, pageSize),
+	})
+}
+
+// RevertActivity godoc
+// @Summary Revert a study activity to a revision
+// @Description Restore a study activity to the state a revision left it in. The revert is recorded as a revision of its own.
+// @Tags study-activities
+// @Produce json
+// @Param id path int true "Activity ID"
+// @Param revision_id path int true "Revision ID"
+// @Success 200 {object} ActivityResponse
+// @Router /api/study_activity/{id}/revert/{revision_id} [post]
+func (h *StudyActivityHandler) RevertActivity(c *gin.Context) {
+	id, revisionID, ok := parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	activity, err := h.activityService.RevertActivity(c.Request.Context(), id, revisionID)
+	if err != nil {
+		revertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": activity,
+	})
+}
+
 // ListSessions handles GET /api/study_activity/:id/study_sessions
 func (h *StudyActivityHandler) ListSessions(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -229,14 +229,3 @@ func (h *StudySessionHandler) FullReset(c *gin.Context) {
 	
 	c.JSON(http.StatusOK, gin.H{"message": "Data reset successful"})
 }
-
-// LoadSeedData handles the request to load initial seed data
-func (h *StudySessionHandler) LoadSeedData(c *gin.Context) {
-	err := h.sessionService.LoadSeedData(c.Request.Context())
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load seed data: " + err.Error()})
-		return
-	}
-	
-	c.JSON(http.StatusOK, gin.H{"message": "Seed data loaded successfully"})
-} 
\ No newline at end of file
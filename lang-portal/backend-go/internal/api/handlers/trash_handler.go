@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-go/internal/responses"
+	"backend-go/internal/service"
+)
+
+type TrashHandler struct {
+	trashService *service.TrashService
+}
+
+func NewTrashHandler(trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// ListTrash handles GET /api/trash
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	pageSize := parseInt(c.Query("page_size"), 10)
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	result, err := h.trashService.ListTrash(c.Request.Context(), c.Query("type"), parseInt(c.Query("page"), 1), pageSize)
+	if err != nil {
+		trashError(c, err, "Failed to fetch trash")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, gin.H{
+		"items": result.Items,
+		"pagination": responses.Pagination{
+			CurrentPage:  result.CurrentPage,
+			TotalPages:   result.TotalPages,
+			TotalItems:   result.TotalItems,
+			ItemsPerPage: pageSize,
+		},
+	})
+}
+
+// RestoreItem handles POST /api/trash/:type/:id/restore
+func (h *TrashHandler) RestoreItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	item, err := h.trashService.Restore(c.Request.Context(), c.Param("type"), id)
+	if err != nil {
+		trashError(c, err, "Failed to restore item")
+		return
+	}
+
+	responses.SuccessResponse(c, http.StatusOK, item)
+}
+
+func trashError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrTrashItemNotFound):
+		responses.ErrorResponse(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidTrashType):
+		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+	default:
+		responses.ErrorResponse(c, http.StatusInternalServerError, message)
+	}
+}
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -30,14 +32,25 @@ func NewWordHandler(wordService *service.WordService) *WordHandler {
 // @Param page_size query int false "Items per page (default: 10)"
 // @Param sort_by query string false "Sort field (kanji, romaji, english, correct_count, wrong_count)"
 // @Param order query string false "Sort order (asc, desc)"
+// @Param group_id query int false "Only words in this group"
+// @Param language query string false "Only words of this language, e.g. ja or es"
+// @Param parts.{key} query string false "Filter on a parts key, e.g. parts.topic=food or parts.jlpt_lte=4"
+// @Param accuracy_lt query number false "Filter on a stat or column with an operator suffix (eq, ne, lt, lte, gt, gte, like, in)"
 // @Success 200 {object} ListWordsResponse
 // @Router /api/words [get]
 func (h *WordHandler) ListWords(c *gin.Context) {
+	filter, err := service.ParseWordFilter(c.Request.URL.Query(), "page", "page_size", "sort_by", "order")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	params := service.ListWordsParams{
 		Page:     parseInt(c.Query("page"), 1),
 		PageSize: parseInt(c.Query("page_size"), 10),
 		SortBy:   c.Query("sort_by"),
 		Order:    c.Query("order"),
+		Filter:   filter,
 	}
 
 	result, err := h.wordService.ListWords(c.Request.Context(), params)
@@ -59,6 +72,49 @@ func (h *WordHandler) ListWords(c *gin.Context) {
 	})
 }
 
+// SearchWords godoc
+// @Summary Search words
+// @Description Full-text search across kanji, romaji, english and parts, ranked by relevance
+// @Tags words
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text"
+// @Param language query string false "Only words of this language, e.g. ja"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Items per page (default: 10)"
+// @Success 200 {object} ListWordsResponse
+// @Router /api/words/search [get]
+func (h *WordHandler) SearchWords(c *gin.Context) {
+	params := service.SearchWordsParams{
+		Query:    c.Query("q"),
+		Language: c.Query("language"),
+		Page:     parseInt(c.Query("page"), 1),
+		PageSize: parseInt(c.Query("page_size"), 10),
+	}
+	if strings.TrimSpace(params.Query) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "query parameter q is required"})
+		return
+	}
+
+	result, err := h.wordService.SearchWords(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"words": result.Words,
+			"pagination": gin.H{
+				"current_page":   result.CurrentPage,
+				"total_pages":    result.TotalPages,
+				"total_items":    result.TotalItems,
+				"items_per_page": params.PageSize,
+			},
+		},
+	})
+}
+
 // GetWord godoc
 // @Summary Get a word by ID
 // @Description Get a single word by its ID with review statistics
@@ -88,7 +144,7 @@ func (h *WordHandler) GetWord(c *gin.Context) {
 
 // CreateWord godoc
 // @Summary Create a new word
-// @Description Create a new vocabulary word
+// @Description Create a new vocabulary word. Romaji may be left out when the word has a kana reading (parts.reading, or kanji written in kana), and is checked against that reading otherwise.
 // @Tags words
 // @Accept json
 // @Produce json
@@ -102,14 +158,13 @@ func (h *WordHandler) CreateWord(c *gin.Context) {
 		return
 	}
 
-	if err := h.wordService.CreateWord(c.Request.Context(), &word); err != nil {
+	warnings, err := h.wordService.CreateWord(c.Request.Context(), &word)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"data": word,
-	})
+	c.JSON(http.StatusCreated, wordWriteResponse(&word, warnings))
 }
 
 // UpdateWord godoc
@@ -136,14 +191,17 @@ func (h *WordHandler) UpdateWord(c *gin.Context) {
 	}
 	word.ID = id
 
-	if err := h.wordService.UpdateWord(c.Request.Context(), &word); err != nil {
+	warnings, err := h.wordService.UpdateWord(c.Request.Context(), &word)
+	if errors.Is(err, service.ErrWordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data": word,
-	})
+	c.JSON(http.StatusOK, wordWriteResponse(&word, warnings))
 }
 
 // DeleteWord godoc
@@ -170,6 +228,70 @@ func (h *WordHandler) DeleteWord(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// GetWordHistory godoc
+// @Summary List the revisions of a word
+// @Description Get the changes made to a word, newest first, with the word before and after each change and who made it and why
+// @Tags words
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Items per page (default: 10)"
+// @Success 200 {object} RevisionsResponse
+// @Router /api/words/{id}/history [get]
+func (h *WordHandler) GetWordHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid word ID"})
+		return
+	}
+	page := parseInt(c.Query("page"), 1)
+	pageSize := parseInt(c.Query("page_size"), 10)
+
+	result, err := h.wordService.GetWordHistory(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		historyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": revisionsPage(result, pageSize),
+	})
+}
+
+// RevertWord godoc
+// @Summary Revert a word to a revision
+// @Description Restore a word to the state a revision left it in. The revert is recorded as a revision of its own.
+// @Tags words
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param revision_id path int true "Revision ID"
+// @Success 200 {object} WordResponse
+// @Router /api/words/{id}/revert/{revision_id} [post]
+func (h *WordHandler) RevertWord(c *gin.Context) {
+	id, revisionID, ok := parseRevisionParams(c)
+	if !ok {
+		return
+	}
+
+	word, warnings, err := h.wordService.RevertWord(c.Request.Context(), id, revisionID)
+	if err != nil {
+		revertError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wordWriteResponse(word, warnings))
+}
+
+// wordWriteResponse wraps a created or updated word, adding the romaji
+// warnings when there are any
+func wordWriteResponse(word *models.Word, warnings []string) gin.H {
+	response := gin.H{"data": word}
+	if len(warnings) > 0 {
+		response["warnings"] = warnings
+	}
+	return response
+}
+
 func parseInt(str string, defaultValue int) int {
 	if str == "" {
 		return defaultValue
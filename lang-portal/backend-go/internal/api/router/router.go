@@ -13,6 +13,16 @@ func SetupRouter(
 	groupService *service.GroupService,
 	activityService *service.StudyActivityService,
 	sessionService *service.StudySessionService,
+	schedulingService *service.SchedulingService,
+	seedService *service.SeedService,
+	importService *service.ImportService,
+	ankiService *service.AnkiService,
+	conjugationService *service.ConjugationService,
+	sentenceService *service.SentenceService,
+	kanjiService *service.KanjiService,
+	dictionaryService *service.DictionaryService,
+	languageService *service.LanguageService,
+	trashService *service.TrashService,
 ) *gin.Engine {
 	router := gin.Default()
 
@@ -21,19 +31,73 @@ func SetupRouter(
 	groupHandler := handlers.NewGroupHandler(groupService)
 	activityHandler := handlers.NewStudyActivityHandler(activityService)
 	sessionHandler := handlers.NewStudySessionHandler(sessionService)
+	schedulingHandler := handlers.NewSchedulingHandler(schedulingService)
+	seedHandler := handlers.NewSeedHandler(seedService)
+	importHandler := handlers.NewImportHandler(importService)
+	ankiHandler := handlers.NewAnkiHandler(ankiService)
+	conjugationHandler := handlers.NewConjugationHandler(conjugationService)
+	sentenceHandler := handlers.NewSentenceHandler(sentenceService)
+	kanjiHandler := handlers.NewKanjiHandler(kanjiService)
+	dictionaryHandler := handlers.NewDictionaryHandler(dictionaryService)
+	languageHandler := handlers.NewLanguageHandler(languageService)
+	trashHandler := handlers.NewTrashHandler(trashService)
 
 	// API group
 	api := router.Group("/api")
+	api.Use(handlers.RecordChange())
 	{
 		// Words routes
 		api.GET("/words", wordHandler.ListWords)
+		api.GET("/words/search", wordHandler.SearchWords)
+		api.POST("/words", wordHandler.CreateWord)
+		api.POST("/words/import.csv", importHandler.ImportWordsCSV)
+		api.POST("/words/from_dictionary/:entry_id", dictionaryHandler.CreateWordFromDictionary)
 		api.GET("/words/:id", wordHandler.GetWord)
+		api.GET("/words/:id/conjugations", conjugationHandler.GetWordConjugations)
+		api.GET("/words/:id/sentences", sentenceHandler.GetWordSentences)
+		api.GET("/words/:id/kanji", kanjiHandler.GetWordKanji)
+		api.PUT("/words/:id", wordHandler.UpdateWord)
+		api.GET("/words/:id/history", wordHandler.GetWordHistory)
+		api.POST("/words/:id/revert/:revision_id", wordHandler.RevertWord)
+		api.DELETE("/words/:id", wordHandler.DeleteWord)
+
+		// Languages routes
+		api.GET("/languages", languageHandler.ListLanguages)
+
+		// Kanji and dictionary routes
+		api.GET("/kanji/:char", kanjiHandler.GetKanji)
+		api.GET("/dictionary", dictionaryHandler.LookupDictionary)
+
+		// Sentences routes
+		api.GET("/sentences", sentenceHandler.ListSentences)
+		api.GET("/sentences/:id", sentenceHandler.GetSentence)
+		api.POST("/sentences", sentenceHandler.CreateSentence)
+		api.PUT("/sentences/:id", sentenceHandler.UpdateSentence)
+		api.DELETE("/sentences/:id", sentenceHandler.DeleteSentence)
 
 		// Groups routes
 		api.GET("/groups", groupHandler.ListGroups)
+		api.POST("/groups", groupHandler.CreateGroup)
 		api.GET("/group/:id", groupHandler.GetGroup)
+		api.PUT("/group/:id", groupHandler.UpdateGroup)
+		api.GET("/group/:id/tree", groupHandler.GetGroupTree)
+		api.PUT("/group/:id/parent", groupHandler.MoveGroup)
 		api.GET("/group/:id/words", groupHandler.GetGroupWords)
+		api.POST("/group/:id/words", groupHandler.AddGroupWords)
+		api.DELETE("/group/:id/words", groupHandler.RemoveGroupWords)
+		api.PUT("/group/:id/words/order", groupHandler.ReorderGroupWords)
+		api.POST("/group/:id/words/move", groupHandler.MoveGroupWords)
+		api.POST("/group/:id/words/copy", groupHandler.CopyGroupWords)
 		api.GET("/group/:id/study_sessions", groupHandler.GetGroupStudySessions)
+		api.GET("/group/:id/export.apkg", ankiHandler.ExportGroupAnki)
+		api.POST("/groups/:id/import", importHandler.ImportGroupWords)
+		api.POST("/groups/import.apkg", ankiHandler.ImportAnki)
+		api.GET("/groups/:id/export.csv", importHandler.ExportGroupCSV)
+		api.GET("/group/:id/conjugation_drill", conjugationHandler.GetConjugationDrill)
+		api.GET("/group/:id/conjugation_stats", conjugationHandler.GetConjugationStats)
+		api.GET("/group/:id/history", groupHandler.GetGroupHistory)
+		api.POST("/group/:id/revert/:revision_id", groupHandler.RevertGroup)
+		api.DELETE("/group/:id", groupHandler.DeleteGroup)
 
 		// Dashboard routes
 		dashboard := api.Group("/dashboard")
@@ -47,17 +111,33 @@ func SetupRouter(
 		api.GET("/study_activity/:id", activityHandler.GetActivity)
 		api.GET("/study_activity/:id/study_sessions", activityHandler.ListSessions)
 		api.POST("/study_activities", activityHandler.CreateActivity)
+		api.PUT("/study_activity/:id", activityHandler.UpdateActivity)
+		api.GET("/study_activity/:id/history", activityHandler.GetActivityHistory)
+		api.POST("/study_activity/:id/revert/:revision_id", activityHandler.RevertActivity)
+		api.DELETE("/study_activity/:id", activityHandler.DeleteActivity)
 
 		// Study sessions routes
 		api.GET("/study_session/:id/words", sessionHandler.GetSessionWords)
 		api.POST("/study_sessions", sessionHandler.CreateSession)
 		api.POST("/study_sessions/:id/review", sessionHandler.AddReview)
+		api.POST("/study_sessions/:id/conjugation_review", conjugationHandler.AddConjugationReview)
+
+		// Spaced-repetition routes
+		api.GET("/review_queue", schedulingHandler.GetReviewQueue)
+
+		// Trash routes
+		api.GET("/trash", trashHandler.ListTrash)
+		api.POST("/trash/:type/:id/restore", trashHandler.RestoreItem)
 
 		// Settings routes
 		settings := api.Group("/settings")
 		{
 			settings.POST("/full_reset", sessionHandler.FullReset)
-			settings.POST("/load_seed_data", sessionHandler.LoadSeedData)
+			settings.POST("/load_seed_data", seedHandler.LoadSeedData)
+			settings.GET("/seed_profiles", seedHandler.ListSeedProfiles)
+			settings.GET("/groups/:id/scheduler", schedulingHandler.GetGroupScheduler)
+			settings.PUT("/groups/:id/scheduler", schedulingHandler.SetGroupScheduler)
+			settings.POST("/groups/:id/replay", schedulingHandler.ReplayGroup)
 		}
 	}
 
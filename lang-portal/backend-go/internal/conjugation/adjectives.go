@@ -0,0 +1,59 @@
+package conjugation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// yoiCompounds are adjectives ending in いい that, like いい, conjugate from
+// よい. Adjectives with が before いい (頭がいい) are too; other adjectives
+// ending in いい, like かわいい, are regular.
+var yoiCompounds = []string{
+	"かっこいい", "かっこういい", "格好いい",
+	"気持ちいい", "きもちいい",
+	"ちょうどいい", "丁度いい",
+}
+
+func iAdjective(word string) (map[Form]string, error) {
+	stem, ok := strings.CutSuffix(word, "い")
+	if !ok || stem == "" {
+		return nil, fmt.Errorf("%w: i-adjective %s does not end in い", ErrNotConjugable, word)
+	}
+	// いい conjugates from よい
+	if word == "いい" || strings.HasSuffix(word, "がいい") || slices.Contains(yoiCompounds, word) {
+		stem = strings.TrimSuffix(word, "いい") + "よ"
+	}
+
+	return map[Form]string{
+		Plain:              word,
+		PlainNegative:      stem + "くない",
+		PlainPast:          stem + "かった",
+		PlainPastNegative:  stem + "くなかった",
+		Polite:             word + "です",
+		PoliteNegative:     stem + "くないです",
+		PolitePast:         stem + "かったです",
+		PolitePastNegative: stem + "くなかったです",
+		Te:                 stem + "くて",
+	}, nil
+}
+
+func naAdjective(word string) (map[Form]string, error) {
+	// The attributive な is sometimes stored with the word
+	stem := strings.TrimSuffix(word, "な")
+	if stem == "" {
+		return nil, fmt.Errorf("%w: empty na-adjective", ErrNotConjugable)
+	}
+
+	return map[Form]string{
+		Plain:              stem + "だ",
+		PlainNegative:      stem + "じゃない",
+		PlainPast:          stem + "だった",
+		PlainPastNegative:  stem + "じゃなかった",
+		Polite:             stem + "です",
+		PoliteNegative:     stem + "じゃありません",
+		PolitePast:         stem + "でした",
+		PolitePastNegative: stem + "じゃありませんでした",
+		Te:                 stem + "で",
+	}, nil
+}
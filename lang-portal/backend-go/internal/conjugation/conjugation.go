@@ -0,0 +1,147 @@
+// Package conjugation inflects Japanese verbs and adjectives. It works on
+// the written form and the kana reading alike, as both end in the same kana.
+package conjugation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Class is the conjugation class of a word
+type Class string
+
+const (
+	// Ichidan verbs drop る: 食べる, 食べます
+	Ichidan Class = "ichidan"
+	// Godan verbs change the vowel of their last kana: 飲む, 飲みます
+	Godan Class = "godan"
+	// Suru is する and nouns made into verbs with it: 勉強する
+	Suru Class = "suru"
+	// Kuru is 来る
+	Kuru Class = "kuru"
+	// IAdjective adjectives end in い: 高い, 高くない
+	IAdjective Class = "i-adjective"
+	// NaAdjective adjectives take だ and な: 静か, 静かだ
+	NaAdjective Class = "na-adjective"
+)
+
+// Form is a conjugated form
+type Form string
+
+const (
+	Plain              Form = "plain"
+	PlainNegative      Form = "plain_negative"
+	PlainPast          Form = "plain_past"
+	PlainPastNegative  Form = "plain_past_negative"
+	Polite             Form = "polite"
+	PoliteNegative     Form = "polite_negative"
+	PolitePast         Form = "polite_past"
+	PolitePastNegative Form = "polite_past_negative"
+	Te                 Form = "te"
+	Potential          Form = "potential"
+	Passive            Form = "passive"
+	Causative          Form = "causative"
+	Volitional         Form = "volitional"
+	PoliteVolitional   Form = "polite_volitional"
+)
+
+var (
+	// ErrUnknownClass is returned for a verb or adjective type that is not
+	// one of the classes
+	ErrUnknownClass = errors.New("unknown conjugation class")
+	// ErrNotConjugable is returned when a word does not have the ending its
+	// class requires, e.g. an ichidan verb not ending in る
+	ErrNotConjugable = errors.New("word cannot be conjugated")
+)
+
+var (
+	verbForms = []Form{
+		Plain, PlainNegative, PlainPast, PlainPastNegative,
+		Polite, PoliteNegative, PolitePast, PolitePastNegative,
+		Te, Potential, Passive, Causative, Volitional, PoliteVolitional,
+	}
+	adjectiveForms = []Form{
+		Plain, PlainNegative, PlainPast, PlainPastNegative,
+		Polite, PoliteNegative, PolitePast, PolitePastNegative,
+		Te,
+	}
+)
+
+// Forms returns the forms of a class in the order Conjugate returns them
+func Forms(class Class) []Form {
+	switch class {
+	case IAdjective, NaAdjective:
+		return adjectiveForms
+	case Ichidan, Godan, Suru, Kuru:
+		return verbForms
+	default:
+		return nil
+	}
+}
+
+// ParseClass returns the class named by a verb_type or adjective_type, which
+// may use the common alternative names (ru-verb, u-verb, i-adj). The
+// dictionary form of the word tells する from 来る for "irregular".
+func ParseClass(name, dictionaryForm string) (Class, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "ichidan", "ru-verb", "ru":
+		return Ichidan, nil
+	case "godan", "u-verb", "u":
+		return Godan, nil
+	case "suru", "suru-verb", "suru verb":
+		return Suru, nil
+	case "kuru":
+		return Kuru, nil
+	case "irregular":
+		switch {
+		case strings.HasSuffix(dictionaryForm, "する"):
+			return Suru, nil
+		case strings.HasSuffix(dictionaryForm, "来る"), strings.HasSuffix(dictionaryForm, "くる"):
+			return Kuru, nil
+		}
+		return "", fmt.Errorf("%w: irregular verb %s is neither する nor 来る", ErrNotConjugable, dictionaryForm)
+	case "i-adjective", "i-adj", "i":
+		return IAdjective, nil
+	case "na-adjective", "na-adj", "na":
+		return NaAdjective, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownClass, name)
+	}
+}
+
+// Inflection is one conjugated form of a word
+type Inflection struct {
+	Form Form
+	Text string
+}
+
+// Conjugate returns every form of word, a dictionary form written in kanji
+// and kana or in kana only, in the order of Forms
+func Conjugate(word string, class Class) ([]Inflection, error) {
+	word = strings.TrimSpace(word)
+	var texts map[Form]string
+	var err error
+	switch class {
+	case IAdjective:
+		texts, err = iAdjective(word)
+	case NaAdjective:
+		texts, err = naAdjective(word)
+	case Ichidan, Godan, Suru, Kuru:
+		var stems *verbStems
+		if stems, err = verb(word, class); err == nil {
+			texts = stems.forms()
+		}
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnknownClass, class)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inflections := make([]Inflection, 0, len(texts))
+	for _, form := range Forms(class) {
+		inflections = append(inflections, Inflection{Form: form, Text: texts[form]})
+	}
+	return inflections, nil
+}
@@ -0,0 +1,134 @@
+package conjugation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConjugate(t *testing.T) {
+	tests := []struct {
+		word  string
+		class Class
+		want  map[Form]string
+	}{
+		{"食べる", Ichidan, map[Form]string{
+			Plain: "食べる", PlainNegative: "食べない", PlainPast: "食べた", PlainPastNegative: "食べなかった",
+			Polite: "食べます", PoliteNegative: "食べません", PolitePast: "食べました", PolitePastNegative: "食べませんでした",
+			Te: "食べて", Potential: "食べられる", Passive: "食べられる", Causative: "食べさせる",
+			Volitional: "食べよう", PoliteVolitional: "食べましょう",
+		}},
+		{"のむ", Godan, map[Form]string{
+			PlainNegative: "のまない", PlainPast: "のんだ", Polite: "のみます", Te: "のんで",
+			Potential: "のめる", Passive: "のまれる", Causative: "のませる", Volitional: "のもう",
+		}},
+		{"買う", Godan, map[Form]string{PlainNegative: "買わない", Te: "買って", Passive: "買われる", Volitional: "買おう"}},
+		{"書く", Godan, map[Form]string{Te: "書いて", PlainPast: "書いた", Potential: "書ける"}},
+		{"泳ぐ", Godan, map[Form]string{Te: "泳いで", PlainPast: "泳いだ"}},
+		{"話す", Godan, map[Form]string{Te: "話して", Polite: "話します"}},
+		{"待つ", Godan, map[Form]string{Te: "待って", PlainNegative: "待たない", Polite: "待ちます"}},
+		{"死ぬ", Godan, map[Form]string{Te: "死んで"}},
+		{"遊ぶ", Godan, map[Form]string{Te: "遊んで", Volitional: "遊ぼう"}},
+		{"帰る", Godan, map[Form]string{Te: "帰って", PlainNegative: "帰らない", Polite: "帰ります"}},
+		{"行く", Godan, map[Form]string{Te: "行って", PlainPast: "行った", Polite: "行きます"}},
+		{"ある", Godan, map[Form]string{PlainNegative: "ない", PlainPastNegative: "なかった", Polite: "あります"}},
+		{"問う", Godan, map[Form]string{Te: "問うて", PlainPast: "問うた", PlainNegative: "問わない", Polite: "問います"}},
+		{"こう", Godan, map[Form]string{Te: "こうて", PlainPast: "こうた"}},
+		{"する", Suru, map[Form]string{
+			PlainNegative: "しない", Polite: "します", Te: "して", Potential: "できる",
+			Passive: "される", Causative: "させる", Volitional: "しよう",
+		}},
+		{"勉強", Suru, map[Form]string{Plain: "勉強する", Polite: "勉強します", Potential: "勉強できる"}},
+		{"愛する", Suru, map[Form]string{Polite: "愛します", Potential: "愛せる"}},
+		{"あいする", Suru, map[Form]string{Potential: "あいせる"}},
+		{"来る", Kuru, map[Form]string{
+			PlainNegative: "来ない", Polite: "来ます", Te: "来て", Potential: "来られる", Volitional: "来よう",
+		}},
+		{"くる", Kuru, map[Form]string{
+			PlainNegative: "こない", PlainPastNegative: "こなかった", Polite: "きます", Te: "きて",
+			Potential: "こられる", Causative: "こさせる", Volitional: "こよう",
+		}},
+		{"高い", IAdjective, map[Form]string{
+			Plain: "高い", PlainNegative: "高くない", PlainPast: "高かった", PlainPastNegative: "高くなかった",
+			Polite: "高いです", PoliteNegative: "高くないです", PolitePast: "高かったです",
+			PolitePastNegative: "高くなかったです", Te: "高くて",
+		}},
+		{"いい", IAdjective, map[Form]string{Plain: "いい", PlainNegative: "よくない", PlainPast: "よかった"}},
+		{"かっこいい", IAdjective, map[Form]string{Plain: "かっこいい", PlainNegative: "かっこよくない", PlainPast: "かっこよかった", Te: "かっこよくて"}},
+		{"頭がいい", IAdjective, map[Form]string{PlainNegative: "頭がよくない", PolitePast: "頭がよかったです"}},
+		{"かわいい", IAdjective, map[Form]string{PlainNegative: "かわいくない", PlainPast: "かわいかった"}},
+		{"静か", NaAdjective, map[Form]string{
+			Plain: "静かだ", PlainNegative: "静かじゃない", PlainPast: "静かだった", PlainPastNegative: "静かじゃなかった",
+			Polite: "静かです", PoliteNegative: "静かじゃありません", PolitePast: "静かでした",
+			PolitePastNegative: "静かじゃありませんでした", Te: "静かで",
+		}},
+		{"きれいな", NaAdjective, map[Form]string{Plain: "きれいだ"}},
+	}
+
+	for _, tt := range tests {
+		inflections, err := Conjugate(tt.word, tt.class)
+		if err != nil {
+			t.Errorf("Conjugate(%q, %s) error = %v", tt.word, tt.class, err)
+			continue
+		}
+		if len(inflections) != len(Forms(tt.class)) {
+			t.Errorf("Conjugate(%q, %s) returned %d forms, want %d", tt.word, tt.class, len(inflections), len(Forms(tt.class)))
+		}
+		got := make(map[Form]string)
+		for _, inflection := range inflections {
+			got[inflection.Form] = inflection.Text
+		}
+		for form, want := range tt.want {
+			if got[form] != want {
+				t.Errorf("Conjugate(%q, %s) %s = %q, want %q", tt.word, tt.class, form, got[form], want)
+			}
+		}
+	}
+}
+
+func TestConjugateErrors(t *testing.T) {
+	tests := []struct {
+		word  string
+		class Class
+	}{
+		{"食べ", Ichidan},
+		{"犬", Godan},
+		{"静か", IAdjective},
+		{"見る", Kuru},
+	}
+
+	for _, tt := range tests {
+		if _, err := Conjugate(tt.word, tt.class); !errors.Is(err, ErrNotConjugable) {
+			t.Errorf("Conjugate(%q, %s) error = %v, want ErrNotConjugable", tt.word, tt.class, err)
+		}
+	}
+}
+
+func TestParseClass(t *testing.T) {
+	tests := []struct {
+		name, word string
+		want       Class
+	}{
+		{"ichidan", "食べる", Ichidan},
+		{"ru-verb", "食べる", Ichidan},
+		{"u-verb", "飲む", Godan},
+		{"irregular", "する", Suru},
+		{"irregular", "勉強する", Suru},
+		{"irregular", "来る", Kuru},
+		{"i-adjective", "高い", IAdjective},
+		{"na-adjective", "静か", NaAdjective},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseClass(tt.name, tt.word)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseClass(%q, %q) = %q, %v, want %q", tt.name, tt.word, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParseClass("noun", "犬"); !errors.Is(err, ErrUnknownClass) {
+		t.Errorf("ParseClass(noun) error = %v, want ErrUnknownClass", err)
+	}
+	if _, err := ParseClass("irregular", "行く"); !errors.Is(err, ErrNotConjugable) {
+		t.Errorf("ParseClass(irregular, 行く) error = %v, want ErrNotConjugable", err)
+	}
+}
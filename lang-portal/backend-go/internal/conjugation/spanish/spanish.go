@@ -0,0 +1,162 @@
+// Package spanish conjugates regular Spanish verbs in the simple indicative
+// tenses, including the spelling changes that keep their pronunciation
+// (busqué, cojo). Stem-changing and irregular verbs are not conjugated.
+package spanish
+
+import (
+	"fmt"
+	"strings"
+
+	"backend-go/internal/conjugation"
+)
+
+// Classes of regular verbs, named after the ending of their infinitive
+const (
+	ArVerb conjugation.Class = "ar"
+	ErVerb conjugation.Class = "er"
+	IrVerb conjugation.Class = "ir"
+)
+
+// Forms that are not conjugated for a person
+const (
+	Infinitive     conjugation.Form = "infinitive"
+	Gerund         conjugation.Form = "gerund"
+	PastParticiple conjugation.Form = "past_participle"
+)
+
+// tenses are conjugated for each of persons, e.g. present_yo
+var (
+	tenses  = []string{"present", "preterite", "imperfect", "future", "conditional"}
+	persons = []string{"yo", "tu", "el", "nosotros", "vosotros", "ellos"}
+)
+
+// endings of each tense by class, in the order of persons. The future and
+// conditional endings are added to the infinitive, the others to the stem.
+var endings = map[string]map[conjugation.Class][]string{
+	"present": {
+		ArVerb: {"o", "as", "a", "amos", "áis", "an"},
+		ErVerb: {"o", "es", "e", "emos", "éis", "en"},
+		IrVerb: {"o", "es", "e", "imos", "ís", "en"},
+	},
+	"preterite": {
+		ArVerb: {"é", "aste", "ó", "amos", "asteis", "aron"},
+		ErVerb: {"í", "iste", "ió", "imos", "isteis", "ieron"},
+		IrVerb: {"í", "iste", "ió", "imos", "isteis", "ieron"},
+	},
+	"imperfect": {
+		ArVerb: {"aba", "abas", "aba", "ábamos", "abais", "aban"},
+		ErVerb: {"ía", "ías", "ía", "íamos", "íais", "ían"},
+		IrVerb: {"ía", "ías", "ía", "íamos", "íais", "ían"},
+	},
+	"future": {
+		"": {"é", "ás", "á", "emos", "éis", "án"},
+	},
+	"conditional": {
+		"": {"ía", "ías", "ía", "íamos", "íais", "ían"},
+	},
+}
+
+// Forms returns every form in the order Conjugate returns them
+func Forms() []conjugation.Form {
+	forms := []conjugation.Form{Infinitive, Gerund, PastParticiple}
+	for _, tense := range tenses {
+		for _, person := range persons {
+			forms = append(forms, conjugation.Form(tense+"_"+person))
+		}
+	}
+	return forms
+}
+
+// ParseClass returns the class of a verb whose verb_type is name, which is
+// regular or the ending of the infinitive (ar, -ar). Irregular and
+// stem-changing verbs are reported as not conjugable.
+func ParseClass(name, infinitive string) (conjugation.Class, error) {
+	name = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "-")
+	switch name {
+	case "regular", "ar", "er", "ir":
+	case "irregular", "stem-changing", "stem changing":
+		return "", fmt.Errorf("%w: %s verb %s", conjugation.ErrNotConjugable, name, infinitive)
+	default:
+		return "", fmt.Errorf("%w: %q", conjugation.ErrUnknownClass, name)
+	}
+
+	class := classOf(strings.ToLower(strings.TrimSpace(infinitive)))
+	if class == "" {
+		return "", fmt.Errorf("%w: %s does not end in -ar, -er or -ir", conjugation.ErrNotConjugable, infinitive)
+	}
+	if name != "regular" && conjugation.Class(name) != class {
+		return "", fmt.Errorf("%w: %s is not an -%s verb", conjugation.ErrNotConjugable, infinitive, name)
+	}
+	return class, nil
+}
+
+func classOf(infinitive string) conjugation.Class {
+	for _, class := range []conjugation.Class{ArVerb, ErVerb, IrVerb} {
+		if stem, ok := strings.CutSuffix(infinitive, string(class)); ok && stem != "" {
+			return class
+		}
+	}
+	return ""
+}
+
+// Conjugate returns every form of a regular verb in the order of Forms
+func Conjugate(infinitive string, class conjugation.Class) ([]conjugation.Inflection, error) {
+	infinitive = strings.ToLower(strings.TrimSpace(infinitive))
+	if classOf(infinitive) != class {
+		return nil, fmt.Errorf("%w: %s is not an -%s verb", conjugation.ErrNotConjugable, infinitive, class)
+	}
+	stem := strings.TrimSuffix(infinitive, string(class))
+	// -er and -ir stems ending in a vowel other than the silent u of gu take
+	// y and accents (leyó, construyo), which is beyond regular conjugation
+	if class != ArVerb && strings.ContainsAny(stem[len(stem)-1:], "aeiou") && !strings.HasSuffix(stem, "gu") {
+		return nil, fmt.Errorf("%w: %s takes the spelling of leer or oír", conjugation.ErrNotConjugable, infinitive)
+	}
+
+	participle, gerund := "ado", "ando"
+	if class != ArVerb {
+		participle, gerund = "ido", "iendo"
+	}
+	inflections := []conjugation.Inflection{
+		{Form: Infinitive, Text: infinitive},
+		{Form: Gerund, Text: stem + gerund},
+		{Form: PastParticiple, Text: stem + participle},
+	}
+
+	for _, tense := range tenses {
+		base, byClass := stem, endings[tense][class]
+		if byClass == nil {
+			base, byClass = infinitive, endings[tense][""]
+		}
+		for i, person := range persons {
+			text := base + byClass[i]
+			if i == 0 {
+				text = respell(text, class, tense)
+			}
+			inflections = append(inflections, conjugation.Inflection{
+				Form: conjugation.Form(tense + "_" + person),
+				Text: text,
+			})
+		}
+	}
+	return inflections, nil
+}
+
+// respell keeps the sound of the last consonant of the stem in the first
+// person forms where the ending would change it: buscar, busqué; llegar,
+// llegué; averiguar, averigüé; empezar, empecé; coger, cojo; distinguir,
+// distingo; vencer, venzo
+func respell(text string, class conjugation.Class, tense string) string {
+	var changes [][2]string
+	switch {
+	case class == ArVerb && tense == "preterite":
+		changes = [][2]string{{"cé", "qué"}, {"gué", "güé"}, {"gé", "gué"}, {"zé", "cé"}}
+	case class != ArVerb && tense == "present":
+		changes = [][2]string{{"guo", "go"}, {"go", "jo"}, {"nco", "nzo"}, {"rco", "rzo"}}
+	}
+	for _, change := range changes {
+		if stem, ok := strings.CutSuffix(text, change[0]); ok {
+			return stem + change[1]
+		}
+	}
+	return text
+}
@@ -0,0 +1,105 @@
+package spanish
+
+import (
+	"errors"
+	"testing"
+
+	"backend-go/internal/conjugation"
+)
+
+func TestConjugate(t *testing.T) {
+	tests := []struct {
+		verb  string
+		class conjugation.Class
+		want  map[conjugation.Form]string
+	}{
+		{"hablar", ArVerb, map[conjugation.Form]string{
+			Infinitive: "hablar", Gerund: "hablando", PastParticiple: "hablado",
+			"present_yo": "hablo", "present_tu": "hablas", "present_el": "habla",
+			"present_nosotros": "hablamos", "present_vosotros": "habláis", "present_ellos": "hablan",
+			"preterite_yo": "hablé", "preterite_el": "habló", "preterite_ellos": "hablaron",
+			"imperfect_nosotros": "hablábamos", "future_tu": "hablarás", "conditional_ellos": "hablarían",
+		}},
+		{"comer", ErVerb, map[conjugation.Form]string{
+			Gerund: "comiendo", PastParticiple: "comido",
+			"present_nosotros": "comemos", "present_vosotros": "coméis",
+			"preterite_yo": "comí", "preterite_el": "comió", "imperfect_yo": "comía", "future_nosotros": "comeremos",
+		}},
+		{"vivir", IrVerb, map[conjugation.Form]string{
+			"present_nosotros": "vivimos", "present_vosotros": "vivís", "preterite_ellos": "vivieron", "conditional_yo": "viviría",
+		}},
+		{"buscar", ArVerb, map[conjugation.Form]string{"preterite_yo": "busqué", "preterite_tu": "buscaste"}},
+		{"llegar", ArVerb, map[conjugation.Form]string{"preterite_yo": "llegué"}},
+		{"averiguar", ArVerb, map[conjugation.Form]string{"preterite_yo": "averigüé"}},
+		{"empezar", ArVerb, map[conjugation.Form]string{"preterite_yo": "empecé", "present_yo": "empezo"}},
+		{"coger", ErVerb, map[conjugation.Form]string{"present_yo": "cojo", "present_tu": "coges"}},
+		{"distinguir", IrVerb, map[conjugation.Form]string{"present_yo": "distingo", "present_tu": "distingues"}},
+		{"vencer", ErVerb, map[conjugation.Form]string{"present_yo": "venzo"}},
+	}
+
+	for _, tt := range tests {
+		inflections, err := Conjugate(tt.verb, tt.class)
+		if err != nil {
+			t.Errorf("Conjugate(%q) error = %v", tt.verb, err)
+			continue
+		}
+		if len(inflections) != len(Forms()) {
+			t.Errorf("Conjugate(%q) returned %d forms, want %d", tt.verb, len(inflections), len(Forms()))
+		}
+		got := make(map[conjugation.Form]string, len(inflections))
+		for _, inflection := range inflections {
+			got[inflection.Form] = inflection.Text
+		}
+		for form, want := range tt.want {
+			if got[form] != want {
+				t.Errorf("Conjugate(%q) %s = %q, want %q", tt.verb, form, got[form], want)
+			}
+		}
+	}
+}
+
+func TestConjugateErrors(t *testing.T) {
+	tests := []struct {
+		verb  string
+		class conjugation.Class
+	}{
+		{"casa", ArVerb},
+		{"comer", ArVerb},
+		{"leer", ErVerb},
+		{"construir", IrVerb},
+	}
+
+	for _, tt := range tests {
+		if _, err := Conjugate(tt.verb, tt.class); !errors.Is(err, conjugation.ErrNotConjugable) {
+			t.Errorf("Conjugate(%q, %s) error = %v, want ErrNotConjugable", tt.verb, tt.class, err)
+		}
+	}
+}
+
+func TestParseClass(t *testing.T) {
+	tests := []struct {
+		name, verb string
+		want       conjugation.Class
+	}{
+		{"regular", "hablar", ArVerb},
+		{"Regular", "comer", ErVerb},
+		{"-ir", "vivir", IrVerb},
+		{"ar", "hablar", ArVerb},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseClass(tt.name, tt.verb)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseClass(%q, %q) = %q, %v, want %q", tt.name, tt.verb, got, err, tt.want)
+		}
+	}
+
+	if _, err := ParseClass("noun", "casa"); !errors.Is(err, conjugation.ErrUnknownClass) {
+		t.Errorf("ParseClass(noun) error = %v, want ErrUnknownClass", err)
+	}
+	for _, tt := range []struct{ name, verb string }{{"irregular", "ser"}, {"er", "hablar"}, {"regular", "casa"}} {
+		if _, err := ParseClass(tt.name, tt.verb); !errors.Is(err, conjugation.ErrNotConjugable) {
+			t.Errorf("ParseClass(%q, %q) error = %v, want ErrNotConjugable", tt.name, tt.verb, err)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package conjugation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// godanRows maps the last kana of a godan verb to its a, i, e and o row
+// kana; う takes わ before ない
+var godanRows = map[string][4]string{
+	"う": {"わ", "い", "え", "お"},
+	"く": {"か", "き", "け", "こ"},
+	"ぐ": {"が", "ぎ", "げ", "ご"},
+	"す": {"さ", "し", "せ", "そ"},
+	"つ": {"た", "ち", "て", "と"},
+	"ぬ": {"な", "に", "ね", "の"},
+	"ぶ": {"ば", "び", "べ", "ぼ"},
+	"む": {"ま", "み", "め", "も"},
+	"る": {"ら", "り", "れ", "ろ"},
+}
+
+// godanTe maps the last kana of a godan verb to the endings of its te and
+// ta forms
+var godanTe = map[string][2]string{
+	"う": {"って", "った"},
+	"つ": {"って", "った"},
+	"る": {"って", "った"},
+	"む": {"んで", "んだ"},
+	"ぶ": {"んで", "んだ"},
+	"ぬ": {"んで", "んだ"},
+	"く": {"いて", "いた"},
+	"ぐ": {"いで", "いだ"},
+	"す": {"して", "した"},
+}
+
+// uteVerbs are the う verbs whose te and ta forms keep う: 問うて, 問うた
+var uteVerbs = []string{"問う", "とう", "請う", "乞う", "こう"}
+
+// suruPotentials are the potentials of the suru verbs made from one kanji
+// that do not take できる, by what comes before する in kanji and in kana
+var suruPotentials = map[string]string{
+	"愛": "愛せる", "あい": "あいせる",
+	"訳": "訳せる", "やく": "やくせる",
+	"察": "察せられる", "さっ": "さっせられる",
+}
+
+// verbStems holds what every form of a verb is built from
+type verbStems struct {
+	dictionary string
+	// negative precedes ない (食べ, 飲ま) and masu precedes ます (食べ, 飲み)
+	negative, masu string
+	te, ta         string
+	potential      string
+	passive        string
+	causative      string
+	volitional     string
+	// plainNegative replaces negative+ない for ある, whose negative is ない
+	plainNegative string
+}
+
+func verb(word string, class Class) (*verbStems, error) {
+	switch class {
+	case Ichidan:
+		stem, ok := strings.CutSuffix(word, "る")
+		if !ok || stem == "" {
+			return nil, fmt.Errorf("%w: ichidan verb %s does not end in る", ErrNotConjugable, word)
+		}
+		return &verbStems{
+			dictionary: word,
+			negative:   stem,
+			masu:       stem,
+			te:         stem + "て",
+			ta:         stem + "た",
+			potential:  stem + "られる",
+			passive:    stem + "られる",
+			causative:  stem + "させる",
+			volitional: stem + "よう",
+		}, nil
+
+	case Godan:
+		return godan(word)
+
+	case Suru:
+		// Nouns listed as suru verbs may be stored without する
+		prefix := strings.TrimSuffix(word, "する")
+		potential, ok := suruPotentials[prefix]
+		if !ok {
+			potential = prefix + "できる"
+		}
+		return &verbStems{
+			dictionary: prefix + "する",
+			negative:   prefix + "し",
+			masu:       prefix + "し",
+			te:         prefix + "して",
+			ta:         prefix + "した",
+			potential:  potential,
+			passive:    prefix + "される",
+			causative:  prefix + "させる",
+			volitional: prefix + "しよう",
+		}, nil
+
+	case Kuru:
+		// 来 keeps its kanji while its reading changes between こ, き and く
+		if prefix, ok := strings.CutSuffix(word, "来る"); ok {
+			return kuru(word, prefix+"来", prefix+"来"), nil
+		}
+		if prefix, ok := strings.CutSuffix(word, "くる"); ok {
+			return kuru(word, prefix+"こ", prefix+"き"), nil
+		}
+		return nil, fmt.Errorf("%w: %s is not 来る", ErrNotConjugable, word)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownClass, class)
+}
+
+func godan(word string) (*verbStems, error) {
+	runes := []rune(word)
+	if len(runes) < 2 {
+		return nil, fmt.Errorf("%w: godan verb %s is too short", ErrNotConjugable, word)
+	}
+	last := string(runes[len(runes)-1])
+	stem := string(runes[:len(runes)-1])
+	row, ok := godanRows[last]
+	if !ok {
+		return nil, fmt.Errorf("%w: godan verb %s does not end in an u-row kana", ErrNotConjugable, word)
+	}
+
+	te := godanTe[last]
+	// 行く is the one く verb whose te form is 行って
+	if strings.HasSuffix(word, "行く") || strings.HasSuffix(word, "いく") {
+		te = [2]string{"って", "った"}
+	}
+	if slices.Contains(uteVerbs, word) {
+		te = [2]string{"うて", "うた"}
+	}
+
+	stems := &verbStems{
+		dictionary: word,
+		negative:   stem + row[0],
+		masu:       stem + row[1],
+		te:         stem + te[0],
+		ta:         stem + te[1],
+		potential:  stem + row[2] + "る",
+		passive:    stem + row[0] + "れる",
+		causative:  stem + row[0] + "せる",
+		volitional: stem + row[3] + "う",
+	}
+	switch word {
+	case "ある", "有る", "在る":
+		stems.plainNegative = "ない"
+	}
+	return stems, nil
+}
+
+func kuru(word, negative, masu string) *verbStems {
+	return &verbStems{
+		dictionary: word,
+		negative:   negative,
+		masu:       masu,
+		te:         masu + "て",
+		ta:         masu + "た",
+		potential:  negative + "られる",
+		passive:    negative + "られる",
+		causative:  negative + "させる",
+		volitional: negative + "よう",
+	}
+}
+
+func (v *verbStems) forms() map[Form]string {
+	plainNegative := v.negative + "ない"
+	if v.plainNegative != "" {
+		plainNegative = v.plainNegative
+	}
+	return map[Form]string{
+		Plain:              v.dictionary,
+		PlainNegative:      plainNegative,
+		PlainPast:          v.ta,
+		PlainPastNegative:  strings.TrimSuffix(plainNegative, "い") + "かった",
+		Polite:             v.masu + "ます",
+		PoliteNegative:     v.masu + "ません",
+		PolitePast:         v.masu + "ました",
+		PolitePastNegative: v.masu + "ませんでした",
+		Te:                 v.te,
+		Potential:          v.potential,
+		Passive:            v.passive,
+		Causative:          v.causative,
+		Volitional:         v.volitional,
+		PoliteVolitional:   v.masu + "ましょう",
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+// Conjugation is one conjugated form of a word. Kanji holds the form as
+// written in the language of the word; Reading and Romaji are only set for
+// Japanese.
+type Conjugation struct {
+	Form    string    `json:"form"`
+	Kanji   string    `json:"kanji"`
+	Reading string    `json:"reading"`
+	Romaji  string    `json:"romaji"`
+	Stats   WordStats `json:"stats"`
+}
+
+type WordConjugations struct {
+	WordID       int64         `json:"word_id"`
+	Kanji        string        `json:"kanji"`
+	Reading      string        `json:"reading"`
+	English      string        `json:"english"`
+	Class        string        `json:"class"`
+	Conjugations []Conjugation `json:"conjugations"`
+}
+
+// ConjugationExercise asks for one form of a word; Answer holds the
+// expected form
+type ConjugationExercise struct {
+	WordID  int64       `json:"word_id"`
+	Kanji   string      `json:"kanji"`
+	Reading string      `json:"reading"`
+	English string      `json:"english"`
+	Class   string      `json:"class"`
+	Form    string      `json:"form"`
+	Answer  Conjugation `json:"answer"`
+}
+
+// FormStats counts the drill reviews of one form, of one word or summed
+// over words when WordID is 0
+type FormStats struct {
+	WordID int64  `json:"word_id,omitempty"`
+	Form   string `json:"form"`
+	WordStats
+}
@@ -0,0 +1,35 @@
+package models
+
+// DictionaryEntry is a JMdict entry. ID is the JMdict entry number.
+type DictionaryEntry struct {
+	ID       int64             `json:"id"`
+	Kanji    []string          `json:"kanji"`
+	Readings []string          `json:"readings"`
+	Senses   []DictionarySense `json:"senses"`
+	// Common marks the words JMdict ranks among the most frequent
+	Common bool `json:"common"`
+}
+
+// DictionarySense is one meaning of a dictionary entry
+type DictionarySense struct {
+	PartsOfSpeech []PartOfSpeech `json:"parts_of_speech"`
+	// Misc holds JMdict usage codes such as "uk" (usually written in kana)
+	Misc    []string `json:"misc"`
+	Glosses []string `json:"glosses"`
+}
+
+// PartOfSpeech is a JMdict part-of-speech code, e.g. "v5k" for "Godan verb
+// with 'ku' ending"
+type PartOfSpeech struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// DictionaryQuery looks entries up by any of its non-empty fields: the
+// start of a kanji spelling, of a reading in romaji (in any spelling
+// transliteration.Normalize accepts), or of an English gloss
+type DictionaryQuery struct {
+	Kanji   string
+	Romaji  string
+	English string
+}
@@ -0,0 +1,36 @@
+package models
+
+// FilterOp is a comparison allowed in a word filter
+type FilterOp string
+
+const (
+	FilterEq     FilterOp = "eq"
+	FilterNe     FilterOp = "ne"
+	FilterLt     FilterOp = "lt"
+	FilterLte    FilterOp = "lte"
+	FilterGt     FilterOp = "gt"
+	FilterGte    FilterOp = "gte"
+	FilterLike   FilterOp = "like"
+	FilterIn     FilterOp = "in"
+	FilterExists FilterOp = "exists"
+)
+
+// WordCondition compares a word field with a value. Field is a column such as
+// "english" or "accuracy", or "parts.<key>" for a key of the parts object.
+// Values holds a single value for every op but in.
+type WordCondition struct {
+	Field  string   `json:"field"`
+	Op     FilterOp `json:"op"`
+	Values []any    `json:"values"`
+}
+
+// WordFilter narrows a word list to the words matching every condition and,
+// when GroupID is set, belonging to that group but to none of
+// ExcludeGroupIDs. Character keeps the words written with that kanji. A
+// smart group saves its filter as JSON, which leaves Character out.
+type WordFilter struct {
+	GroupID         int64           `json:"group_id,omitempty"`
+	ExcludeGroupIDs []int64         `json:"exclude_group_ids,omitempty"`
+	Character       string          `json:"-"`
+	Conditions      []WordCondition `json:"conditions,omitempty"`
+}
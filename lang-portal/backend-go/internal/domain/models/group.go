@@ -2,11 +2,25 @@ package models
 
 import "time"
 
+// Group is a set of words of one language. The words of a smart group are
+// not added to it but are the words its Filter matches when it is read, and
+// its words count is counted then. Groups nest under a parent of their
+// language, ordered by Position; a group holds the words of the groups
+// under it, and its words count and last study time include theirs.
 type Group struct {
-	ID            int64      `json:"id"`
-	Name          string     `json:"name"`
-	WordsCount    int        `json:"words_count"`
-	LastStudiedAt *time.Time `json:"last_studied_at,omitempty"`
+	ID            int64       `json:"id"`
+	Name          string      `json:"name"`
+	Language      string      `json:"language"`
+	WordsCount    int         `json:"words_count"`
+	Filter        *WordFilter `json:"filter,omitempty"`
+	ParentID      *int64      `json:"parent_id,omitempty"`
+	Position      int         `json:"position"`
+	LastStudiedAt *time.Time  `json:"last_studied_at,omitempty"`
+}
+
+// Smart reports whether the group is defined by a saved filter
+func (g *Group) Smart() bool {
+	return g.Filter != nil
 }
 
 type GroupStats struct {
@@ -18,4 +32,11 @@ type GroupStats struct {
 type GroupWithStats struct {
 	Group
 	Stats GroupStats `json:"stats"`
-} 
\ No newline at end of file
+}
+
+// GroupTree is a group with its stats and the groups under it, in order
+type GroupTree struct {
+	GroupWithStats
+	Children []*GroupTree `json:"children"`
+}
+ 
\ No newline at end of file
@@ -0,0 +1,18 @@
+package models
+
+// Kanji is a character of the kanji dictionary. Grade, frequency and JLPT
+// level are omitted when KANJIDIC2 does not give them, and a kanji missing
+// from the dictionary only has its character.
+type Kanji struct {
+	Character   string `json:"character"`
+	Grade       int    `json:"grade,omitempty"`
+	StrokeCount int    `json:"stroke_count,omitempty"`
+	// Frequency is the rank among the 2,500 most used kanji in newspapers
+	Frequency int `json:"frequency,omitempty"`
+	// JLPT is the level of the old four-level test, 4 being the easiest
+	JLPT        int      `json:"jlpt,omitempty"`
+	OnReadings  []string `json:"on_readings,omitempty"`
+	KunReadings []string `json:"kun_readings,omitempty"`
+	Nanori      []string `json:"nanori,omitempty"`
+	Meanings    []string `json:"meanings,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// Language is a language the vocabulary can be in
+type Language struct {
+	// Code is the ISO 639-1 code, e.g. "ja"
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Script is the ISO 15924 code of the writing system terms are written
+	// in, e.g. "Jpan" for Japanese or "Latn" for Spanish
+	Script string `json:"script"`
+	// Transliteration names the romanization stored with each term, empty
+	// for languages written in the Latin script
+	Transliteration string `json:"transliteration"`
+}
+
+// DefaultLanguage is the language of words and groups created without one,
+// and of everything stored before languages existed
+const DefaultLanguage = "ja"
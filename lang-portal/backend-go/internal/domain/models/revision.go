@@ -0,0 +1,65 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// RevisionKind names the entities whose changes are recorded, in the
+// <kind>_revisions table, and that go to the trash when deleted
+type RevisionKind string
+
+const (
+	RevisionWord          RevisionKind = "word"
+	RevisionGroup         RevisionKind = "group"
+	RevisionStudyActivity RevisionKind = "study_activity"
+)
+
+// Revision actions
+const (
+	RevisionCreate  = "create"
+	RevisionUpdate  = "update"
+	RevisionRevert  = "revert"
+	RevisionDelete  = "delete"
+	RevisionRestore = "restore"
+)
+
+// Revision is one change to a word, group or study activity. Before and
+// After hold the entity as the API returns it; Before is null for a
+// creation or restore and After for a deletion. RevertedTo is the revision
+// a revert restored.
+type Revision struct {
+	ID         int64           `json:"id"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Author     string          `json:"author"`
+	Reason     string          `json:"reason"`
+	RevertedTo *int64          `json:"reverted_to,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Change describes who makes the writes done with a context and why. The
+// revisions recorded by those writes carry it.
+type Change struct {
+	Author string
+	Reason string
+	// RevertTo is set by reverts to the revision being restored
+	RevertTo int64
+}
+
+// changeKey is the context key WithChange stores a change under
+type changeKey struct{}
+
+// WithChange returns a context whose writes are recorded as made by change
+func WithChange(ctx context.Context, change Change) context.Context {
+	return context.WithValue(ctx, changeKey{}, change)
+}
+
+// ChangeFromContext returns the change set by WithChange, or an anonymous
+// change without a reason
+func ChangeFromContext(ctx context.Context) Change {
+	change, _ := ctx.Value(changeKey{}).(Change)
+	return change
+}
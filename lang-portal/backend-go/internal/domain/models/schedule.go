@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WordSchedule is the spaced-repetition state of a word. Algorithm names the
+// scheduler that produced it; the remaining fields are only meaningful to
+// the algorithms that use them (ease factor for SM-2, box for Leitner,
+// stability and difficulty for FSRS).
+type WordSchedule struct {
+	WordID         int64     `json:"word_id"`
+	Algorithm      string    `json:"algorithm"`
+	Repetitions    int       `json:"repetitions"`
+	Lapses         int       `json:"lapses"`
+	IntervalDays   int       `json:"interval_days"`
+	EaseFactor     float64   `json:"ease_factor,omitempty"`
+	Box            int       `json:"box,omitempty"`
+	Stability      float64   `json:"stability,omitempty"`
+	Difficulty     float64   `json:"difficulty,omitempty"`
+	DueAt          time.Time `json:"due_at"`
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+}
+
+// DueWord is an entry of the review queue. Schedule is nil for words that
+// have never been reviewed.
+type DueWord struct {
+	WordWithStats
+	Schedule *WordSchedule `json:"schedule"`
+}
+
+// MarshalJSON is needed as the one promoted from WordWithStats would leave
+// the schedule out
+func (d DueWord) MarshalJSON() ([]byte, error) {
+	type word WordWithStats
+	return json.Marshal(struct {
+		word
+		wordTerms
+		Schedule *WordSchedule `json:"schedule"`
+	}{word(d.WordWithStats), wordTerms{d.Kanji, d.Romaji, d.English}, d.Schedule})
+}
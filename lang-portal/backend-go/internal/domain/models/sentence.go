@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Sentence is an example sentence showing words in context
+type Sentence struct {
+	ID       int64  `json:"id"`
+	Japanese string `json:"japanese"`
+	Reading  string `json:"reading"`
+	English  string `json:"english"`
+	// Source credits where the sentence comes from, e.g. "Tatoeba #12345"
+	Source    string    `json:"source"`
+	WordIDs   []int64   `json:"word_ids"`
+	CreatedAt time.Time `json:"created_at"`
+}
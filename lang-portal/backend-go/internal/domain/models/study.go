@@ -20,6 +20,7 @@ type WordReviewItem struct {
 	WordID         int64     `json:"word_id"`
 	StudySessionID int64     `json:"study_session_id"`
 	Correct        bool      `json:"correct"`
+	Form           string    `json:"form,omitempty"` // conjugated form drilled, empty for word reviews
 	CreatedAt      time.Time `json:"created_at"`
 }
 
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// TrashItem is a deleted word, group or study activity that can still be
+// restored. Name is the term of a word and the name of a group or activity.
+type TrashItem struct {
+	Type      RevisionKind `json:"type"`
+	ID        int64        `json:"id"`
+	Name      string       `json:"name"`
+	DeletedAt time.Time    `json:"deleted_at"`
+}
+
+// PurgeResult counts the items removed from the trash for good
+type PurgeResult struct {
+	Words           int `json:"words"`
+	Groups          int `json:"groups"`
+	StudyActivities int `json:"study_activities"`
+}
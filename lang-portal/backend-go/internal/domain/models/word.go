@@ -1,11 +1,68 @@
 package models
 
+import "encoding/json"
+
+// Word is a vocabulary word of any language. Kanji, Romaji and English hold
+// its term, transliteration and gloss, under the names the API used when it
+// only held Japanese; the JSON form carries both names.
 type Word struct {
-	ID      int64           `json:"id"`
-	Kanji   string         `json:"kanji"`
-	Romaji  string         `json:"romaji"`
-	English string         `json:"english"`
-	Parts   map[string]any `json:"parts"`
+	ID       int64          `json:"id"`
+	Language string         `json:"language"`
+	Kanji    string         `json:"kanji"`
+	Reading  string         `json:"reading"`
+	Ruby     []RubySegment  `json:"ruby,omitempty"`
+	Romaji   string         `json:"romaji"`
+	English  string         `json:"english"`
+	Parts    map[string]any `json:"parts"`
+	// SortKey orders the words of a language, as set by its language pack
+	SortKey string `json:"-"`
+}
+
+// wordTerms are the language-agnostic JSON names of a word's fields
+type wordTerms struct {
+	Term            string `json:"term"`
+	Transliteration string `json:"transliteration"`
+	Gloss           string `json:"gloss"`
+}
+
+func (w Word) MarshalJSON() ([]byte, error) {
+	type word Word
+	return json.Marshal(struct {
+		word
+		wordTerms
+	}{word(w), wordTerms{w.Kanji, w.Romaji, w.English}})
+}
+
+// UnmarshalJSON accepts term, transliteration and gloss in place of kanji,
+// romaji and english
+func (w *Word) UnmarshalJSON(data []byte) error {
+	type word Word
+	var v struct {
+		word
+		wordTerms
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*w = Word(v.word)
+	if w.Kanji == "" {
+		w.Kanji = v.Term
+	}
+	if w.Romaji == "" {
+		w.Romaji = v.Transliteration
+	}
+	if w.English == "" {
+		w.English = v.Gloss
+	}
+	return nil
+}
+
+// RubySegment is a piece of the kanji field with the furigana written above
+// it; Ruby is empty for pieces written in kana
+type RubySegment struct {
+	Text string `json:"text"`
+	Ruby string `json:"ruby,omitempty"`
 }
 
 type WordStats struct {
@@ -15,14 +72,27 @@ type WordStats struct {
 }
 
 type WordWithStats struct {
-	ID      int64           `json:"id"`
-	Kanji   string         `json:"kanji"`
-	Romaji  string         `json:"romaji"`
-	English string         `json:"english"`
-	Parts   map[string]any `json:"parts"`
-	Stats   struct {
+	ID       int64          `json:"id"`
+	Language string         `json:"language"`
+	Kanji    string         `json:"kanji"`
+	Reading  string         `json:"reading"`
+	Ruby     []RubySegment  `json:"ruby,omitempty"`
+	Romaji   string         `json:"romaji"`
+	English  string         `json:"english"`
+	Parts    map[string]any `json:"parts"`
+	Stats    struct {
 		CorrectCount int     `json:"correct_count"`
 		WrongCount   int     `json:"wrong_count"`
 		Accuracy     float64 `json:"accuracy"`
 	} `json:"stats"`
-} 
\ No newline at end of file
+	// Example is the simplest sentence using the word, set on word details
+	Example *Sentence `json:"example,omitempty"`
+}
+
+func (w WordWithStats) MarshalJSON() ([]byte, error) {
+	type word WordWithStats
+	return json.Marshal(struct {
+		word
+		wordTerms
+	}{word(w), wordTerms{w.Kanji, w.Romaji, w.English}})
+}
@@ -0,0 +1,201 @@
+// Package jmdict reads the JMdict Japanese-English dictionary
+// (https://www.edrdg.org/wiki/index.php/JMdict-EDICT_Dictionary_Project).
+package jmdict
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// ErrInvalidFile is returned for input that is not a JMdict file
+var ErrInvalidFile = errors.New("not a JMdict file")
+
+// commonPriorities are the priority codes JMdict marks common words with
+var commonPriorities = map[string]bool{
+	"news1": true,
+	"ichi1": true,
+	"spec1": true,
+	"spec2": true,
+	"gai1":  true,
+}
+
+// Tag is a code JMdict declares as an XML entity, such as the part of
+// speech "v1" for "Ichidan verb"
+type Tag struct {
+	Code        string
+	Description string
+}
+
+// Entry is one word of the dictionary
+type Entry struct {
+	// Sequence is the stable JMdict entry number (ent_seq)
+	Sequence int64
+	Kanji    []string
+	Readings []string
+	Senses   []Sense
+	// Common is set when a kanji or reading has one of the priorities the
+	// dictionary marks common words with
+	Common bool
+}
+
+// Sense is one meaning of an entry. Glosses are the English ones.
+type Sense struct {
+	PartsOfSpeech []Tag
+	// Misc holds usage codes such as "uk" (usually written in kana)
+	Misc    []string
+	Glosses []string
+}
+
+// entry mirrors the <entry> element
+type entry struct {
+	Sequence string `xml:"ent_seq"`
+	Kanji    []struct {
+		Text     string   `xml:"keb"`
+		Priority []string `xml:"ke_pri"`
+	} `xml:"k_ele"`
+	Readings []struct {
+		Text     string   `xml:"reb"`
+		Priority []string `xml:"re_pri"`
+	} `xml:"r_ele"`
+	Senses []struct {
+		PartsOfSpeech []string `xml:"pos"`
+		Misc          []string `xml:"misc"`
+		Glosses       []struct {
+			Lang  string `xml:"lang,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"gloss"`
+	} `xml:"sense"`
+}
+
+var entityDeclaration = regexp.MustCompile(`<!ENTITY\s+(\S+)\s+"([^"]*)"\s*>`)
+
+// Read streams a JMdict XML file, calling fn for every entry in file order.
+// Only one entry is held in memory at a time; an error from fn stops the
+// read and is returned as is.
+//
+// The codes JMdict declares as entities (parts of speech, misc, fields)
+// are read as their names, e.g. &v1; gives "v1", and described from the
+// DOCTYPE. As in JMdict, a sense without parts of speech has those of the
+// sense before it.
+func Read(r io.Reader, fn func(*Entry) error) error {
+	decoder := xml.NewDecoder(r)
+	decoder.Entity = map[string]string{}
+	descriptions := map[string]string{}
+	root := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error reading JMdict: %v", err)
+		}
+
+		switch token := token.(type) {
+		case xml.Directive:
+			for _, match := range entityDeclaration.FindAllSubmatch(token, -1) {
+				code := string(match[1])
+				decoder.Entity[code] = code
+				descriptions[code] = string(match[2])
+			}
+		case xml.StartElement:
+			if !root {
+				if token.Name.Local != "JMdict" {
+					return ErrInvalidFile
+				}
+				root = true
+				continue
+			}
+			if token.Name.Local != "entry" {
+				if err := decoder.Skip(); err != nil {
+					return fmt.Errorf("error reading JMdict: %v", err)
+				}
+				continue
+			}
+
+			var element entry
+			if err := decoder.DecodeElement(&element, &token); err != nil {
+				return fmt.Errorf("error reading JMdict entry: %v", err)
+			}
+			e, err := element.entry(descriptions)
+			if err != nil {
+				return err
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !root {
+		return ErrInvalidFile
+	}
+	return nil
+}
+
+func (e *entry) entry(descriptions map[string]string) (*Entry, error) {
+	sequence, err := strconv.ParseInt(e.Sequence, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JMdict entry number %q", e.Sequence)
+	}
+
+	result := &Entry{
+		Sequence: sequence,
+		Kanji:    []string{},
+		Readings: []string{},
+		Senses:   []Sense{},
+	}
+	for _, kanji := range e.Kanji {
+		result.Kanji = append(result.Kanji, kanji.Text)
+		result.Common = result.Common || isCommon(kanji.Priority)
+	}
+	for _, reading := range e.Readings {
+		result.Readings = append(result.Readings, reading.Text)
+		result.Common = result.Common || isCommon(reading.Priority)
+	}
+
+	var partsOfSpeech []Tag
+	for _, sense := range e.Senses {
+		if len(sense.PartsOfSpeech) > 0 {
+			partsOfSpeech = make([]Tag, len(sense.PartsOfSpeech))
+			for i, code := range sense.PartsOfSpeech {
+				partsOfSpeech[i] = Tag{Code: code, Description: descriptions[code]}
+			}
+		}
+
+		s := Sense{
+			PartsOfSpeech: partsOfSpeech,
+			Misc:          sense.Misc,
+			Glosses:       []string{},
+		}
+		if s.PartsOfSpeech == nil {
+			s.PartsOfSpeech = []Tag{}
+		}
+		if s.Misc == nil {
+			s.Misc = []string{}
+		}
+		for _, gloss := range sense.Glosses {
+			if gloss.Lang == "" || gloss.Lang == "eng" {
+				s.Glosses = append(s.Glosses, gloss.Value)
+			}
+		}
+		// Senses of multilingual files may only have other languages
+		if len(s.Glosses) > 0 {
+			result.Senses = append(result.Senses, s)
+		}
+	}
+	return result, nil
+}
+
+func isCommon(priorities []string) bool {
+	for _, priority := range priorities {
+		if commonPriorities[priority] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package jmdict
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE JMdict [
+<!ELEMENT JMdict (entry*)>
+<!-- <pos> part-of-speech info -->
+<!ENTITY v1 "Ichidan verb">
+<!ENTITY vt "transitive verb">
+<!ENTITY n "noun (common) (futsuumeishi)">
+<!ENTITY uk "word usually written using kana alone">
+]>
+<JMdict>
+<!-- JMdict created: 2025-02-08 -->
+<entry>
+<ent_seq>1358280</ent_seq>
+<k_ele>
+<keb>食べる</keb>
+<ke_pri>ichi1</ke_pri>
+</k_ele>
+<k_ele>
+<keb>喰べる</keb>
+</k_ele>
+<r_ele>
+<reb>たべる</reb>
+<re_pri>ichi1</re_pri>
+</r_ele>
+<sense>
+<pos>&v1;</pos>
+<pos>&vt;</pos>
+<gloss>to eat</gloss>
+<gloss xml:lang="ger">essen</gloss>
+</sense>
+<sense>
+<gloss>to live on (e.g. a salary)</gloss>
+</sense>
+</entry>
+<entry>
+<ent_seq>1000220</ent_seq>
+<k_ele><keb>明白</keb></k_ele>
+<r_ele><reb>めいはく</reb></r_ele>
+<sense>
+<pos>&n;</pos>
+<misc>&uk;</misc>
+<gloss>obvious</gloss>
+<gloss>clear</gloss>
+</sense>
+<sense>
+<gloss xml:lang="ger">klar</gloss>
+</sense>
+</entry>
+</JMdict>`
+
+func TestRead(t *testing.T) {
+	var entries []*Entry
+	err := Read(strings.NewReader(sample), func(e *Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	verb := []Tag{{"v1", "Ichidan verb"}, {"vt", "transitive verb"}}
+	want := []*Entry{
+		{
+			Sequence: 1358280,
+			Kanji:    []string{"食べる", "喰べる"},
+			Readings: []string{"たべる"},
+			Senses: []Sense{
+				{PartsOfSpeech: verb, Misc: []string{}, Glosses: []string{"to eat"}},
+				{PartsOfSpeech: verb, Misc: []string{}, Glosses: []string{"to live on (e.g. a salary)"}},
+			},
+			Common: true,
+		},
+		{
+			Sequence: 1000220,
+			Kanji:    []string{"明白"},
+			Readings: []string{"めいはく"},
+			Senses: []Sense{
+				{PartsOfSpeech: []Tag{{"n", "noun (common) (futsuumeishi)"}}, Misc: []string{"uk"}, Glosses: []string{"obvious", "clear"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("Read() = %+v, want %+v", entries, want)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	if err := Read(strings.NewReader(`<kanjidic2></kanjidic2>`), func(*Entry) error { return nil }); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Read() of another file error = %v, want ErrInvalidFile", err)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err := Read(strings.NewReader(sample), func(*Entry) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Read() = %v after %d calls, want the callback error after 1", err, calls)
+	}
+}
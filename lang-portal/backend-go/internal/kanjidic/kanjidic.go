@@ -0,0 +1,185 @@
+// Package kanjidic reads the KANJIDIC2 kanji dictionary
+// (https://www.edrdg.org/wiki/index.php/KANJIDIC_Project) and finds the kanji
+// a word is written with.
+package kanjidic
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ErrInvalidFile is returned for input that is not a KANJIDIC2 file
+var ErrInvalidFile = errors.New("not a KANJIDIC2 file")
+
+// Character is one kanji of the dictionary. Grade, Frequency and JLPT are 0
+// when the dictionary does not give them; Meanings are the English ones.
+type Character struct {
+	Literal     string
+	Grade       int
+	StrokeCount int
+	Frequency   int
+	JLPT        int
+	OnReadings  []string
+	KunReadings []string
+	Nanori      []string
+	Meanings    []string
+}
+
+// character mirrors the <character> element
+type character struct {
+	Literal string `xml:"literal"`
+	Misc    struct {
+		Grade       string   `xml:"grade"`
+		StrokeCount []string `xml:"stroke_count"`
+		Freq        string   `xml:"freq"`
+		JLPT        string   `xml:"jlpt"`
+	} `xml:"misc"`
+	ReadingMeaning struct {
+		Groups []struct {
+			Readings []struct {
+				Type  string `xml:"r_type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"reading"`
+			Meanings []struct {
+				Lang  string `xml:"m_lang,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"meaning"`
+		} `xml:"rmgroup"`
+		Nanori []string `xml:"nanori"`
+	} `xml:"reading_meaning"`
+}
+
+// Read streams a KANJIDIC2 XML file, calling fn for every character in file
+// order. Only one character is held in memory at a time; an error from fn
+// stops the read and is returned as is.
+func Read(r io.Reader, fn func(*Character) error) error {
+	decoder := xml.NewDecoder(r)
+	root := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error reading KANJIDIC2: %v", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if !root {
+			if start.Name.Local != "kanjidic2" {
+				return ErrInvalidFile
+			}
+			root = true
+			continue
+		}
+		if start.Name.Local != "character" {
+			if err := decoder.Skip(); err != nil {
+				return fmt.Errorf("error reading KANJIDIC2: %v", err)
+			}
+			continue
+		}
+
+		var element character
+		if err := decoder.DecodeElement(&element, &start); err != nil {
+			return fmt.Errorf("error reading KANJIDIC2 character: %v", err)
+		}
+		c, err := element.character()
+		if err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+
+	if !root {
+		return ErrInvalidFile
+	}
+	return nil
+}
+
+func (e *character) character() (*Character, error) {
+	c := &Character{
+		Literal:     e.Literal,
+		OnReadings:  []string{},
+		KunReadings: []string{},
+		Nanori:      e.ReadingMeaning.Nanori,
+		Meanings:    []string{},
+	}
+	if c.Nanori == nil {
+		c.Nanori = []string{}
+	}
+
+	var err error
+	if c.Grade, err = optionalInt(e.Misc.Grade); err != nil {
+		return nil, fmt.Errorf("invalid grade of %s: %v", e.Literal, err)
+	}
+	if c.Frequency, err = optionalInt(e.Misc.Freq); err != nil {
+		return nil, fmt.Errorf("invalid frequency of %s: %v", e.Literal, err)
+	}
+	if c.JLPT, err = optionalInt(e.Misc.JLPT); err != nil {
+		return nil, fmt.Errorf("invalid JLPT level of %s: %v", e.Literal, err)
+	}
+	// The first stroke count is the accepted one, the others are common
+	// miscounts
+	if len(e.Misc.StrokeCount) > 0 {
+		if c.StrokeCount, err = optionalInt(e.Misc.StrokeCount[0]); err != nil {
+			return nil, fmt.Errorf("invalid stroke count of %s: %v", e.Literal, err)
+		}
+	}
+
+	for _, group := range e.ReadingMeaning.Groups {
+		for _, reading := range group.Readings {
+			switch reading.Type {
+			case "ja_on":
+				c.OnReadings = append(c.OnReadings, reading.Value)
+			case "ja_kun":
+				c.KunReadings = append(c.KunReadings, reading.Value)
+			}
+		}
+		for _, meaning := range group.Meanings {
+			if meaning.Lang == "" || meaning.Lang == "en" {
+				c.Meanings = append(c.Meanings, meaning.Value)
+			}
+		}
+	}
+	return c, nil
+}
+
+func optionalInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// IsKanji reports whether r is a CJK ideograph. The ranges are the unified
+// ideographs, extension A, the compatibility ideographs and the
+// supplementary planes; migration 000009 uses the same ones.
+func IsKanji(r rune) bool {
+	return (r >= 0x3400 && r <= 0x4DBF) ||
+		(r >= 0x4E00 && r <= 0x9FFF) ||
+		(r >= 0xF900 && r <= 0xFAFF) ||
+		(r >= 0x20000 && r <= 0x3134F)
+}
+
+// Characters returns the distinct kanji of text in the order they first
+// appear, e.g. 日本語 gives 日, 本 and 語. Kana, 々 and other characters are
+// left out.
+func Characters(text string) []string {
+	var characters []string
+	seen := make(map[rune]bool)
+	for _, r := range text {
+		if IsKanji(r) && !seen[r] {
+			seen[r] = true
+			characters = append(characters, string(r))
+		}
+	}
+	return characters
+}
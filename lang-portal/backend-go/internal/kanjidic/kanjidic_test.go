@@ -0,0 +1,112 @@
+package kanjidic
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE kanjidic2 [
+<!ELEMENT kanjidic2 (header,character*)>
+]>
+<kanjidic2>
+<header>
+<file_version>4</file_version>
+<database_version>2025-041</database_version>
+</header>
+<!-- Entry for Kanji: 食 -->
+<character>
+<literal>食</literal>
+<codepoint><cp_value cp_type="ucs">98df</cp_value></codepoint>
+<misc>
+<grade>2</grade>
+<stroke_count>9</stroke_count>
+<stroke_count>10</stroke_count>
+<freq>328</freq>
+<jlpt>4</jlpt>
+</misc>
+<reading_meaning>
+<rmgroup>
+<reading r_type="pinyin">shi2</reading>
+<reading r_type="ja_on">ショク</reading>
+<reading r_type="ja_on">ジキ</reading>
+<reading r_type="ja_kun">く.う</reading>
+<reading r_type="ja_kun">た.べる</reading>
+<meaning>eat</meaning>
+<meaning>food</meaning>
+<meaning m_lang="fr">manger</meaning>
+</rmgroup>
+<nanori>け</nanori>
+</reading_meaning>
+</character>
+<character>
+<literal>丂</literal>
+<misc>
+<stroke_count>2</stroke_count>
+</misc>
+</character>
+</kanjidic2>`
+
+func TestRead(t *testing.T) {
+	var characters []*Character
+	err := Read(strings.NewReader(sample), func(c *Character) error {
+		characters = append(characters, c)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	want := []*Character{
+		{
+			Literal: "食", Grade: 2, StrokeCount: 9, Frequency: 328, JLPT: 4,
+			OnReadings:  []string{"ショク", "ジキ"},
+			KunReadings: []string{"く.う", "た.べる"},
+			Nanori:      []string{"け"},
+			Meanings:    []string{"eat", "food"},
+		},
+		{
+			Literal: "丂", StrokeCount: 2,
+			OnReadings: []string{}, KunReadings: []string{}, Nanori: []string{}, Meanings: []string{},
+		},
+	}
+	if !reflect.DeepEqual(characters, want) {
+		t.Errorf("Read() = %+v, want %+v", characters, want)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	if err := Read(strings.NewReader(`<JMdict></JMdict>`), func(*Character) error { return nil }); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Read() of another file error = %v, want ErrInvalidFile", err)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err := Read(strings.NewReader(sample), func(*Character) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Read() = %v after %d calls, want the callback error after 1", err, calls)
+	}
+}
+
+func TestCharacters(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"日本語", []string{"日", "本", "語"}},
+		{"食べ物", []string{"食", "物"}},
+		{"人々", []string{"人"}},
+		{"日曜日", []string{"日", "曜"}},
+		{"たべる", nil},
+	}
+	for _, tt := range tests {
+		if got := Characters(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Characters(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
@@ -4,60 +4,136 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
 	"strings"
+	"time"
 
 	"backend-go/internal/domain/models"
 )
 
+// Transactor runs fn in a transaction. Repository calls made with the context
+// passed to fn take part in that transaction.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type LanguageRepository interface {
+	List(ctx context.Context) ([]*models.Language, error)
+	GetByCode(ctx context.Context, code string) (*models.Language, error)
+}
+
 type WordRepository interface {
 	Create(ctx context.Context, word *models.Word) error
 	GetByID(ctx context.Context, id int64) (*models.Word, error)
-	List(ctx context.Context, page, pageSize int, sortBy, order string) ([]*models.WordWithStats, int, error)
+	GetByKanjiRomaji(ctx context.Context, kanji, romaji, language string) (*models.Word, error)
+	List(ctx context.Context, page, pageSize int, sortBy, order string, filter models.WordFilter) ([]*models.WordWithStats, int, error)
+	Search(ctx context.Context, query, language string, page, pageSize int) ([]*models.WordWithStats, int, error)
 	Update(ctx context.Context, word *models.Word) error
 	Delete(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) (bool, error)
 	GetStats(ctx context.Context, wordID int64) (*models.WordStats, error)
+	ListWithoutReading(ctx context.Context) ([]*models.Word, error)
+	SetSortKey(ctx context.Context, id int64, sortKey string) (bool, error)
 }
 
 type GroupRepository interface {
 	Create(ctx context.Context, group *models.Group) error
 	GetByID(ctx context.Context, id int64) (*models.Group, error)
-	List(ctx context.Context, page, pageSize int, sortBy, order string) ([]*models.Group, int, error)
+	GetByName(ctx context.Context, name string) (*models.Group, error)
+	List(ctx context.Context, page, pageSize int, sortBy, order, language string) ([]*models.Group, int, error)
 	Update(ctx context.Context, group *models.Group) error
 	Delete(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) (bool, error)
 	GetStats(ctx context.Context, groupID int64) (*models.GroupStats, error)
 	AddWord(ctx context.Context, groupID, wordID int64) error
 	RemoveWord(ctx context.Context, groupID, wordID int64) error
-	ListWords(ctx context.Context, groupID int64, page, pageSize int) ([]*models.WordWithStats, int, error)
-	GetGroupWords(ctx context.Context, groupID int64, page int, sortBy, order string) ([]*models.WordWithStats, int, error)
+	HasWord(ctx context.Context, groupID, wordID int64) (bool, error)
+	AddWords(ctx context.Context, groupID int64, wordIDs []int64, position int) (int, error)
+	RemoveWords(ctx context.Context, groupID int64, wordIDs []int64) (int, error)
+	ReorderWords(ctx context.Context, groupID int64, wordIDs []int64) (int, error)
+	ListWords(ctx context.Context, groupID int64, page, pageSize int, filter models.WordFilter) ([]*models.WordWithStats, int, error)
+	GetGroupWords(ctx context.Context, groupID int64, page int, sortBy, order string, filter models.WordFilter) ([]*models.WordWithStats, int, error)
 	ListStudySessions(ctx context.Context, groupID int64, page, pageSize int) ([]models.StudySessionWithStats, int, error)
+	ListChildren(ctx context.Context, parentID int64) ([]*models.Group, error)
+	SetParent(ctx context.Context, id int64, parentID *int64, position int) error
 }
 
 type StudyActivityRepository interface {
 	Create(ctx context.Context, activity *models.StudyActivity) error
 	GetByID(ctx context.Context, id int64) (*models.StudyActivity, error)
+	GetByName(ctx context.Context, name string) (*models.StudyActivity, error)
 	List(ctx context.Context) ([]*models.StudyActivity, error)
 	Update(ctx context.Context, activity *models.StudyActivity) error
 	Delete(ctx context.Context, id int64) error
+	Restore(ctx context.Context, id int64) (bool, error)
 }
 
 type StudySessionRepository interface {
 	Create(ctx context.Context, session *models.StudySession) error
 	GetByID(ctx context.Context, id int64) (*models.StudySession, error)
+	FindSession(ctx context.Context, groupID, activityID int64, createdAt time.Time) (*models.StudySession, error)
 	ListByGroup(ctx context.Context, groupID int64, page, pageSize int) ([]*models.StudySession, int, error)
 	AddReview(ctx context.Context, review *models.WordReviewItem) error
 	GetSessionStats(ctx context.Context, sessionID int64) (*models.StudySessionStats, error)
 	ListReviews(ctx context.Context, sessionID int64) ([]*models.WordReviewItem, error)
+	ListFormStats(ctx context.Context, groupID, wordID int64) ([]*models.FormStats, error)
 	GetLastSession(ctx context.Context) (*models.StudySessionWithStats, error)
 	GetStudyProgress(ctx context.Context, days int) (*models.StudyProgress, error)
 	GetQuickStats(ctx context.Context) (*models.QuickStats, error)
 	GetSessionWords(ctx context.Context, sessionID int64) ([]*models.WordWithStats, error)
 	FullReset(ctx context.Context) error
-	LoadSeedData(ctx context.Context, seedsDir string) error
 	ListByActivity(ctx context.Context, activityID int64, page, pageSize int) ([]*models.StudySession, error)
 }
 
+type ScheduleRepository interface {
+	Get(ctx context.Context, wordID int64) (*models.WordSchedule, error)
+	Upsert(ctx context.Context, schedule *models.WordSchedule) error
+	ListDue(ctx context.Context, groupID int64, language string, now time.Time, limit int) ([]*models.DueWord, error)
+	ListHistory(ctx context.Context, groupID int64) ([]*models.WordReviewItem, error)
+	ListWordHistory(ctx context.Context, wordID int64) ([]*models.WordReviewItem, error)
+	ListLastReviewGroups(ctx context.Context) (map[int64]int64, error)
+}
+
+type GroupSettingsRepository interface {
+	GetScheduler(ctx context.Context, groupID int64) (string, error)
+	SetScheduler(ctx context.Context, groupID int64, scheduler string) error
+}
+
+type SentenceRepository interface {
+	Create(ctx context.Context, sentence *models.Sentence) error
+	GetByID(ctx context.Context, id int64) (*models.Sentence, error)
+	List(ctx context.Context, query string, page, pageSize int) ([]*models.Sentence, int, error)
+	ListByWord(ctx context.Context, wordID int64, page, pageSize int) ([]*models.Sentence, int, error)
+	Update(ctx context.Context, sentence *models.Sentence) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type KanjiRepository interface {
+	Upsert(ctx context.Context, kanji *models.Kanji) error
+	GetByCharacter(ctx context.Context, character string) (*models.Kanji, error)
+	ListByCharacters(ctx context.Context, characters []string) (map[string]*models.Kanji, error)
+}
+
+type DictionaryRepository interface {
+	Upsert(ctx context.Context, entry *models.DictionaryEntry) error
+	GetByID(ctx context.Context, id int64) (*models.DictionaryEntry, error)
+	Search(ctx context.Context, query models.DictionaryQuery, page, pageSize int) ([]*models.DictionaryEntry, int, error)
+}
+
+// RevisionRepository reads the histories the word, group and study activity
+// repositories record as they write
+type RevisionRepository interface {
+	List(ctx context.Context, kind models.RevisionKind, entityID int64, page, pageSize int) ([]*models.Revision, int, error)
+	GetByID(ctx context.Context, kind models.RevisionKind, entityID, id int64) (*models.Revision, error)
+}
+
+// TrashRepository lists and purges the words, groups and study activities
+// their repositories moved to the trash
+type TrashRepository interface {
+	List(ctx context.Context, kind string, page, pageSize int) ([]*models.TrashItem, int, error)
+	Purge(ctx context.Context, before time.Time) (*models.PurgeResult, error)
+}
+
 type Repository struct {
 	db *sql.DB
 }
@@ -89,8 +165,8 @@ func (r *Repository) FullReset(ctx context.Context) error {
 	return tx.Commit()
 }
 
-// LoadSeedData reads and executes SQL files from the seeds directory
-func (r *Repository) LoadSeedData(ctx context.Context, seedsDir string) error {
+// LoadSeedData reads and executes the SQL seed files in seeds
+func (r *Repository) LoadSeedData(ctx context.Context, seeds fs.FS) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -106,8 +182,7 @@ func (r *Repository) LoadSeedData(ctx context.Context, seedsDir string) error {
 	}
 
 	for _, filename := range seedFiles {
-		filepath := filepath.Join(seedsDir, filename)
-		content, err := os.ReadFile(filepath)
+		content, err := fs.ReadFile(seeds, filename)
 		if err != nil {
 			return fmt.Errorf("failed to read seed file %s: %v", filename, err)
 		}
@@ -35,14 +35,51 @@ func (d *Database) Close() error {
 	return d.DB.Close()
 }
 
+// txKey is the context key WithinTx stores its transaction under
+type txKey struct{}
+
+// WithinTx runs fn inside a transaction carried by the context it is given.
+// Queries made through the Database with that context join the transaction,
+// and nested WithinTx calls reuse it, so several repository writes commit or
+// roll back together.
+func (db *Database) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %v", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
 func (db *Database) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
 	return db.DB.QueryRowContext(ctx, query, args...)
 }
 
 func (db *Database) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx.QueryContext(ctx, query, args...)
+	}
 	return db.DB.QueryContext(ctx, query, args...)
 }
 
 func (db *Database) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx.ExecContext(ctx, query, args...)
+	}
 	return db.DB.ExecContext(ctx, query, args...)
 } 
\ No newline at end of file
@@ -0,0 +1,352 @@
+package implementations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+	"backend-go/internal/transliteration"
+)
+
+type DictionaryRepository struct {
+	db *sqlite.Database
+}
+
+func NewDictionaryRepository(db *sqlite.Database) *DictionaryRepository {
+	return &DictionaryRepository{db: db}
+}
+
+// Upsert stores a dictionary entry, replacing the entry of the same ID
+func (r *DictionaryRepository) Upsert(ctx context.Context, entry *models.DictionaryEntry) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.deleteEntry(ctx, entry.ID); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO dictionary_entries (id, common) VALUES (?, ?)`
+		if _, err := r.db.ExecContext(ctx, query, entry.ID, entry.Common); err != nil {
+			return fmt.Errorf("error storing dictionary entry %d: %v", entry.ID, err)
+		}
+
+		for i, kanji := range entry.Kanji {
+			query := `INSERT INTO dictionary_kanji (entry_id, position, text) VALUES (?, ?, ?)`
+			if _, err := r.db.ExecContext(ctx, query, entry.ID, i, kanji); err != nil {
+				return fmt.Errorf("error storing dictionary kanji: %v", err)
+			}
+		}
+		for i, reading := range entry.Readings {
+			query := `INSERT INTO dictionary_readings (entry_id, position, text, romaji) VALUES (?, ?, ?, ?)`
+			if _, err := r.db.ExecContext(ctx, query, entry.ID, i, reading, readingKey(reading)); err != nil {
+				return fmt.Errorf("error storing dictionary reading: %v", err)
+			}
+		}
+		for i, sense := range entry.Senses {
+			if err := r.insertSense(ctx, entry.ID, i, sense); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *DictionaryRepository) insertSense(ctx context.Context, entryID int64, position int, sense models.DictionarySense) error {
+	misc := sense.Misc
+	if misc == nil {
+		misc = []string{}
+	}
+	miscJSON, err := json.Marshal(misc)
+	if err != nil {
+		return fmt.Errorf("error marshaling sense misc: %v", err)
+	}
+
+	var senseID int64
+	query := `INSERT INTO dictionary_senses (entry_id, position, misc) VALUES (?, ?, ?) RETURNING id`
+	if err := r.db.QueryRowContext(ctx, query, entryID, position, string(miscJSON)).Scan(&senseID); err != nil {
+		return fmt.Errorf("error storing dictionary sense: %v", err)
+	}
+
+	for i, gloss := range sense.Glosses {
+		query := `INSERT INTO dictionary_glosses (sense_id, position, text) VALUES (?, ?, ?)`
+		if _, err := r.db.ExecContext(ctx, query, senseID, i, gloss); err != nil {
+			return fmt.Errorf("error storing dictionary gloss: %v", err)
+		}
+	}
+	for i, pos := range sense.PartsOfSpeech {
+		query := `
+			INSERT INTO dictionary_parts_of_speech (code, description) VALUES (?, ?)
+			ON CONFLICT (code) DO UPDATE SET description = excluded.description
+			WHERE description <> excluded.description`
+		if _, err := r.db.ExecContext(ctx, query, pos.Code, pos.Description); err != nil {
+			return fmt.Errorf("error storing part of speech %s: %v", pos.Code, err)
+		}
+
+		query = `INSERT INTO dictionary_sense_parts_of_speech (sense_id, position, code) VALUES (?, ?, ?)`
+		if _, err := r.db.ExecContext(ctx, query, senseID, i, pos.Code); err != nil {
+			return fmt.Errorf("error storing sense part of speech: %v", err)
+		}
+	}
+	return nil
+}
+
+// deleteEntry removes an entry and everything under it
+func (r *DictionaryRepository) deleteEntry(ctx context.Context, id int64) error {
+	senses := `SELECT id FROM dictionary_senses WHERE entry_id = ?`
+	for _, query := range []string{
+		`DELETE FROM dictionary_glosses WHERE sense_id IN (` + senses + `)`,
+		`DELETE FROM dictionary_sense_parts_of_speech WHERE sense_id IN (` + senses + `)`,
+		`DELETE FROM dictionary_senses WHERE entry_id = ?`,
+		`DELETE FROM dictionary_readings WHERE entry_id = ?`,
+		`DELETE FROM dictionary_kanji WHERE entry_id = ?`,
+		`DELETE FROM dictionary_entries WHERE id = ?`,
+	} {
+		if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+			return fmt.Errorf("error replacing dictionary entry %d: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// readingKey is the form readings are searched by: the reading romanized and
+// normalized, or "" for kana that cannot be romanized
+func readingKey(reading string) string {
+	romaji, err := transliteration.Romanize(reading, transliteration.Wapuro)
+	if err != nil {
+		return ""
+	}
+	return transliteration.Normalize(romaji)
+}
+
+// GetByID returns a dictionary entry, or nil when there is none with the ID
+func (r *DictionaryRepository) GetByID(ctx context.Context, id int64) (*models.DictionaryEntry, error) {
+	entries, err := r.loadEntries(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// Search returns a page of the entries matching the query: exact matches
+// first, then entries starting with it, common words before the others
+func (r *DictionaryRepository) Search(ctx context.Context, query models.DictionaryQuery, page, pageSize int) ([]*models.DictionaryEntry, int, error) {
+	var matches []string
+	var args []any
+	// match ranks the rows of table whose column starts with a non-empty
+	// value, 0 for the value itself and 1 for longer ones. The range keeps
+	// the search on the column index.
+	match := func(table, column, entryID, value string) {
+		if value == "" {
+			return
+		}
+		matches = append(matches, `
+			SELECT `+entryID+` AS entry_id, CASE WHEN `+column+` = ? THEN 0 ELSE 1 END AS rank
+			FROM `+table+`
+			WHERE `+column+` >= ? AND `+column+` < ?`)
+		args = append(args, value, value, value+string(utf8.MaxRune))
+	}
+
+	match("dictionary_kanji", "text", "entry_id", query.Kanji)
+	match("dictionary_readings", "romaji", "entry_id", transliteration.Normalize(query.Romaji))
+	if query.English != "" {
+		glosses := "dictionary_glosses JOIN dictionary_senses s ON s.id = dictionary_glosses.sense_id"
+		match(glosses, "dictionary_glosses.text", "s.entry_id", query.English)
+		// Verbs are glossed "to eat"
+		match(glosses, "dictionary_glosses.text", "s.entry_id", "to "+query.English)
+	}
+	if len(matches) == 0 {
+		return []*models.DictionaryEntry{}, 0, nil
+	}
+
+	ranked := `
+		SELECT m.entry_id, MIN(m.rank) AS rank
+		FROM (` + strings.Join(matches, " UNION ALL ") + `) m
+		GROUP BY m.entry_id`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+ranked+`)`, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting dictionary entries: %v", err)
+	}
+
+	listQuery := `
+		SELECT e.id
+		FROM (` + ranked + `) r
+		JOIN dictionary_entries e ON e.id = r.entry_id
+		ORDER BY r.rank, e.common DESC, e.id
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, listQuery, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error searching dictionary: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, 0, fmt.Errorf("error scanning dictionary entry: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating dictionary entries: %v", err)
+	}
+	rows.Close()
+
+	entries, err := r.loadEntries(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// loadEntries reads the entries with the given IDs in that order, skipping
+// IDs that do not exist
+func (r *DictionaryRepository) loadEntries(ctx context.Context, ids []int64) ([]*models.DictionaryEntry, error) {
+	entries := []*models.DictionaryEntry{}
+	if len(ids) == 0 {
+		return entries, nil
+	}
+
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	in := `(?` + strings.Repeat(", ?", len(ids)-1) + `)`
+
+	byID := make(map[int64]*models.DictionaryEntry, len(ids))
+	err := r.scan(ctx, `SELECT id, common FROM dictionary_entries WHERE id IN `+in, args, func(scan func(...any) error) error {
+		entry := &models.DictionaryEntry{Kanji: []string{}, Readings: []string{}, Senses: []models.DictionarySense{}}
+		if err := scan(&entry.ID, &entry.Common); err != nil {
+			return err
+		}
+		byID[entry.ID] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.scan(ctx, `SELECT entry_id, text FROM dictionary_kanji WHERE entry_id IN `+in+` ORDER BY entry_id, position`, args, func(scan func(...any) error) error {
+		var entryID int64
+		var text string
+		if err := scan(&entryID, &text); err != nil {
+			return err
+		}
+		byID[entryID].Kanji = append(byID[entryID].Kanji, text)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.scan(ctx, `SELECT entry_id, text FROM dictionary_readings WHERE entry_id IN `+in+` ORDER BY entry_id, position`, args, func(scan func(...any) error) error {
+		var entryID int64
+		var text string
+		if err := scan(&entryID, &text); err != nil {
+			return err
+		}
+		byID[entryID].Readings = append(byID[entryID].Readings, text)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Senses are read with their glosses and parts of speech in one pass each
+	senses := make(map[int64]*models.DictionarySense)
+	var senseOrder []int64
+	senseEntries := make(map[int64]int64)
+	err = r.scan(ctx, `SELECT id, entry_id, misc FROM dictionary_senses WHERE entry_id IN `+in+` ORDER BY entry_id, position`, args, func(scan func(...any) error) error {
+		var senseID, entryID int64
+		var misc []byte
+		if err := scan(&senseID, &entryID, &misc); err != nil {
+			return err
+		}
+		sense := &models.DictionarySense{PartsOfSpeech: []models.PartOfSpeech{}, Glosses: []string{}}
+		if err := json.Unmarshal(misc, &sense.Misc); err != nil {
+			return fmt.Errorf("error unmarshaling sense misc: %v", err)
+		}
+		senses[senseID] = sense
+		senseOrder = append(senseOrder, senseID)
+		senseEntries[senseID] = entryID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.scan(ctx, `
+		SELECT g.sense_id, g.text
+		FROM dictionary_glosses g
+		JOIN dictionary_senses s ON s.id = g.sense_id
+		WHERE s.entry_id IN `+in+`
+		ORDER BY g.sense_id, g.position`, args, func(scan func(...any) error) error {
+		var senseID int64
+		var text string
+		if err := scan(&senseID, &text); err != nil {
+			return err
+		}
+		senses[senseID].Glosses = append(senses[senseID].Glosses, text)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.scan(ctx, `
+		SELECT sp.sense_id, p.code, p.description
+		FROM dictionary_sense_parts_of_speech sp
+		JOIN dictionary_senses s ON s.id = sp.sense_id
+		JOIN dictionary_parts_of_speech p ON p.code = sp.code
+		WHERE s.entry_id IN `+in+`
+		ORDER BY sp.sense_id, sp.position`, args, func(scan func(...any) error) error {
+		var senseID int64
+		var pos models.PartOfSpeech
+		if err := scan(&senseID, &pos.Code, &pos.Description); err != nil {
+			return err
+		}
+		senses[senseID].PartsOfSpeech = append(senses[senseID].PartsOfSpeech, pos)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, senseID := range senseOrder {
+		entry := byID[senseEntries[senseID]]
+		entry.Senses = append(entry.Senses, *senses[senseID])
+	}
+	for _, id := range ids {
+		if entry, ok := byID[id]; ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// scan runs query and calls fn for every row, closing the rows before
+// returning as the database allows one connection
+func (r *DictionaryRepository) scan(ctx context.Context, query string, args []any, fn func(scan func(...any) error) error) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error reading dictionary: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows.Scan); err != nil {
+			return fmt.Errorf("error scanning dictionary: %v", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating dictionary: %v", err)
+	}
+	return nil
+}
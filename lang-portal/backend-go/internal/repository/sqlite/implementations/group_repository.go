@@ -19,37 +19,101 @@ func NewGroupRepository(db *sqlite.Database) *GroupRepository {
 	return &GroupRepository{db: db}
 }
 
+// Create stores a group; groups created without a language are Japanese.
+// A group with a filter is stored as a smart group. The group comes last
+// among the groups under its parent, or at the top without one.
 func (r *GroupRepository) Create(ctx context.Context, group *models.Group) error {
-	query := `
-		INSERT INTO groups (name, words_count)
-		VALUES (?, 0)
-		RETURNING id`
-
-	err := r.db.QueryRowContext(ctx, query, group.Name).Scan(&group.ID)
+	if group.Language == "" {
+		group.Language = models.DefaultLanguage
+	}
+	filter, err := groupFilterJSON(group.Filter)
 	if err != nil {
-		return fmt.Errorf("error creating group: %v", err)
+		return err
 	}
 
-	return nil
+	query := `
+		INSERT INTO groups (name, language, words_count, filter, parent_id, position)
+		VALUES (?, ?, 0, ?, ?, (
+			SELECT COALESCE(MAX(position) + 1, 0) FROM groups WHERE parent_id IS ? AND deleted_at IS NULL
+		))
+		RETURNING id, position`
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		err := r.db.QueryRowContext(ctx, query, group.Name, group.Language, filter, group.ParentID, group.ParentID).Scan(&group.ID, &group.Position)
+		if err != nil {
+			return fmt.Errorf("error creating group: %v", err)
+		}
+
+		return r.recordRevision(ctx, group.ID, models.RevisionCreate, nil)
+	})
 }
 
-func (r *GroupRepository) GetByID(ctx context.Context, id int64) (*models.Group, error) {
-	query := `
-		SELECT g.id, g.name, g.words_count, 
+// groupTree pairs each group not in the trash that roots selects with
+// itself and each group under it, as (root_id, id), to roll study sessions
+// up the tree. roots is a condition on groups; its arguments come first.
+func groupTree(roots string) string {
+	return `group_tree(root_id, id) AS (
+			SELECT id, id FROM groups WHERE deleted_at IS NULL AND (` + roots + `)
+			UNION
+			SELECT t.root_id, g.id
+			FROM group_tree t
+			JOIN groups g ON g.parent_id = t.id
+			WHERE g.deleted_at IS NULL
+		)`
+}
+
+// selectGroups reads the groups roots selects as scanGroup expects them,
+// with the last study time of the groups under them; callers pass the
+// arguments of roots first, then add the WHERE clause and GROUP BY g.id
+func selectGroups(roots string) string {
+	return `
+		WITH RECURSIVE ` + groupTree(roots) + `
+		SELECT g.id, g.name, g.language, g.words_count, g.filter, g.parent_id, g.position,
+		       EXISTS(SELECT 1 FROM groups c WHERE c.parent_id = g.id AND c.deleted_at IS NULL),
 		       MAX(s.created_at) as last_studied_at
 		FROM groups g
-		LEFT JOIN study_sessions s ON g.id = s.group_id
-		WHERE g.id = ?
-		GROUP BY g.id`
+		LEFT JOIN group_tree t ON t.root_id = g.id
+		LEFT JOIN study_sessions s ON s.group_id = t.id`
+}
 
+// scanGroup scans a row of selectGroups and reports whether the group has
+// groups under it
+func scanGroup(row interface{ Scan(...any) error }) (*models.Group, bool, error) {
 	group := &models.Group{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var filter, lastStudiedAt sql.NullString
+	var hasChildren bool
+	err := row.Scan(
 		&group.ID,
 		&group.Name,
+		&group.Language,
 		&group.WordsCount,
-		&group.LastStudiedAt,
+		&filter,
+		&group.ParentID,
+		&group.Position,
+		&hasChildren,
+		&lastStudiedAt,
 	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if group.LastStudiedAt, err = nullableTime(lastStudiedAt); err != nil {
+		return nil, false, fmt.Errorf("error parsing last studied time: %v", err)
+	}
+	if group.Filter, err = parseGroupFilter(filter); err != nil {
+		return nil, false, err
+	}
+	return group, hasChildren, nil
+}
+
+// GetByID returns a group, counting the words of a smart group or a group
+// with groups under it as they now are
+func (r *GroupRepository) GetByID(ctx context.Context, id int64) (*models.Group, error) {
+	query := selectGroups("id = ?") + `
+		WHERE g.id = ? AND g.deleted_at IS NULL
+		GROUP BY g.id`
 
+	group, hasChildren, err := scanGroup(r.db.QueryRowContext(ctx, query, id, id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -57,13 +121,37 @@ func (r *GroupRepository) GetByID(ctx context.Context, id int64) (*models.Group,
 		return nil, fmt.Errorf("error getting group: %v", err)
 	}
 
+	if err := r.countWords(ctx, group, hasChildren); err != nil {
+		return nil, err
+	}
+
 	return group, nil
 }
 
-func (r *GroupRepository) List(ctx context.Context, page, pageSize int, sortBy, order string) ([]*models.Group, int, error) {
+// GetByName looks a group up by its name
+func (r *GroupRepository) GetByName(ctx context.Context, name string) (*models.Group, error) {
+	var id int64
+	query := `SELECT id FROM groups WHERE name = ? AND deleted_at IS NULL ORDER BY id LIMIT 1`
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting group: %v", err)
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// List returns a page of groups. A non-empty language keeps the groups of
+// that language. Smart groups and groups with groups under them sort by
+// words count by their own added words, as their words are only counted
+// once the page is read.
+func (r *GroupRepository) List(ctx context.Context, page, pageSize int, sortBy, order, language string) ([]*models.Group, int, error) {
 	// Validate and sanitize sort parameters
 	allowedSortFields := map[string]string{
 		"name":        "g.name",
+		"language":    "g.language",
 		"words_count": "g.words_count",
 	}
 
@@ -82,98 +170,208 @@ func (r *GroupRepository) List(ctx context.Context, page, pageSize int, sortBy,
 
 	// Get total count
 	var total int
-	countQuery := `SELECT COUNT(*) FROM groups`
-	err := r.db.QueryRowContext(ctx, countQuery).Scan(&total)
+	countQuery := `SELECT COUNT(*) FROM groups WHERE deleted_at IS NULL AND (? = '' OR language = ?)`
+	err := r.db.QueryRowContext(ctx, countQuery, language, language).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error counting groups: %v", err)
 	}
 
-	// Main query
-	query := `
-		SELECT g.id, g.name, g.words_count, 
-		       MAX(s.created_at) as last_studied_at
-		FROM groups g
-		LEFT JOIN study_sessions s ON g.id = s.group_id
-		GROUP BY g.id
-		ORDER BY ` + dbSortField + ` ` + order + `
+	// Main query, rolling up the tree under the groups of the page only
+	pageQuery := `
+		SELECT g.id FROM groups g
+		WHERE g.deleted_at IS NULL AND (? = '' OR g.language = ?)
+		ORDER BY ` + dbSortField + ` ` + order + `, g.id
 		LIMIT ? OFFSET ?`
+	query := selectGroups("id IN ("+pageQuery+")") + `
+		WHERE g.id IN (` + pageQuery + `)
+		GROUP BY g.id
+		ORDER BY ` + dbSortField + ` ` + order + `, g.id`
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	pageArgs := []any{language, language, pageSize, offset}
+	rows, err := r.db.QueryContext(ctx, query, append(pageArgs, pageArgs...)...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error listing groups: %v", err)
 	}
 	defer rows.Close()
 
-	var groups []*models.Group
-	for rows.Next() {
-		group := &models.Group{}
-		err := rows.Scan(
-			&group.ID,
-			&group.Name,
-			&group.WordsCount,
-			&group.LastStudiedAt,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("error scanning group: %v", err)
-		}
-		groups = append(groups, group)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating groups: %v", err)
+	groups, err := r.scanGroups(ctx, rows)
+	if err != nil {
+		return nil, 0, err
 	}
-
 	return groups, total, nil
 }
 
+// Update renames a group and replaces the filter of a smart group
 func (r *GroupRepository) Update(ctx context.Context, group *models.Group) error {
+	filter, err := groupFilterJSON(group.Filter)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE groups 
-		SET name = ?
-		WHERE id = ?`
+		SET name = ?, filter = ?
+		WHERE id = ? AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, group.Name, group.ID)
-	if err != nil {
-		return fmt.Errorf("error updating group: %v", err)
-	}
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.snapshot(ctx, group.ID)
+		if err != nil {
+			return err
+		}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("error getting affected rows: %v", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("group not found")
-	}
+		result, err := r.db.ExecContext(ctx, query, group.Name, filter, group.ID)
+		if err != nil {
+			return fmt.Errorf("error updating group: %v", err)
+		}
 
-	return nil
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("group not found")
+		}
+
+		return r.recordRevision(ctx, group.ID, models.RevisionUpdate, before)
+	})
 }
 
+// Delete moves a group to the trash. Its words and study sessions are kept
+// until it is purged; the groups after it move up one.
 func (r *GroupRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM groups WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("error deleting group: %v", err)
-	}
+	query := `UPDATE groups SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.snapshot(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		result, err := r.db.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("error deleting group: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("group not found")
+		}
+		if err := r.renumberGroups(ctx, before.ParentID); err != nil {
+			return err
+		}
+
+		return recordRevision(ctx, r.db, models.RevisionGroup, id, models.RevisionDelete, before, nil)
+	})
+}
+
+// Restore takes a group out of the trash and reports whether it was there.
+// It comes back at its old position, moving the groups from there on down
+// one. Its words count leaves out the words deleted in the meantime.
+func (r *GroupRepository) Restore(ctx context.Context, id int64) (bool, error) {
+	restored := false
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query := `UPDATE groups SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+		result, err := r.db.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("error restoring group: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+		restored = true
+
+		// A group whose parent is gone comes back last at the top
+		orphan := `
+			UPDATE groups
+			SET parent_id = NULL,
+			    position = (SELECT COALESCE(MAX(position) + 1, 0) FROM groups WHERE parent_id IS NULL AND deleted_at IS NULL)
+			WHERE id = ? AND parent_id IS NOT NULL
+			  AND parent_id NOT IN (SELECT id FROM groups WHERE deleted_at IS NULL)`
+		if _, err := r.db.ExecContext(ctx, orphan, id); err != nil {
+			return fmt.Errorf("error restoring group: %v", err)
+		}
+
+		var parentID *int64
+		var position int
+		query = `SELECT parent_id, position FROM groups WHERE id = ?`
+		if err := r.db.QueryRowContext(ctx, query, id).Scan(&parentID, &position); err != nil {
+			return fmt.Errorf("error getting group: %v", err)
+		}
+		makeRoom := `
+			UPDATE groups SET position = position + 1
+			WHERE parent_id IS ? AND position >= ? AND id != ? AND deleted_at IS NULL`
+		if _, err := r.db.ExecContext(ctx, makeRoom, parentID, position, id); err != nil {
+			return fmt.Errorf("error restoring group: %v", err)
+		}
+		if err := r.renumberGroups(ctx, parentID); err != nil {
+			return err
+		}
 
-	rows, err := result.RowsAffected()
+		if err := r.updateWordsCount(ctx, id); err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, id, models.RevisionRestore, nil)
+	})
+	return restored, err
+}
+
+// groupSnapshot is the part of a group its revisions record. The words count
+// and last study time follow from its words and sessions.
+type groupSnapshot struct {
+	ID       int64              `json:"id"`
+	Name     string             `json:"name"`
+	Language string             `json:"language"`
+	Filter   *models.WordFilter `json:"filter,omitempty"`
+	ParentID *int64             `json:"parent_id,omitempty"`
+	Position int                `json:"position"`
+}
+
+func (r *GroupRepository) snapshot(ctx context.Context, id int64) (*groupSnapshot, error) {
+	snapshot := &groupSnapshot{}
+	var filter sql.NullString
+	query := `SELECT id, name, language, filter, parent_id, position FROM groups WHERE id = ?`
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&snapshot.ID, &snapshot.Name, &snapshot.Language, &filter, &snapshot.ParentID, &snapshot.Position)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("error getting affected rows: %v", err)
+		return nil, fmt.Errorf("error getting group: %v", err)
 	}
-	if rows == 0 {
-		return fmt.Errorf("group not found")
+	if snapshot.Filter, err = parseGroupFilter(filter); err != nil {
+		return nil, err
 	}
+	return snapshot, nil
+}
 
-	return nil
+// recordRevision records a change to a group, snapshotting the group as it
+// now is
+func (r *GroupRepository) recordRevision(ctx context.Context, id int64, action string, before *groupSnapshot) error {
+	after, err := r.snapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+	return recordRevision(ctx, r.db, models.RevisionGroup, id, action, before, after)
 }
 
+// GetStats returns the review stats of the study sessions of a group and
+// the groups under it
 func (r *GroupRepository) GetStats(ctx context.Context, groupID int64) (*models.GroupStats, error) {
 	query := `
+		WITH RECURSIVE ` + groupTree("id = ?") + `
 		SELECT 
 			COUNT(*) as total_reviews,
-			SUM(CASE WHEN correct THEN 1 ELSE 0 END) as correct_reviews
+			COALESCE(SUM(CASE WHEN correct THEN 1 ELSE 0 END), 0) as correct_reviews
 		FROM word_review_items wri
 		JOIN study_sessions ss ON wri.study_session_id = ss.id
-		WHERE ss.group_id = ?`
+		WHERE ss.group_id IN (SELECT id FROM group_tree)`
 
 	stats := &models.GroupStats{}
 	var correctReviews int
@@ -193,172 +391,407 @@ func (r *GroupRepository) GetStats(ctx context.Context, groupID int64) (*models.
 	return stats, nil
 }
 
+// AddWord adds a word to a group of its language
 func (r *GroupRepository) AddWord(ctx context.Context, groupID, wordID int64) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		var wordLanguage, groupLanguage sql.NullString
+		languageQuery := `
+			SELECT (SELECT language FROM terms WHERE id = ? AND deleted_at IS NULL),
+			       (SELECT language FROM groups WHERE id = ? AND deleted_at IS NULL)`
+		if err := r.db.QueryRowContext(ctx, languageQuery, wordID, groupID).Scan(&wordLanguage, &groupLanguage); err != nil {
+			return fmt.Errorf("error getting word and group languages: %v", err)
+		}
+		if wordLanguage != groupLanguage {
+			return fmt.Errorf("word %d (%s) is not in the language of group %d (%s)", wordID, wordLanguage.String, groupID, groupLanguage.String)
+		}
+
+		// Add word to group, last
+		query := `
+			INSERT INTO word_groups (word_id, group_id, position)
+			VALUES (?, ?, (SELECT COALESCE(MAX(position) + 1, 0) FROM word_groups WHERE group_id = ?))`
+		if _, err := r.db.ExecContext(ctx, query, wordID, groupID, groupID); err != nil {
+			return fmt.Errorf("error adding word to group: %v", err)
+		}
+
+		return r.updateWordsCount(ctx, groupID)
+	})
+}
+
+// HasWord reports whether the word is a member of the group, which for a
+// smart group is whether its filter matches the word
+func (r *GroupRepository) HasWord(ctx context.Context, groupID, wordID int64) (bool, error) {
+	members, args, err := groupMembers(ctx, r.db, groupID, nil)
 	if err != nil {
-		return fmt.Errorf("error starting transaction: %v", err)
+		return false, err
 	}
-	defer tx.Rollback()
 
-	// Add word to group
-	query := `INSERT INTO word_groups (word_id, group_id) VALUES (?, ?)`
-	_, err = tx.ExecContext(ctx, query, wordID, groupID)
-	if err != nil {
-		return fmt.Errorf("error adding word to group: %v", err)
+	var exists bool
+	query := `SELECT ? IN (` + members + `)`
+	if err := r.db.QueryRowContext(ctx, query, append([]any{wordID}, args...)...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("error checking group membership: %v", err)
 	}
+	return exists, nil
+}
 
-	// Update words count
-	updateQuery := `
-		UPDATE groups 
-		SET words_count = (
-			SELECT COUNT(*) 
-			FROM word_groups 
-			WHERE group_id = ?
-		)
-		WHERE id = ?`
+func (r *GroupRepository) RemoveWord(ctx context.Context, groupID, wordID int64) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		// Remove word from group
+		query := `DELETE FROM word_groups WHERE word_id = ? AND group_id = ?`
+		result, err := r.db.ExecContext(ctx, query, wordID, groupID)
+		if err != nil {
+			return fmt.Errorf("error removing word from group: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("word not found in group")
+		}
+
+		if err := r.renumberWords(ctx, groupID); err != nil {
+			return err
+		}
+		return r.updateWordsCount(ctx, groupID)
+	})
+}
+
+// AddWords adds words to a group at a position among its words, from 0, in
+// the order given, skipping those already in it, and returns how many were
+// added. The words from that position on move down; a position past the
+// last adds the words last. The words count is updated once for all of
+// them.
+func (r *GroupRepository) AddWords(ctx context.Context, groupID int64, wordIDs []int64, position int) (int, error) {
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM word_groups WHERE group_id = ? AND word_id = ?)`
+	makeRoom := `UPDATE word_groups SET position = position + 1 WHERE group_id = ? AND position >= ?`
+	insert := `INSERT INTO word_groups (word_id, group_id, position) VALUES (?, ?, ?)`
+
+	added := 0
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.renumberWords(ctx, groupID); err != nil {
+			return err
+		}
+		var count int
+		countQuery := `SELECT COUNT(*) FROM word_groups WHERE group_id = ?`
+		if err := r.db.QueryRowContext(ctx, countQuery, groupID).Scan(&count); err != nil {
+			return fmt.Errorf("error counting group words: %v", err)
+		}
+		position = min(position, count)
+
+		for _, wordID := range wordIDs {
+			var exists bool
+			if err := r.db.QueryRowContext(ctx, existsQuery, groupID, wordID).Scan(&exists); err != nil {
+				return fmt.Errorf("error checking group membership: %v", err)
+			}
+			if exists {
+				continue
+			}
+			if _, err := r.db.ExecContext(ctx, makeRoom, groupID, position); err != nil {
+				return fmt.Errorf("error adding word to group: %v", err)
+			}
+			if _, err := r.db.ExecContext(ctx, insert, wordID, groupID, position); err != nil {
+				return fmt.Errorf("error adding word to group: %v", err)
+			}
+			position++
+			added++
+		}
 
-	_, err = tx.ExecContext(ctx, updateQuery, groupID, groupID)
+		return r.updateWordsCount(ctx, groupID)
+	})
 	if err != nil {
-		return fmt.Errorf("error updating words count: %v", err)
+		return 0, err
 	}
+	return added, nil
+}
+
+// RemoveWords removes words from a group, skipping those not in it, and
+// returns how many were removed
+func (r *GroupRepository) RemoveWords(ctx context.Context, groupID int64, wordIDs []int64) (int, error) {
+	query := `DELETE FROM word_groups WHERE word_id = ? AND group_id = ?`
+
+	removed := 0
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		for _, wordID := range wordIDs {
+			result, err := r.db.ExecContext(ctx, query, wordID, groupID)
+			if err != nil {
+				return fmt.Errorf("error removing word from group: %v", err)
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("error getting affected rows: %v", err)
+			}
+			removed += int(rows)
+		}
 
-	return tx.Commit()
+		if err := r.renumberWords(ctx, groupID); err != nil {
+			return err
+		}
+		return r.updateWordsCount(ctx, groupID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
 }
 
-func (r *GroupRepository) RemoveWord(ctx context.Context, groupID, wordID int64) error {
-	tx, err := r.db.BeginTx(ctx, nil)
+// ReorderWords puts words of a group first, in the order given, followed
+// by its other words in the order they had, and returns how many of the
+// words were in the group
+func (r *GroupRepository) ReorderWords(ctx context.Context, groupID int64, wordIDs []int64) (int, error) {
+	query := `UPDATE word_groups SET position = ? WHERE group_id = ? AND word_id = ?`
+
+	reordered := 0
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		// Negative positions sort the given words before every other word
+		for i, wordID := range wordIDs {
+			result, err := r.db.ExecContext(ctx, query, i-len(wordIDs), groupID, wordID)
+			if err != nil {
+				return fmt.Errorf("error reordering group words: %v", err)
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("error getting affected rows: %v", err)
+			}
+			reordered += int(rows)
+		}
+
+		return r.renumberWords(ctx, groupID)
+	})
 	if err != nil {
-		return fmt.Errorf("error starting transaction: %v", err)
+		return 0, err
 	}
-	defer tx.Rollback()
+	return reordered, nil
+}
 
-	// Remove word from group
-	query := `DELETE FROM word_groups WHERE word_id = ? AND group_id = ?`
-	result, err := tx.ExecContext(ctx, query, wordID, groupID)
+// renumberWords numbers the words of a group from 0 in the order of their
+// positions, closing the gaps removals leave
+func (r *GroupRepository) renumberWords(ctx context.Context, groupID int64) error {
+	query := `SELECT word_id FROM word_groups WHERE group_id = ? ORDER BY position, word_id`
+	rows, err := r.db.QueryContext(ctx, query, groupID)
 	if err != nil {
-		return fmt.Errorf("error removing word from group: %v", err)
+		return fmt.Errorf("error listing group words: %v", err)
 	}
+	defer rows.Close()
+
+	var wordIDs []int64
+	for rows.Next() {
+		var wordID int64
+		if err := rows.Scan(&wordID); err != nil {
+			return fmt.Errorf("error scanning group word: %v", err)
+		}
+		wordIDs = append(wordIDs, wordID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating group words: %v", err)
+	}
+	rows.Close()
 
-	rows, err := result.RowsAffected()
+	update := `UPDATE word_groups SET position = ? WHERE group_id = ? AND word_id = ? AND position != ?`
+	for i, wordID := range wordIDs {
+		if _, err := r.db.ExecContext(ctx, update, i, groupID, wordID, i); err != nil {
+			return fmt.Errorf("error numbering group words: %v", err)
+		}
+	}
+	return nil
+}
+
+// renumberGroups numbers the groups not in the trash under a parent, or at
+// the top for a nil parentID, from 0 in the order of their positions,
+// closing the gaps the trash leaves
+func (r *GroupRepository) renumberGroups(ctx context.Context, parentID *int64) error {
+	query := `SELECT id FROM groups WHERE parent_id IS ? AND deleted_at IS NULL ORDER BY position, id`
+	rows, err := r.db.QueryContext(ctx, query, parentID)
 	if err != nil {
-		return fmt.Errorf("error getting affected rows: %v", err)
+		return fmt.Errorf("error listing groups: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("error scanning group: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating groups: %v", err)
 	}
-	if rows == 0 {
-		return fmt.Errorf("word not found in group")
+	rows.Close()
+
+	update := `UPDATE groups SET position = ? WHERE id = ? AND position != ?`
+	for i, id := range ids {
+		if _, err := r.db.ExecContext(ctx, update, i, id, i); err != nil {
+			return fmt.Errorf("error numbering groups: %v", err)
+		}
 	}
+	return nil
+}
 
-	// Update words count
+// groupWordsCount counts the words of the group being updated that are not
+// in the trash
+const groupWordsCount = `(
+			SELECT COUNT(*)
+			FROM word_groups wg
+			JOIN terms t ON t.id = wg.word_id
+			WHERE wg.group_id = groups.id AND t.deleted_at IS NULL
+		)`
+
+// updateWordsCount recomputes the cached words_count of a group. Smart
+// groups add no words and keep a count of 0, counting theirs when read.
+func (r *GroupRepository) updateWordsCount(ctx context.Context, groupID int64) error {
 	updateQuery := `
 		UPDATE groups 
-		SET words_count = (
-			SELECT COUNT(*) 
-			FROM word_groups 
-			WHERE group_id = ?
-		)
+		SET words_count = ` + groupWordsCount + `
 		WHERE id = ?`
 
-	_, err = tx.ExecContext(ctx, updateQuery, groupID, groupID)
-	if err != nil {
+	if _, err := r.db.ExecContext(ctx, updateQuery, groupID); err != nil {
 		return fmt.Errorf("error updating words count: %v", err)
 	}
-
-	return tx.Commit()
+	return nil
 }
 
-func (r *GroupRepository) ListWords(ctx context.Context, groupID int64, page, pageSize int) ([]*models.WordWithStats, int, error) {
-	// Get total count
-	var total int
-	countQuery := `
-		SELECT COUNT(*) 
-		FROM word_groups 
-		WHERE group_id = ?`
-	
-	err := r.db.QueryRowContext(ctx, countQuery, groupID).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("error counting words in group: %v", err)
+// scanGroups scans and closes rows of selectGroups, then counts the words
+// of the groups that need it
+func (r *GroupRepository) scanGroups(ctx context.Context, rows *sql.Rows) ([]*models.Group, error) {
+	defer rows.Close()
+
+	var groups []*models.Group
+	var rollUp []bool
+	for rows.Next() {
+		group, hasChildren, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning group: %v", err)
+		}
+		groups = append(groups, group)
+		rollUp = append(rollUp, hasChildren)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating groups: %v", err)
 	}
+	rows.Close()
 
-	// Calculate offset
-	offset := (page - 1) * pageSize
+	for i, group := range groups {
+		if err := r.countWords(ctx, group, rollUp[i]); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
 
-	// Main query
-	query := `
-		SELECT 
-			w.id, w.kanji, w.romaji, w.english, w.parts,
-			COALESCE(correct_reviews.count, 0) as correct_count,
-			COALESCE(wrong_reviews.count, 0) as wrong_count
-		FROM words w
-		JOIN word_groups wg ON w.id = wg.word_id
-		LEFT JOIN (
-			SELECT word_id, COUNT(*) as count
-			FROM word_review_items
-			WHERE correct = true
-			GROUP BY word_id
-		) correct_reviews ON w.id = correct_reviews.word_id
-		LEFT JOIN (
-			SELECT word_id, COUNT(*) as count
-			FROM word_review_items
-			WHERE correct = false
-			GROUP BY word_id
-		) wrong_reviews ON w.id = wrong_reviews.word_id
-		WHERE wg.group_id = ?
-		LIMIT ? OFFSET ?`
+// ListChildren returns the groups directly under a group, in order
+func (r *GroupRepository) ListChildren(ctx context.Context, parentID int64) ([]*models.Group, error) {
+	query := selectGroups("parent_id = ?") + `
+		WHERE g.parent_id = ? AND g.deleted_at IS NULL
+		GROUP BY g.id
+		ORDER BY g.position, g.id`
 
-	rows, err := r.db.QueryContext(ctx, query, groupID, pageSize, offset)
+	rows, err := r.db.QueryContext(ctx, query, parentID, parentID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error listing words: %v", err)
+		return nil, fmt.Errorf("error listing child groups: %v", err)
 	}
-	defer rows.Close()
-
-	var words []*models.WordWithStats
-	for rows.Next() {
-		var word models.WordWithStats
-		var partsJSON []byte
-		var correctCount, wrongCount int
+	return r.scanGroups(ctx, rows)
+}
 
-		err := rows.Scan(
-			&word.ID,
-			&word.Kanji,
-			&word.Romaji,
-			&word.English,
-			&partsJSON,
-			&correctCount,
-			&wrongCount,
-		)
+// SetParent moves a group under another group, or to the top for a nil
+// parentID, at a position among the groups there, from 0. A position past
+// the last puts it last; the groups after it move down one.
+func (r *GroupRepository) SetParent(ctx context.Context, id int64, parentID *int64, position int) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		var oldParentID *int64
+		var oldPosition int
+		query := `SELECT parent_id, position FROM groups WHERE id = ? AND deleted_at IS NULL`
+		err := r.db.QueryRowContext(ctx, query, id).Scan(&oldParentID, &oldPosition)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("group not found")
+		}
+		if err != nil {
+			return fmt.Errorf("error getting group: %v", err)
+		}
+		before, err := r.snapshot(ctx, id)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error scanning word: %v", err)
+			return err
 		}
 
-		if err := json.Unmarshal(partsJSON, &word.Parts); err != nil {
-			return nil, 0, fmt.Errorf("error unmarshaling parts: %v", err)
+		closeGap := `
+			UPDATE groups SET position = position - 1
+			WHERE parent_id IS ? AND position > ? AND id != ? AND deleted_at IS NULL`
+		if _, err := r.db.ExecContext(ctx, closeGap, oldParentID, oldPosition, id); err != nil {
+			return fmt.Errorf("error moving group: %v", err)
 		}
 
-		word.Stats = models.WordStats{
-			CorrectCount: correctCount,
-			WrongCount:   wrongCount,
+		var siblings int
+		countQuery := `SELECT COUNT(*) FROM groups WHERE parent_id IS ? AND id != ? AND deleted_at IS NULL`
+		if err := r.db.QueryRowContext(ctx, countQuery, parentID, id).Scan(&siblings); err != nil {
+			return fmt.Errorf("error counting groups: %v", err)
 		}
+		position = min(position, siblings)
 
-		totalAttempts := correctCount + wrongCount
-		if totalAttempts > 0 {
-			word.Stats.Accuracy = float64(correctCount) / float64(totalAttempts) * 100
+		makeRoom := `
+			UPDATE groups SET position = position + 1
+			WHERE parent_id IS ? AND position >= ? AND id != ? AND deleted_at IS NULL`
+		if _, err := r.db.ExecContext(ctx, makeRoom, parentID, position, id); err != nil {
+			return fmt.Errorf("error moving group: %v", err)
 		}
 
-		words = append(words, &word)
+		update := `UPDATE groups SET parent_id = ?, position = ? WHERE id = ?`
+		if _, err := r.db.ExecContext(ctx, update, parentID, position, id); err != nil {
+			return fmt.Errorf("error moving group: %v", err)
+		}
+		return r.recordRevision(ctx, id, models.RevisionUpdate, before)
+	})
+}
+
+// countWords sets the words count of a smart group, or of a group with
+// groups under it, to the number of words it now holds
+func (r *GroupRepository) countWords(ctx context.Context, group *models.Group, hasChildren bool) error {
+	if !group.Smart() && !hasChildren {
+		return nil
+	}
+	members, args, err := groupMembers(ctx, r.db, group.ID, nil)
+	if err != nil {
+		return err
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating words: %v", err)
+	query := `SELECT COUNT(*) FROM (` + members + `)`
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&group.WordsCount); err != nil {
+		return fmt.Errorf("error counting group words: %v", err)
 	}
+	return nil
+}
 
-	return words, total, nil
+// groupFilterJSON returns the filter of a group as it is stored: NULL for a
+// group that is not smart
+func groupFilterJSON(filter *models.WordFilter) (any, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling group filter: %v", err)
+	}
+	return string(data), nil
 }
 
+// ListWords returns a page of the words of a group matching filter, by
+// their position in the group
+func (r *GroupRepository) ListWords(ctx context.Context, groupID int64, page, pageSize int, filter models.WordFilter) ([]*models.WordWithStats, int, error) {
+	filter.GroupID = groupID
+	return listWords(ctx, r.db, filter, page, pageSize, "position", "position", "asc")
+}
+
+// ListStudySessions returns a page of the study sessions of a group and the
+// groups under it, newest first
 func (r *GroupRepository) ListStudySessions(ctx context.Context, groupID int64, page, pageSize int) ([]models.StudySessionWithStats, int, error) {
 	offset := (page - 1) * pageSize
 
 	// Get total count
 	var total int
-	countQuery := `SELECT COUNT(*) FROM study_sessions WHERE group_id = ?`
+	countQuery := `
+		WITH RECURSIVE ` + groupTree("id = ?") + `
+		SELECT COUNT(*) FROM study_sessions
+		WHERE group_id IN (SELECT id FROM group_tree)`
 	err := r.db.QueryRowContext(ctx, countQuery, groupID).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error counting study sessions: %v", err)
@@ -366,6 +799,7 @@ func (r *GroupRepository) ListStudySessions(ctx context.Context, groupID int64,
 
 	// Get study sessions with stats
 	query := `
+		WITH RECURSIVE ` + groupTree("id = ?") + `
 		SELECT 
 			s.id, s.created_at,
 			a.id, a.name, a.url,
@@ -374,7 +808,7 @@ func (r *GroupRepository) ListStudySessions(ctx context.Context, groupID int64,
 		FROM study_sessions s
 		JOIN study_activities a ON s.study_activity_id = a.id
 		LEFT JOIN word_review_items r ON s.id = r.study_session_id
-		WHERE s.group_id = ?
+		WHERE s.group_id IN (SELECT id FROM group_tree)
 		GROUP BY s.id
 		ORDER BY s.created_at DESC
 		LIMIT ? OFFSET ?`
@@ -409,49 +843,9 @@ func (r *GroupRepository) ListStudySessions(ctx context.Context, groupID int64,
 	return sessions, total, nil
 }
 
-// GetGroupWords retrieves paginated words with stats for a group
-func (r *GroupRepository) GetGroupWords(ctx context.Context, groupID int64, page int, sortBy, order string) ([]*models.WordWithStats, int, error) {
-	offset := (page - 1) * 10
-	query := `
-		SELECT w.id, w.kanji, w.romaji, w.english, w.parts,
-			   COUNT(wr.id) as review_count, 
-			   SUM(CASE WHEN wr.correct THEN 1 ELSE 0 END) as correct_count
-		FROM words w
-		JOIN group_words gw ON w.id = gw.word_id
-		LEFT JOIN word_reviews wr ON w.id = wr.word_id
-		WHERE gw.group_id = ?
-		GROUP BY w.id
-		ORDER BY ` + sortBy + ` ` + order + `
-		LIMIT 10 OFFSET ?`
-
-	rows, err := r.db.QueryContext(ctx, query, groupID, offset)
-	if err != nil {
-		return nil, 0, err
-	}
-	defer rows.Close()
-
-	var words []*models.WordWithStats
-	for rows.Next() {
-		var w models.WordWithStats
-		var partsJSON []byte
-		err := rows.Scan(
-			&w.ID, &w.Kanji, &w.Romaji, &w.English, &partsJSON,
-			&w.Stats.WrongCount, &w.Stats.CorrectCount, 
-		)
-		if err != nil {
-			return nil, 0, err
-		}
-		if err := json.Unmarshal(partsJSON, &w.Parts); err != nil {
-			return nil, 0, err
-		}
-		words = append(words, &w)
-	}
-
-	var total int
-	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM group_words WHERE group_id = ?", groupID).Scan(&total)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return words, total, nil
-} 
\ No newline at end of file
+// GetGroupWords retrieves paginated words with stats for a group, by their
+// position in the group unless sorted otherwise
+func (r *GroupRepository) GetGroupWords(ctx context.Context, groupID int64, page int, sortBy, order string, filter models.WordFilter) ([]*models.WordWithStats, int, error) {
+	filter.GroupID = groupID
+	return listWords(ctx, r.db, filter, page, 10, sortBy, "position", order)
+}
\ No newline at end of file
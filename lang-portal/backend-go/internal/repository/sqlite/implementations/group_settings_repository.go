@@ -0,0 +1,47 @@
+package implementations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"backend-go/internal/repository/sqlite"
+)
+
+type GroupSettingsRepository struct {
+	db *sqlite.Database
+}
+
+func NewGroupSettingsRepository(db *sqlite.Database) *GroupSettingsRepository {
+	return &GroupSettingsRepository{db: db}
+}
+
+// GetScheduler returns the scheduler configured for a group, or an empty
+// string when the group uses the default.
+func (r *GroupSettingsRepository) GetScheduler(ctx context.Context, groupID int64) (string, error) {
+	query := `SELECT scheduler FROM group_settings WHERE group_id = ?`
+
+	var scheduler string
+	err := r.db.QueryRowContext(ctx, query, groupID).Scan(&scheduler)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error getting group scheduler: %v", err)
+	}
+
+	return scheduler, nil
+}
+
+func (r *GroupSettingsRepository) SetScheduler(ctx context.Context, groupID int64, scheduler string) error {
+	query := `
+		INSERT INTO group_settings (group_id, scheduler)
+		VALUES (?, ?)
+		ON CONFLICT (group_id) DO UPDATE SET scheduler = excluded.scheduler`
+
+	if _, err := r.db.ExecContext(ctx, query, groupID, scheduler); err != nil {
+		return fmt.Errorf("error setting group scheduler: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package implementations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+)
+
+type KanjiRepository struct {
+	db *sqlite.Database
+}
+
+func NewKanjiRepository(db *sqlite.Database) *KanjiRepository {
+	return &KanjiRepository{db: db}
+}
+
+// Upsert stores a kanji, replacing the entry of the same character
+func (r *KanjiRepository) Upsert(ctx context.Context, kanji *models.Kanji) error {
+	lists := make([]string, 4)
+	for i, list := range [][]string{kanji.OnReadings, kanji.KunReadings, kanji.Nanori, kanji.Meanings} {
+		if list == nil {
+			list = []string{}
+		}
+		data, err := json.Marshal(list)
+		if err != nil {
+			return fmt.Errorf("error marshaling kanji %s: %v", kanji.Character, err)
+		}
+		lists[i] = string(data)
+	}
+
+	query := `
+		INSERT INTO kanji (character, grade, stroke_count, frequency, jlpt, on_readings, kun_readings, nanori, meanings)
+		VALUES (?, NULLIF(?, 0), ?, NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, ?)
+		ON CONFLICT (character) DO UPDATE SET
+			grade = excluded.grade,
+			stroke_count = excluded.stroke_count,
+			frequency = excluded.frequency,
+			jlpt = excluded.jlpt,
+			on_readings = excluded.on_readings,
+			kun_readings = excluded.kun_readings,
+			nanori = excluded.nanori,
+			meanings = excluded.meanings`
+
+	_, err := r.db.ExecContext(ctx, query,
+		kanji.Character,
+		kanji.Grade,
+		kanji.StrokeCount,
+		kanji.Frequency,
+		kanji.JLPT,
+		lists[0],
+		lists[1],
+		lists[2],
+		lists[3],
+	)
+	if err != nil {
+		return fmt.Errorf("error storing kanji %s: %v", kanji.Character, err)
+	}
+	return nil
+}
+
+// GetByCharacter looks a kanji up, returning nil when it is not in the
+// dictionary
+func (r *KanjiRepository) GetByCharacter(ctx context.Context, character string) (*models.Kanji, error) {
+	kanji, err := r.query(ctx, `WHERE character = ?`, character)
+	if err != nil {
+		return nil, err
+	}
+	if len(kanji) == 0 {
+		return nil, nil
+	}
+	return kanji[0], nil
+}
+
+// ListByCharacters returns the dictionary entries of the given characters,
+// keyed by character. Characters missing from the dictionary are left out.
+func (r *KanjiRepository) ListByCharacters(ctx context.Context, characters []string) (map[string]*models.Kanji, error) {
+	entries := make(map[string]*models.Kanji, len(characters))
+	if len(characters) == 0 {
+		return entries, nil
+	}
+
+	args := make([]any, len(characters))
+	for i, character := range characters {
+		args[i] = character
+	}
+	kanji, err := r.query(ctx, `WHERE character IN (?`+strings.Repeat(", ?", len(characters)-1)+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range kanji {
+		entries[entry.Character] = entry
+	}
+	return entries, nil
+}
+
+func (r *KanjiRepository) query(ctx context.Context, where string, args ...any) ([]*models.Kanji, error) {
+	query := `
+		SELECT character, COALESCE(grade, 0), stroke_count, COALESCE(frequency, 0), COALESCE(jlpt, 0),
+		       on_readings, kun_readings, nanori, meanings
+		FROM kanji ` + where
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting kanji: %v", err)
+	}
+	defer rows.Close()
+
+	var kanji []*models.Kanji
+	for rows.Next() {
+		entry := &models.Kanji{}
+		var onReadings, kunReadings, nanori, meanings []byte
+		err := rows.Scan(
+			&entry.Character,
+			&entry.Grade,
+			&entry.StrokeCount,
+			&entry.Frequency,
+			&entry.JLPT,
+			&onReadings,
+			&kunReadings,
+			&nanori,
+			&meanings,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning kanji: %v", err)
+		}
+
+		for _, list := range []struct {
+			data   []byte
+			target *[]string
+		}{
+			{onReadings, &entry.OnReadings},
+			{kunReadings, &entry.KunReadings},
+			{nanori, &entry.Nanori},
+			{meanings, &entry.Meanings},
+		} {
+			if err := json.Unmarshal(list.data, list.target); err != nil {
+				return nil, fmt.Errorf("error unmarshaling kanji %s: %v", entry.Character, err)
+			}
+		}
+		kanji = append(kanji, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating kanji: %v", err)
+	}
+	return kanji, nil
+}
@@ -0,0 +1,54 @@
+package implementations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+)
+
+type LanguageRepository struct {
+	db *sqlite.Database
+}
+
+func NewLanguageRepository(db *sqlite.Database) *LanguageRepository {
+	return &LanguageRepository{db: db}
+}
+
+// List returns every language, by name
+func (r *LanguageRepository) List(ctx context.Context) ([]*models.Language, error) {
+	query := `SELECT code, name, script, transliteration FROM languages ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing languages: %v", err)
+	}
+	defer rows.Close()
+
+	languages := []*models.Language{}
+	for rows.Next() {
+		language := &models.Language{}
+		if err := rows.Scan(&language.Code, &language.Name, &language.Script, &language.Transliteration); err != nil {
+			return nil, fmt.Errorf("error scanning language: %v", err)
+		}
+		languages = append(languages, language)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating languages: %v", err)
+	}
+	return languages, nil
+}
+
+func (r *LanguageRepository) GetByCode(ctx context.Context, code string) (*models.Language, error) {
+	query := `SELECT code, name, script, transliteration FROM languages WHERE code = ?`
+	language := &models.Language{}
+	err := r.db.QueryRowContext(ctx, query, code).Scan(&language.Code, &language.Name, &language.Script, &language.Transliteration)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting language: %v", err)
+	}
+	return language, nil
+}
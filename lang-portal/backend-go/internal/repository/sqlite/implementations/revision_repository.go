@@ -0,0 +1,191 @@
+package implementations
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+)
+
+type RevisionRepository struct {
+	db *sqlite.Database
+}
+
+func NewRevisionRepository(db *sqlite.Database) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+// revisionTable returns the table holding the history of a kind of entity
+// and the column naming the entity
+func revisionTable(kind models.RevisionKind) (string, string, error) {
+	switch kind {
+	case models.RevisionWord, models.RevisionGroup, models.RevisionStudyActivity:
+		return string(kind) + "_revisions", string(kind) + "_id", nil
+	}
+	return "", "", fmt.Errorf("unknown revision kind %q", kind)
+}
+
+// List returns a page of the history of an entity, newest first
+func (r *RevisionRepository) List(ctx context.Context, kind models.RevisionKind, entityID int64, page, pageSize int) ([]*models.Revision, int, error) {
+	table, column, err := revisionTable(kind)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s = ?`, table, column)
+	if err := r.db.QueryRowContext(ctx, countQuery, entityID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting revisions: %v", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, action, before, after, author, reason, reverted_to, created_at
+		FROM %s
+		WHERE %s = ?
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?`, table, column)
+
+	rows, err := r.db.QueryContext(ctx, query, entityID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing revisions: %v", err)
+	}
+	defer rows.Close()
+
+	revisions := []*models.Revision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating revisions: %v", err)
+	}
+
+	return revisions, total, nil
+}
+
+// GetByID returns a revision of an entity, or nil when the entity has no
+// revision with that id
+func (r *RevisionRepository) GetByID(ctx context.Context, kind models.RevisionKind, entityID, id int64) (*models.Revision, error) {
+	table, column, err := revisionTable(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, action, before, after, author, reason, reverted_to, created_at
+		FROM %s
+		WHERE id = ? AND %s = ?`, table, column)
+
+	revision, err := scanRevision(r.db.QueryRowContext(ctx, query, id, entityID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return revision, err
+}
+
+func scanRevision(row interface{ Scan(...any) error }) (*models.Revision, error) {
+	revision := &models.Revision{}
+	var before, after sql.NullString
+	var revertedTo sql.NullInt64
+	err := row.Scan(
+		&revision.ID,
+		&revision.Action,
+		&before,
+		&after,
+		&revision.Author,
+		&revision.Reason,
+		&revertedTo,
+		&revision.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error scanning revision: %v", err)
+	}
+
+	if before.Valid {
+		revision.Before = json.RawMessage(before.String)
+	}
+	if after.Valid {
+		revision.After = json.RawMessage(after.String)
+	}
+	if revertedTo.Valid {
+		revision.RevertedTo = &revertedTo.Int64
+	}
+	return revision, nil
+}
+
+// recordRevision appends a change to the history of an entity, with the
+// author and reason of the change carried by ctx. before and after are
+// stored as JSON, a nil snapshot as NULL. An update made with the change of
+// a revert is recorded as a revert, and an update that leaves the entity as
+// it was is not recorded.
+func recordRevision(ctx context.Context, db *sqlite.Database, kind models.RevisionKind, entityID int64, action string, before, after any) error {
+	table, column, err := revisionTable(kind)
+	if err != nil {
+		return err
+	}
+	beforeJSON, err := snapshotJSON(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshotJSON(after)
+	if err != nil {
+		return err
+	}
+
+	change := models.ChangeFromContext(ctx)
+	var revertedTo any
+	if action == models.RevisionUpdate {
+		if change.RevertTo != 0 {
+			action, revertedTo = models.RevisionRevert, change.RevertTo
+		} else if bytes.Equal(beforeJSON, afterJSON) {
+			return nil
+		}
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (%s, action, before, after, author, reason, reverted_to)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, table, column)
+
+	_, err = db.ExecContext(ctx, query,
+		entityID,
+		action,
+		nullableJSON(beforeJSON),
+		nullableJSON(afterJSON),
+		change.Author,
+		change.Reason,
+		revertedTo,
+	)
+	if err != nil {
+		return fmt.Errorf("error recording %s revision: %v", kind, err)
+	}
+	return nil
+}
+
+// snapshotJSON marshals a snapshot, returning nil for a missing one
+func snapshotJSON(snapshot any) ([]byte, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling revision snapshot: %v", err)
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	return data, nil
+}
+
+func nullableJSON(data []byte) any {
+	if data == nil {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,324 @@
+package implementations
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+)
+
+type ScheduleRepository struct {
+	db *sqlite.Database
+}
+
+func NewScheduleRepository(db *sqlite.Database) *ScheduleRepository {
+	return &ScheduleRepository{db: db}
+}
+
+func (r *ScheduleRepository) Get(ctx context.Context, wordID int64) (*models.WordSchedule, error) {
+	query := `
+		SELECT word_id, algorithm, repetitions, lapses, interval_days,
+		       ease_factor, box, stability, difficulty, due_at, last_reviewed_at
+		FROM word_schedules
+		WHERE word_id = ?`
+
+	schedule := &models.WordSchedule{}
+	err := r.db.QueryRowContext(ctx, query, wordID).Scan(
+		&schedule.WordID,
+		&schedule.Algorithm,
+		&schedule.Repetitions,
+		&schedule.Lapses,
+		&schedule.IntervalDays,
+		&schedule.EaseFactor,
+		&schedule.Box,
+		&schedule.Stability,
+		&schedule.Difficulty,
+		&schedule.DueAt,
+		&schedule.LastReviewedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting word schedule: %v", err)
+	}
+
+	return schedule, nil
+}
+
+func (r *ScheduleRepository) Upsert(ctx context.Context, schedule *models.WordSchedule) error {
+	query := `
+		INSERT INTO word_schedules (
+			word_id, algorithm, repetitions, lapses, interval_days,
+			ease_factor, box, stability, difficulty, due_at, last_reviewed_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (word_id) DO UPDATE SET
+			algorithm = excluded.algorithm,
+			repetitions = excluded.repetitions,
+			lapses = excluded.lapses,
+			interval_days = excluded.interval_days,
+			ease_factor = excluded.ease_factor,
+			box = excluded.box,
+			stability = excluded.stability,
+			difficulty = excluded.difficulty,
+			due_at = excluded.due_at,
+			last_reviewed_at = excluded.last_reviewed_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		schedule.WordID,
+		schedule.Algorithm,
+		schedule.Repetitions,
+		schedule.Lapses,
+		schedule.IntervalDays,
+		schedule.EaseFactor,
+		schedule.Box,
+		schedule.Stability,
+		schedule.Difficulty,
+		schedule.DueAt.UTC(),
+		schedule.LastReviewedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("error saving word schedule: %v", err)
+	}
+
+	return nil
+}
+
+// ListDue returns words whose review is due at now, most overdue first,
+// followed by words that have never been reviewed, in their order in the
+// group. A groupID of 0 draws from all words and an empty language from
+// every language.
+func (r *ScheduleRepository) ListDue(ctx context.Context, groupID int64, language string, now time.Time, limit int) ([]*models.DueWord, error) {
+	members, membersArgs, err := r.groupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT 
+			w.id, w.language, w.term, w.reading, w.ruby, w.transliteration, w.gloss, w.parts,
+			COALESCE(correct_reviews.count, 0) as correct_count,
+			COALESCE(wrong_reviews.count, 0) as wrong_count,
+			s.word_id, s.algorithm, s.repetitions, s.lapses, s.interval_days,
+			s.ease_factor, s.box, s.stability, s.difficulty, s.due_at, s.last_reviewed_at
+		FROM terms w
+		LEFT JOIN word_schedules s ON w.id = s.word_id
+		LEFT JOIN (
+			SELECT word_id, COUNT(*) as count
+			FROM word_review_items
+			WHERE correct = true
+			GROUP BY word_id
+		) correct_reviews ON w.id = correct_reviews.word_id
+		LEFT JOIN (
+			SELECT word_id, COUNT(*) as count
+			FROM word_review_items
+			WHERE correct = false
+			GROUP BY word_id
+		) wrong_reviews ON w.id = wrong_reviews.word_id` + groupWordOrderJoin + `
+		WHERE w.deleted_at IS NULL
+		  AND (s.word_id IS NULL OR s.due_at <= ?)
+		  AND (? = 0 OR w.id IN (` + members + `))
+		  AND (? = '' OR w.language = ?)
+		ORDER BY s.due_at IS NULL, s.due_at ASC, ` + groupWordPosition + `, w.id ASC
+		LIMIT ?`
+
+	args := append([]any{groupID, now.UTC(), groupID}, membersArgs...)
+	rows, err := r.db.QueryContext(ctx, query, append(args, language, language, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing due words: %v", err)
+	}
+	defer rows.Close()
+
+	words := []*models.DueWord{}
+	for rows.Next() {
+		var word models.DueWord
+		var partsJSON []byte
+		var ruby sql.NullString
+		var correctCount, wrongCount int
+		var scheduleWordID sql.NullInt64
+		var algorithm sql.NullString
+		var repetitions, lapses, intervalDays, box sql.NullInt64
+		var easeFactor, stability, difficulty sql.NullFloat64
+		var dueAt, lastReviewedAt sql.NullTime
+
+		err := rows.Scan(
+			&word.ID,
+			&word.Language,
+			&word.Kanji,
+			&word.Reading,
+			&ruby,
+			&word.Romaji,
+			&word.English,
+			&partsJSON,
+			&correctCount,
+			&wrongCount,
+			&scheduleWordID,
+			&algorithm,
+			&repetitions,
+			&lapses,
+			&intervalDays,
+			&easeFactor,
+			&box,
+			&stability,
+			&difficulty,
+			&dueAt,
+			&lastReviewedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning due word: %v", err)
+		}
+
+		if err := json.Unmarshal(partsJSON, &word.Parts); err != nil {
+			return nil, fmt.Errorf("error unmarshaling parts: %v", err)
+		}
+		if word.Ruby, err = parseRuby(ruby); err != nil {
+			return nil, err
+		}
+
+		word.Stats = models.WordStats{
+			CorrectCount: correctCount,
+			WrongCount:   wrongCount,
+		}
+
+		totalAttempts := correctCount + wrongCount
+		if totalAttempts > 0 {
+			word.Stats.Accuracy = float64(correctCount) / float64(totalAttempts) * 100
+		}
+
+		if scheduleWordID.Valid {
+			word.Schedule = &models.WordSchedule{
+				WordID:         scheduleWordID.Int64,
+				Algorithm:      algorithm.String,
+				Repetitions:    int(repetitions.Int64),
+				Lapses:         int(lapses.Int64),
+				IntervalDays:   int(intervalDays.Int64),
+				EaseFactor:     easeFactor.Float64,
+				Box:            int(box.Int64),
+				Stability:      stability.Float64,
+				Difficulty:     difficulty.Float64,
+				DueAt:          dueAt.Time,
+				LastReviewedAt: lastReviewedAt.Time,
+			}
+		}
+
+		words = append(words, &word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due words: %v", err)
+	}
+
+	return words, nil
+}
+
+// ListHistory returns every recorded review of the words in a group in the
+// order they happened, for replaying through a scheduler. A groupID of 0
+// returns the history of all words. Conjugation drill reviews are left out,
+// as they do not schedule the word itself.
+func (r *ScheduleRepository) ListHistory(ctx context.Context, groupID int64) ([]*models.WordReviewItem, error) {
+	members, membersArgs, err := r.groupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, word_id, study_session_id, correct, created_at
+		FROM word_review_items
+		WHERE form IS NULL
+		  AND (? = 0 OR word_id IN (` + members + `))
+		ORDER BY created_at ASC, id ASC`
+
+	return r.queryHistory(ctx, query, append([]any{groupID}, membersArgs...)...)
+}
+
+// groupMembers selects the words of a group, or none for a groupID of 0,
+// which draws from all words instead
+func (r *ScheduleRepository) groupMembers(ctx context.Context, groupID int64) (string, []any, error) {
+	if groupID == 0 {
+		return `SELECT NULL`, nil, nil
+	}
+	return groupMembers(ctx, r.db, groupID, nil)
+}
+
+// ListWordHistory returns every recorded review of a single word in the
+// order they happened, leaving out conjugation drill reviews
+func (r *ScheduleRepository) ListWordHistory(ctx context.Context, wordID int64) ([]*models.WordReviewItem, error) {
+	query := `
+		SELECT id, word_id, study_session_id, correct, created_at
+		FROM word_review_items
+		WHERE word_id = ? AND form IS NULL
+		ORDER BY created_at ASC, id ASC`
+
+	return r.queryHistory(ctx, query, wordID)
+}
+
+// ListLastReviewGroups maps every reviewed word to the group of the study
+// session it was last reviewed in, leaving out conjugation drill reviews
+func (r *ScheduleRepository) ListLastReviewGroups(ctx context.Context) (map[int64]int64, error) {
+	query := `
+		SELECT wri.word_id, ss.group_id
+		FROM word_review_items wri
+		JOIN study_sessions ss ON ss.id = wri.study_session_id
+		WHERE wri.id = (
+			SELECT last.id
+			FROM word_review_items last
+			WHERE last.word_id = wri.word_id AND last.form IS NULL
+			ORDER BY last.created_at DESC, last.id DESC
+			LIMIT 1
+		)`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing reviewed groups: %v", err)
+	}
+	defer rows.Close()
+
+	groups := make(map[int64]int64)
+	for rows.Next() {
+		var wordID, groupID int64
+		if err := rows.Scan(&wordID, &groupID); err != nil {
+			return nil, fmt.Errorf("error scanning reviewed group: %v", err)
+		}
+		groups[wordID] = groupID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewed groups: %v", err)
+	}
+	return groups, nil
+}
+
+func (r *ScheduleRepository) queryHistory(ctx context.Context, query string, args ...interface{}) ([]*models.WordReviewItem, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing review history: %v", err)
+	}
+	defer rows.Close()
+
+	var reviews []*models.WordReviewItem
+	for rows.Next() {
+		review := &models.WordReviewItem{}
+		err := rows.Scan(
+			&review.ID,
+			&review.WordID,
+			&review.StudySessionID,
+			&review.Correct,
+			&review.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning review: %v", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review history: %v", err)
+	}
+
+	return reviews, nil
+}
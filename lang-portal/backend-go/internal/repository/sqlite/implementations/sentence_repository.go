@@ -0,0 +1,264 @@
+package implementations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+)
+
+type SentenceRepository struct {
+	db *sqlite.Database
+}
+
+func NewSentenceRepository(db *sqlite.Database) *SentenceRepository {
+	return &SentenceRepository{db: db}
+}
+
+// Create stores a sentence and links it to its words
+func (r *SentenceRepository) Create(ctx context.Context, sentence *models.Sentence) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query := `
+			INSERT INTO sentences (japanese, reading, english, source)
+			VALUES (?, ?, ?, ?)
+			RETURNING id, created_at`
+
+		err := r.db.QueryRowContext(ctx, query,
+			sentence.Japanese,
+			sentence.Reading,
+			sentence.English,
+			sentence.Source,
+		).Scan(&sentence.ID, &sentence.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("error creating sentence: %v", err)
+		}
+
+		return r.linkWords(ctx, sentence)
+	})
+}
+
+func (r *SentenceRepository) GetByID(ctx context.Context, id int64) (*models.Sentence, error) {
+	query := `
+		SELECT id, japanese, reading, english, source, created_at
+		FROM sentences
+		WHERE id = ?`
+
+	sentence := &models.Sentence{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&sentence.ID,
+		&sentence.Japanese,
+		&sentence.Reading,
+		&sentence.English,
+		&sentence.Source,
+		&sentence.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting sentence: %v", err)
+	}
+
+	if err := r.loadWordIDs(ctx, []*models.Sentence{sentence}); err != nil {
+		return nil, err
+	}
+	return sentence, nil
+}
+
+// List returns a page of sentences, newest first. A non-empty query keeps
+// the sentences whose Japanese or English contains it.
+func (r *SentenceRepository) List(ctx context.Context, query string, page, pageSize int) ([]*models.Sentence, int, error) {
+	where := "1 = 1"
+	var args []any
+	if query = strings.TrimSpace(query); query != "" {
+		where = "(instr(s.japanese, ?) > 0 OR instr(lower(s.english), lower(?)) > 0)"
+		args = append(args, query, query)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM sentences s WHERE ` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting sentences: %v", err)
+	}
+
+	listQuery := `
+		SELECT s.id, s.japanese, s.reading, s.english, s.source, s.created_at
+		FROM sentences s
+		WHERE ` + where + `
+		ORDER BY s.id DESC
+		LIMIT ? OFFSET ?`
+
+	sentences, err := r.query(ctx, listQuery, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sentences, total, nil
+}
+
+// ListByWord returns a page of the sentences using a word, shortest first as
+// those make the simplest examples
+func (r *SentenceRepository) ListByWord(ctx context.Context, wordID int64, page, pageSize int) ([]*models.Sentence, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM word_sentences WHERE word_id = ?`
+	if err := r.db.QueryRowContext(ctx, countQuery, wordID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting word sentences: %v", err)
+	}
+
+	query := `
+		SELECT s.id, s.japanese, s.reading, s.english, s.source, s.created_at
+		FROM sentences s
+		JOIN word_sentences ws ON ws.sentence_id = s.id
+		WHERE ws.word_id = ?
+		ORDER BY length(s.japanese), s.id
+		LIMIT ? OFFSET ?`
+
+	sentences, err := r.query(ctx, query, wordID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sentences, total, nil
+}
+
+// Update replaces a sentence and its word links
+func (r *SentenceRepository) Update(ctx context.Context, sentence *models.Sentence) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query := `
+			UPDATE sentences
+			SET japanese = ?, reading = ?, english = ?, source = ?
+			WHERE id = ?`
+
+		result, err := r.db.ExecContext(ctx, query,
+			sentence.Japanese,
+			sentence.Reading,
+			sentence.English,
+			sentence.Source,
+			sentence.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("error updating sentence: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("sentence not found")
+		}
+
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM word_sentences WHERE sentence_id = ?`, sentence.ID); err != nil {
+			return fmt.Errorf("error unlinking sentence words: %v", err)
+		}
+		return r.linkWords(ctx, sentence)
+	})
+}
+
+func (r *SentenceRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM word_sentences WHERE sentence_id = ?`, id); err != nil {
+			return fmt.Errorf("error unlinking sentence words: %v", err)
+		}
+
+		result, err := r.db.ExecContext(ctx, `DELETE FROM sentences WHERE id = ?`, id)
+		if err != nil {
+			return fmt.Errorf("error deleting sentence: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("sentence not found")
+		}
+		return nil
+	})
+}
+
+func (r *SentenceRepository) linkWords(ctx context.Context, sentence *models.Sentence) error {
+	query := `INSERT OR IGNORE INTO word_sentences (word_id, sentence_id) VALUES (?, ?)`
+	for _, wordID := range sentence.WordIDs {
+		if _, err := r.db.ExecContext(ctx, query, wordID, sentence.ID); err != nil {
+			return fmt.Errorf("error linking word %d to sentence: %v", wordID, err)
+		}
+	}
+	return nil
+}
+
+func (r *SentenceRepository) query(ctx context.Context, query string, args ...any) ([]*models.Sentence, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sentences: %v", err)
+	}
+	defer rows.Close()
+
+	sentences := []*models.Sentence{}
+	for rows.Next() {
+		sentence := &models.Sentence{}
+		err := rows.Scan(
+			&sentence.ID,
+			&sentence.Japanese,
+			&sentence.Reading,
+			&sentence.English,
+			&sentence.Source,
+			&sentence.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning sentence: %v", err)
+		}
+		sentences = append(sentences, sentence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sentences: %v", err)
+	}
+	// The rows are closed before loading the links, as the database allows
+	// one connection
+	rows.Close()
+
+	if err := r.loadWordIDs(ctx, sentences); err != nil {
+		return nil, err
+	}
+	return sentences, nil
+}
+
+// loadWordIDs fills in the linked words of each sentence
+func (r *SentenceRepository) loadWordIDs(ctx context.Context, sentences []*models.Sentence) error {
+	if len(sentences) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*models.Sentence, len(sentences))
+	args := make([]any, len(sentences))
+	for i, sentence := range sentences {
+		sentence.WordIDs = []int64{}
+		byID[sentence.ID] = sentence
+		args[i] = sentence.ID
+	}
+
+	query := `
+		SELECT sentence_id, word_id
+		FROM word_sentences
+		WHERE sentence_id IN (?` + strings.Repeat(", ?", len(sentences)-1) + `)
+		ORDER BY sentence_id, word_id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error listing sentence words: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sentenceID, wordID int64
+		if err := rows.Scan(&sentenceID, &wordID); err != nil {
+			return fmt.Errorf("error scanning sentence word: %v", err)
+		}
+		byID[sentenceID].WordIDs = append(byID[sentenceID].WordIDs, wordID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating sentence words: %v", err)
+	}
+	return nil
+}
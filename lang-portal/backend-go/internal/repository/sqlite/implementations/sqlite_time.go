@@ -0,0 +1,34 @@
+package implementations
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+// nullableTime converts a timestamp produced by an aggregate such as
+// MAX(created_at) into a *time.Time. SQLite drops the column's declared type
+// for aggregates, so the driver hands those values back as plain strings.
+func nullableTime(value sql.NullString) (*time.Time, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	for _, layout := range sqlite3.SQLiteTimestampFormats {
+		if t, err := time.ParseInLocation(layout, value.String, time.UTC); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid timestamp %q", value.String)
+}
+
+// timestampOrNull formats t the way CURRENT_TIMESTAMP does, so explicit and
+// defaulted created_at values compare and sort together. The zero time maps
+// to NULL, letting COALESCE(?, CURRENT_TIMESTAMP) fall back to now.
+func timestampOrNull(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
@@ -23,20 +23,22 @@ func (r *StudyActivityRepository) Create(ctx context.Context, activity *models.S
 		VALUES (?, ?)
 		RETURNING id`
 
-	err := r.db.QueryRowContext(ctx, query,
-		activity.Name,
-		activity.URL,
-	).Scan(&activity.ID)
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		err := r.db.QueryRowContext(ctx, query,
+			activity.Name,
+			activity.URL,
+		).Scan(&activity.ID)
 
-	if err != nil {
-		return fmt.Errorf("error creating study activity: %v", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error creating study activity: %v", err)
+		}
 
-	return nil
+		return r.recordRevision(ctx, activity.ID, models.RevisionCreate, nil)
+	})
 }
 
 func (r *StudyActivityRepository) GetByID(ctx context.Context, id int64) (*models.StudyActivity, error) {
-	query := `SELECT id, name, url FROM study_activities WHERE id = ?`
+	query := `SELECT id, name, url FROM study_activities WHERE id = ? AND deleted_at IS NULL`
 
 	activity := &models.StudyActivity{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
@@ -55,6 +57,27 @@ func (r *StudyActivityRepository) GetByID(ctx context.Context, id int64) (*model
 	return activity, nil
 }
 
+// GetByName looks a study activity up by its name
+func (r *StudyActivityRepository) GetByName(ctx context.Context, name string) (*models.StudyActivity, error) {
+	query := `SELECT id, name, url FROM study_activities WHERE name = ? AND deleted_at IS NULL ORDER BY id LIMIT 1`
+
+	activity := &models.StudyActivity{}
+	err := r.db.QueryRowContext(ctx, query, name).Scan(
+		&activity.ID,
+		&activity.Name,
+		&activity.URL,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting study activity: %v", err)
+	}
+
+	return activity, nil
+}
+
 func (r *StudyActivityRepository) List(ctx context.Context) ([]*models.StudyActivity, error) {
 	query := `
 		SELECT 
@@ -62,6 +85,7 @@ func (r *StudyActivityRepository) List(ctx context.Context) ([]*models.StudyActi
 			MAX(ss.created_at) as last_used
 		FROM study_activities sa
 		LEFT JOIN study_sessions ss ON sa.id = ss.study_activity_id
+		WHERE sa.deleted_at IS NULL
 		GROUP BY sa.id
 		ORDER BY sa.name ASC`
 
@@ -100,42 +124,95 @@ func (r *StudyActivityRepository) Update(ctx context.Context, activity *models.S
 	query := `
 		UPDATE study_activities 
 		SET name = ?, url = ?
-		WHERE id = ?`
+		WHERE id = ? AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query,
-		activity.Name,
-		activity.URL,
-		activity.ID,
-	)
-	if err != nil {
-		return fmt.Errorf("error updating study activity: %v", err)
-	}
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.GetByID(ctx, activity.ID)
+		if err != nil {
+			return err
+		}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("error getting affected rows: %v", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("study activity not found")
-	}
+		result, err := r.db.ExecContext(ctx, query,
+			activity.Name,
+			activity.URL,
+			activity.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("error updating study activity: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("study activity not found")
+		}
 
-	return nil
+		return r.recordRevision(ctx, activity.ID, models.RevisionUpdate, before)
+	})
 }
 
+// Delete moves a study activity to the trash. Its study sessions are kept
+// until it is purged.
 func (r *StudyActivityRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM study_activities WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, id)
-	if err != nil {
-		return fmt.Errorf("error deleting study activity: %v", err)
-	}
+	query := `UPDATE study_activities SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		result, err := r.db.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("error deleting study activity: %v", err)
+		}
 
-	rows, err := result.RowsAffected()
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("study activity not found")
+		}
+
+		return recordRevision(ctx, r.db, models.RevisionStudyActivity, id, models.RevisionDelete, before, nil)
+	})
+}
+
+// Restore takes a study activity out of the trash and reports whether it was
+// there
+func (r *StudyActivityRepository) Restore(ctx context.Context, id int64) (bool, error) {
+	query := `UPDATE study_activities SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+
+	restored := false
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		result, err := r.db.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("error restoring study activity: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+		restored = true
+
+		return r.recordRevision(ctx, id, models.RevisionRestore, nil)
+	})
+	return restored, err
+}
+
+// recordRevision records a change to a study activity, snapshotting the
+// activity as it now is
+func (r *StudyActivityRepository) recordRevision(ctx context.Context, id int64, action string, before *models.StudyActivity) error {
+	after, err := r.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("error getting affected rows: %v", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("study activity not found")
+		return err
 	}
-
-	return nil
+	return recordRevision(ctx, r.db, models.RevisionStudyActivity, id, action, before, after)
 } 
\ No newline at end of file
@@ -5,9 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"time"
 
 	"backend-go/internal/domain/models"
 	"backend-go/internal/repository/sqlite"
@@ -24,12 +22,13 @@ func NewStudySessionRepository(db *sqlite.Database) *StudySessionRepository {
 func (r *StudySessionRepository) Create(ctx context.Context, session *models.StudySession) error {
 	query := `
 		INSERT INTO study_sessions (group_id, study_activity_id, created_at)
-		VALUES (?, ?, CURRENT_TIMESTAMP)
+		VALUES (?, ?, COALESCE(?, CURRENT_TIMESTAMP))
 		RETURNING id, created_at`
 
 	err := r.db.QueryRowContext(ctx, query,
 		session.GroupID,
 		session.StudyActivityID,
+		timestampOrNull(session.CreatedAt),
 	).Scan(&session.ID, &session.CreatedAt)
 
 	if err != nil {
@@ -63,6 +62,33 @@ func (r *StudySessionRepository) GetByID(ctx context.Context, id int64) (*models
 	return session, nil
 }
 
+// FindSession looks up the session of a group and activity started at createdAt
+func (r *StudySessionRepository) FindSession(ctx context.Context, groupID, activityID int64, createdAt time.Time) (*models.StudySession, error) {
+	query := `
+		SELECT id, group_id, study_activity_id, created_at
+		FROM study_sessions
+		WHERE group_id = ? AND study_activity_id = ? AND created_at = ?
+		ORDER BY id
+		LIMIT 1`
+
+	session := &models.StudySession{}
+	err := r.db.QueryRowContext(ctx, query, groupID, activityID, timestampOrNull(createdAt)).Scan(
+		&session.ID,
+		&session.GroupID,
+		&session.StudyActivityID,
+		&session.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding study session: %v", err)
+	}
+
+	return session, nil
+}
+
 func (r *StudySessionRepository) ListByGroup(ctx context.Context, groupID int64, page, pageSize int) ([]*models.StudySession, int, error) {
 	// Calculate offset
 	offset := (page - 1) * pageSize
@@ -117,14 +143,16 @@ func (r *StudySessionRepository) ListByGroup(ctx context.Context, groupID int64,
 
 func (r *StudySessionRepository) AddReview(ctx context.Context, review *models.WordReviewItem) error {
 	query := `
-		INSERT INTO word_review_items (word_id, study_session_id, correct, created_at)
-		VALUES (?, ?, ?, CURRENT_TIMESTAMP)
+		INSERT INTO word_review_items (word_id, study_session_id, correct, form, created_at)
+		VALUES (?, ?, ?, NULLIF(?, ''), COALESCE(?, CURRENT_TIMESTAMP))
 		RETURNING id, created_at`
 
 	err := r.db.QueryRowContext(ctx, query,
 		review.WordID,
 		review.StudySessionID,
 		review.Correct,
+		review.Form,
+		timestampOrNull(review.CreatedAt),
 	).Scan(&review.ID, &review.CreatedAt)
 
 	if err != nil {
@@ -176,7 +204,7 @@ func (r *StudySessionRepository) GetSessionStats(ctx context.Context, sessionID
 
 func (r *StudySessionRepository) ListReviews(ctx context.Context, sessionID int64) ([]*models.WordReviewItem, error) {
 	query := `
-		SELECT id, word_id, study_session_id, correct, created_at
+		SELECT id, word_id, study_session_id, correct, COALESCE(form, ''), created_at
 		FROM word_review_items
 		WHERE study_session_id = ?
 		ORDER BY created_at ASC`
@@ -195,6 +223,7 @@ func (r *StudySessionRepository) ListReviews(ctx context.Context, sessionID int6
 			&review.WordID,
 			&review.StudySessionID,
 			&review.Correct,
+			&review.Form,
 			&review.CreatedAt,
 		)
 		if err != nil {
@@ -210,6 +239,48 @@ func (r *StudySessionRepository) ListReviews(ctx context.Context, sessionID int6
 	return reviews, nil
 }
 
+// ListFormStats counts the conjugation drill reviews per word and form. A
+// groupID limits them to the study sessions of that group and a wordID to
+// one word; 0 means any.
+func (r *StudySessionRepository) ListFormStats(ctx context.Context, groupID, wordID int64) ([]*models.FormStats, error) {
+	query := `
+		SELECT 
+			r.word_id, r.form,
+			SUM(CASE WHEN r.correct THEN 1 ELSE 0 END) as correct_count,
+			SUM(CASE WHEN r.correct THEN 0 ELSE 1 END) as wrong_count
+		FROM word_review_items r
+		JOIN study_sessions s ON s.id = r.study_session_id
+		WHERE r.form IS NOT NULL
+		  AND (? = 0 OR s.group_id = ?)
+		  AND (? = 0 OR r.word_id = ?)
+		GROUP BY r.word_id, r.form
+		ORDER BY r.word_id, r.form`
+
+	rows, err := r.db.QueryContext(ctx, query, groupID, groupID, wordID, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing form stats: %v", err)
+	}
+	defer rows.Close()
+
+	var stats []*models.FormStats
+	for rows.Next() {
+		stat := &models.FormStats{}
+		if err := rows.Scan(&stat.WordID, &stat.Form, &stat.CorrectCount, &stat.WrongCount); err != nil {
+			return nil, fmt.Errorf("error scanning form stats: %v", err)
+		}
+		if total := stat.CorrectCount + stat.WrongCount; total > 0 {
+			stat.Accuracy = float64(stat.CorrectCount) / float64(total) * 100
+		}
+		stats = append(stats, stat)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating form stats: %v", err)
+	}
+
+	return stats, nil
+}
+
 // FullReset deletes all study session related data
 func (r *StudySessionRepository) FullReset(ctx context.Context) error {
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -302,10 +373,10 @@ func (r *StudySessionRepository) GetQuickStats(ctx context.Context) (*models.Qui
 // GetSessionWords retrieves words associated with a specific study session
 func (r *StudySessionRepository) GetSessionWords(ctx context.Context, sessionID int64) ([]*models.WordWithStats, error) {
 	query := `
-		SELECT w.id, w.kanji, w.romaji, w.english, w.parts,
+		SELECT w.id, w.language, w.term, w.reading, w.ruby, w.transliteration, w.gloss, w.parts,
 			   COUNT(wr.id) as review_count, 
 			   SUM(CASE WHEN wr.correct THEN 1 ELSE 0 END) as correct_count
-		FROM words w
+		FROM terms w
 		JOIN session_words sw ON w.id = sw.word_id
 		WHERE sw.session_id = ?
 		GROUP BY w.id`
@@ -320,8 +391,9 @@ func (r *StudySessionRepository) GetSessionWords(ctx context.Context, sessionID
 	for rows.Next() {
 		var w models.WordWithStats
 		var partsJSON []byte
+		var ruby sql.NullString
 		err := rows.Scan(
-			&w.ID, &w.Kanji, &w.Romaji, &w.English, &partsJSON,
+			&w.ID, &w.Language, &w.Kanji, &w.Reading, &ruby, &w.Romaji, &w.English, &partsJSON,
 			&w.Stats.WrongCount, &w.Stats.CorrectCount,
 		)
 		if err != nil {
@@ -330,6 +402,9 @@ func (r *StudySessionRepository) GetSessionWords(ctx context.Context, sessionID
 		if err := json.Unmarshal(partsJSON, &w.Parts); err != nil {
 			return nil, err
 		}
+		if w.Ruby, err = parseRuby(ruby); err != nil {
+			return nil, err
+		}
 		words = append(words, &w)
 	}
 
@@ -400,41 +475,3 @@ func (r *StudySessionRepository) ListByActivity(ctx context.Context, activityID
 
 	return sessions, nil
 }
-
-// LoadSeedData reads and executes SQL files from the seeds directory
-func (r *StudySessionRepository) LoadSeedData(ctx context.Context, seedsDir string) error {
-	tx, err := r.db.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	// List of seed files in order of execution
-	seedFiles := []string{
-		"study_sessions.sql", // Add your specific seed file for study sessions
-	}
-
-	for _, filename := range seedFiles {
-		filepath := filepath.Join(seedsDir, filename)
-		content, err := os.ReadFile(filepath)
-		if err != nil {
-			return fmt.Errorf("failed to read seed file %s: %v", filename, err)
-		}
-
-		// Split content into individual SQL statements
-		statements := strings.Split(string(content), ";")
-		
-		for _, stmt := range statements {
-			stmt = strings.TrimSpace(stmt)
-			if stmt == "" {
-				continue
-			}
-
-			if _, err := tx.ExecContext(ctx, stmt); err != nil {
-				return fmt.Errorf("failed to execute seed query from %s: %v", filename, err)
-			}
-		}
-	}
-
-	return tx.Commit()
-} 
\ No newline at end of file
@@ -0,0 +1,256 @@
+package implementations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+)
+
+type TrashRepository struct {
+	db *sqlite.Database
+}
+
+func NewTrashRepository(db *sqlite.Database) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// trashItems lists everything in the trash as (type, id, name, deleted_at)
+const trashItems = `
+	SELECT 'word' AS type, id, term AS name, deleted_at FROM terms WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'group', id, name, deleted_at FROM groups WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'study_activity', id, name, deleted_at FROM study_activities WHERE deleted_at IS NOT NULL`
+
+// List returns a page of the trash, most recently deleted first. An empty
+// kind lists every kind of item.
+func (r *TrashRepository) List(ctx context.Context, kind string, page, pageSize int) ([]*models.TrashItem, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM (` + trashItems + `) WHERE ? = '' OR type = ?`
+	if err := r.db.QueryRowContext(ctx, countQuery, kind, kind).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting trash: %v", err)
+	}
+
+	query := `
+		SELECT type, id, name, deleted_at
+		FROM (` + trashItems + `)
+		WHERE ? = '' OR type = ?
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, kind, kind, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing trash: %v", err)
+	}
+	defer rows.Close()
+
+	items := []*models.TrashItem{}
+	for rows.Next() {
+		item := &models.TrashItem{}
+		if err := rows.Scan(&item.Type, &item.ID, &item.Name, &item.DeletedAt); err != nil {
+			return nil, 0, fmt.Errorf("error scanning trash item: %v", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating trash: %v", err)
+	}
+
+	return items, total, nil
+}
+
+// Purge permanently removes the items deleted before a time, along with
+// what only exists through them: the group memberships, kanji and sentence
+// links, reviews and schedule of a word, and the memberships, settings and
+// study sessions of a group or study activity. Their revisions are kept.
+// The groups under a purged group move to the top, the groups left behind
+// are renumbered and smart groups stop excluding purged groups. A group a
+// smart group draws its words from stays until that smart group goes.
+func (r *TrashRepository) Purge(ctx context.Context, before time.Time) (*models.PurgeResult, error) {
+	cutoff := timestampOrNull(before)
+	purgedWords := `SELECT id FROM terms WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	purgedGroups := `
+		WITH RECURSIVE kept_groups(id) AS (
+			SELECT id FROM groups WHERE deleted_at IS NULL OR deleted_at >= ?
+			UNION
+			SELECT json_extract(g.filter, '$.group_id')
+			FROM groups g
+			JOIN kept_groups k ON k.id = g.id
+			WHERE json_extract(g.filter, '$.group_id') IS NOT NULL
+		)
+		SELECT id FROM groups WHERE id NOT IN (SELECT id FROM kept_groups)`
+	purgedActivities := `SELECT id FROM study_activities WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	purgedSessions := `
+		SELECT id FROM study_sessions
+		WHERE group_id IN (` + purgedGroups + `) OR study_activity_id IN (` + purgedActivities + `)`
+
+	steps := []struct {
+		query string
+		args  int
+	}{
+		{`DELETE FROM word_groups WHERE word_id IN (` + purgedWords + `)`, 1},
+		{`DELETE FROM word_kanji WHERE word_id IN (` + purgedWords + `)`, 1},
+		{`DELETE FROM word_sentences WHERE word_id IN (` + purgedWords + `)`, 1},
+		{`DELETE FROM word_review_items WHERE word_id IN (` + purgedWords + `)`, 1},
+		{`DELETE FROM word_schedules WHERE word_id IN (` + purgedWords + `)`, 1},
+		{`DELETE FROM word_groups WHERE group_id IN (` + purgedGroups + `)`, 1},
+		{`DELETE FROM group_settings WHERE group_id IN (` + purgedGroups + `)`, 1},
+		{`DELETE FROM word_review_items WHERE study_session_id IN (` + purgedSessions + `)`, 2},
+		{`DELETE FROM study_sessions WHERE id IN (` + purgedSessions + `)`, 2},
+	}
+
+	result := &models.PurgeResult{}
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		groupIDs, err := r.ids(ctx, purgedGroups, cutoff)
+		if err != nil {
+			return err
+		}
+		renumbered, err := r.ids(ctx, `
+			SELECT DISTINCT group_id FROM word_groups
+			WHERE word_id IN (`+purgedWords+`) AND group_id NOT IN (`+purgedGroups+`)
+			ORDER BY group_id`, cutoff, cutoff)
+		if err != nil {
+			return err
+		}
+		orphans, err := r.ids(ctx, `
+			SELECT id FROM groups
+			WHERE parent_id IN (`+purgedGroups+`) AND id NOT IN (`+purgedGroups+`)
+			ORDER BY deleted_at IS NOT NULL, position, id`, cutoff, cutoff)
+		if err != nil {
+			return err
+		}
+
+		for _, step := range steps {
+			args := make([]any, step.args)
+			for i := range args {
+				args[i] = cutoff
+			}
+			if _, err := r.db.ExecContext(ctx, step.query, args...); err != nil {
+				return fmt.Errorf("error purging trash: %v", err)
+			}
+		}
+
+		orphan := `
+			UPDATE groups
+			SET parent_id = NULL,
+			    position = (SELECT COALESCE(MAX(position) + 1, 0) FROM groups WHERE parent_id IS NULL AND deleted_at IS NULL)
+			WHERE id = ?`
+		for _, id := range orphans {
+			if _, err := r.db.ExecContext(ctx, orphan, id); err != nil {
+				return fmt.Errorf("error moving child group: %v", err)
+			}
+		}
+		groupRepo := NewGroupRepository(r.db)
+		if err := groupRepo.renumberGroups(ctx, nil); err != nil {
+			return err
+		}
+		if err := r.stripExcludedGroups(ctx, groupIDs); err != nil {
+			return err
+		}
+		for _, id := range renumbered {
+			if err := groupRepo.renumberWords(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		removals := []struct {
+			query string
+			count *int
+		}{
+			{`DELETE FROM terms WHERE deleted_at IS NOT NULL AND deleted_at < ?`, &result.Words},
+			{`DELETE FROM groups WHERE id IN (` + purgedGroups + `)`, &result.Groups},
+			{`DELETE FROM study_activities WHERE deleted_at IS NOT NULL AND deleted_at < ?`, &result.StudyActivities},
+		}
+		for _, removal := range removals {
+			res, err := r.db.ExecContext(ctx, removal.query, cutoff)
+			if err != nil {
+				return fmt.Errorf("error purging trash: %v", err)
+			}
+			rows, err := res.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("error getting affected rows: %v", err)
+			}
+			*removal.count = int(rows)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ids runs a query selecting one id per row
+func (r *TrashRepository) ids(ctx context.Context, query string, args ...any) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error listing trash: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning trash item: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trash: %v", err)
+	}
+	return ids, nil
+}
+
+// stripExcludedGroups takes the purged groups out of the groups the smart
+// groups that remain exclude
+func (r *TrashRepository) stripExcludedGroups(ctx context.Context, purged []int64) error {
+	if len(purged) == 0 {
+		return nil
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT id, filter FROM groups WHERE filter IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("error listing smart groups: %v", err)
+	}
+	defer rows.Close()
+
+	filters := map[int64]*models.WordFilter{}
+	for rows.Next() {
+		var id int64
+		var filterJSON sql.NullString
+		if err := rows.Scan(&id, &filterJSON); err != nil {
+			return fmt.Errorf("error scanning smart group: %v", err)
+		}
+		filter, err := parseGroupFilter(filterJSON)
+		if err != nil {
+			return err
+		}
+		excluded := len(filter.ExcludeGroupIDs)
+		filter.ExcludeGroupIDs = slices.DeleteFunc(filter.ExcludeGroupIDs, func(groupID int64) bool {
+			return slices.Contains(purged, groupID)
+		})
+		if len(filter.ExcludeGroupIDs) != excluded && !slices.Contains(purged, id) {
+			filters[id] = filter
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating smart groups: %v", err)
+	}
+	rows.Close()
+
+	for id, filter := range filters {
+		filterJSON, err := groupFilterJSON(filter)
+		if err != nil {
+			return err
+		}
+		if _, err := r.db.ExecContext(ctx, `UPDATE groups SET filter = ? WHERE id = ?`, filterJSON, id); err != nil {
+			return fmt.Errorf("error updating smart group: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,398 @@
+package implementations
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+)
+
+// wordsWithStats selects words with their review counts; the aliases of the
+// count subqueries are used by wordSortFields and wordFilterColumns
+const wordsWithStats = `
+		FROM terms w
+		LEFT JOIN (
+			SELECT word_id, COUNT(*) as count
+			FROM word_review_items
+			WHERE correct = true
+			GROUP BY word_id
+		) correct_reviews ON w.id = correct_reviews.word_id
+		LEFT JOIN (
+			SELECT word_id, COUNT(*) as count
+			FROM word_review_items
+			WHERE correct = false
+			GROUP BY word_id
+		) wrong_reviews ON w.id = wrong_reviews.word_id`
+
+const wordAccuracy = `CASE WHEN COALESCE(correct_reviews.count, 0) + COALESCE(wrong_reviews.count, 0) > 0
+			THEN COALESCE(correct_reviews.count, 0) * 100.0 / (COALESCE(correct_reviews.count, 0) + COALESCE(wrong_reviews.count, 0))
+			ELSE 0 END`
+
+// wordDaysSince counts the days since a word's last review matching a
+// condition on word_review_items; words never reviewed so are NULL
+func wordDaysSince(condition string) string {
+	return `julianday('now') - julianday((
+			SELECT MAX(created_at) FROM word_review_items
+			WHERE word_id = w.id AND ` + condition + `))`
+}
+
+// wordSortFields and wordFilterColumns accept both the language-agnostic
+// column names and the Japanese ones the API started with. Terms sort by the
+// key their language pack gives them.
+var wordSortFields = map[string]string{
+	"id":              "w.id",
+	"language":        "w.language",
+	"term":            "w.sort_key",
+	"kanji":           "w.sort_key",
+	"reading":         "w.reading",
+	"transliteration": "w.transliteration",
+	"romaji":          "w.transliteration",
+	"gloss":           "w.gloss",
+	"english":         "w.gloss",
+	"correct_count":   "COALESCE(correct_reviews.count, 0)",
+	"wrong_count":     "COALESCE(wrong_reviews.count, 0)",
+}
+
+// groupWordOrder ranks the words of the group given as its argument, as
+// (word_id, path): the words added to it by their position, then the words
+// of each group under it in turn, to any depth. Paths are text that sorts
+// in that order. Words only a smart group's filter matches have no path.
+const groupWordOrder = `
+		WITH RECURSIVE group_order(id, path) AS (
+			SELECT ?, ''
+			UNION ALL
+			SELECT g.id, o.path || printf('%08d.', g.position)
+			FROM group_order o
+			JOIN groups g ON g.parent_id = o.id
+			WHERE g.deleted_at IS NULL
+		)
+		SELECT wg.word_id, MIN(o.path || printf(' %08d', wg.position)) AS path
+		FROM group_order o
+		JOIN word_groups wg ON wg.group_id = o.id
+		GROUP BY wg.word_id`
+
+// groupWordOrderJoin joins groupWordOrder as word_order to words w
+const groupWordOrderJoin = `
+		LEFT JOIN (` + groupWordOrder + `) word_order ON word_order.word_id = w.id`
+
+// groupWordPosition sorts words joined by groupWordOrderJoin in their order
+// in the group, those without a path last
+const groupWordPosition = `COALESCE(word_order.path, '~')`
+
+// wordFilterColumns are the fields a word filter may compare besides parts
+var wordFilterColumns = map[string]string{
+	"language":          "w.language",
+	"term":              "w.term",
+	"kanji":             "w.term",
+	"reading":           "w.reading",
+	"transliteration":   "w.transliteration",
+	"romaji":            "w.transliteration",
+	"gloss":             "w.gloss",
+	"english":           "w.gloss",
+	"correct_count":     "COALESCE(correct_reviews.count, 0)",
+	"wrong_count":       "COALESCE(wrong_reviews.count, 0)",
+	"accuracy":          wordAccuracy,
+	"days_since_review": wordDaysSince("true"),
+	"days_since_wrong":  wordDaysSince("correct = false"),
+}
+
+// partsColumns are the indexed generated columns extracting the most common
+// parts keys (migration 000007); other keys are read with json_extract
+var partsColumns = map[string]string{
+	"topic":          "w.parts_topic",
+	"verb_type":      "w.parts_verb_type",
+	"adjective_type": "w.parts_adjective_type",
+}
+
+// wordFilterSQL turns a word filter into a parameterized WHERE condition,
+// which leaves out the words in the trash. Field names only ever come from
+// the allow-lists above; every value is passed as an argument. The groups
+// the filter names are looked up to tell smart groups apart; visiting holds
+// the groups whose words are being selected already, as for the filter of a
+// smart group, which may not name them again.
+func wordFilterSQL(ctx context.Context, db *sqlite.Database, filter models.WordFilter, visiting map[int64]bool) (string, []any, error) {
+	clauses := []string{"w.deleted_at IS NULL"}
+	var args []any
+
+	if filter.GroupID != 0 {
+		members, membersArgs, err := groupMembers(ctx, db, filter.GroupID, visiting)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, "w.id IN ("+members+")")
+		args = append(args, membersArgs...)
+	}
+	for _, groupID := range filter.ExcludeGroupIDs {
+		members, membersArgs, err := groupMembers(ctx, db, groupID, visiting)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, "w.id NOT IN ("+members+")")
+		args = append(args, membersArgs...)
+	}
+	if filter.Character != "" {
+		clauses = append(clauses, "w.id IN (SELECT word_id FROM word_kanji WHERE character = ?)")
+		args = append(args, filter.Character)
+	}
+
+	for _, condition := range filter.Conditions {
+		if len(condition.Values) == 0 {
+			return "", nil, fmt.Errorf("filter on %s has no value", condition.Field)
+		}
+
+		var column string
+		var columnArgs []any
+		if key, ok := strings.CutPrefix(condition.Field, "parts."); ok {
+			if strings.ContainsAny(key, `"\$.[]`) || key == "" {
+				return "", nil, fmt.Errorf("invalid parts key %q", key)
+			}
+			path := `$."` + key + `"`
+			if condition.Op == models.FilterExists {
+				exists, _ := condition.Values[0].(bool)
+				if exists {
+					clauses = append(clauses, "json_type(w.parts, ?) IS NOT NULL")
+				} else {
+					clauses = append(clauses, "json_type(w.parts, ?) IS NULL")
+				}
+				args = append(args, path)
+				continue
+			}
+			if column, ok = partsColumns[key]; !ok {
+				column = "json_extract(w.parts, ?)"
+				columnArgs = []any{path}
+			}
+		} else if column, ok = wordFilterColumns[condition.Field]; !ok {
+			return "", nil, fmt.Errorf("unknown filter field %q", condition.Field)
+		}
+
+		var comparison string
+		switch condition.Op {
+		case models.FilterEq:
+			comparison = "= ?"
+		case models.FilterNe:
+			// Words without the field are not equal to the value either
+			comparison = "IS NOT ?"
+		case models.FilterLt:
+			comparison = "< ?"
+		case models.FilterLte:
+			comparison = "<= ?"
+		case models.FilterGt:
+			comparison = "> ?"
+		case models.FilterGte:
+			comparison = ">= ?"
+		case models.FilterLike:
+			comparison = "LIKE ?"
+		case models.FilterIn:
+			comparison = "IN (?" + strings.Repeat(", ?", len(condition.Values)-1) + ")"
+		default:
+			return "", nil, fmt.Errorf("unsupported filter operator %q", condition.Op)
+		}
+		if condition.Op != models.FilterIn && len(condition.Values) != 1 {
+			return "", nil, fmt.Errorf("filter %s %s takes one value", condition.Field, condition.Op)
+		}
+
+		clauses = append(clauses, column+" "+comparison)
+		args = append(args, columnArgs...)
+		args = append(args, condition.Values...)
+	}
+
+	return strings.Join(clauses, " AND "), args, nil
+}
+
+// groupMembers returns a query selecting the ids of the words of a group:
+// the words added to it or, for a smart group, the words of its language its
+// filter matches at the time of the query, along with the words of every
+// group under it. visiting holds the groups whose words are being selected
+// already; a group found among them again is an error.
+func groupMembers(ctx context.Context, db *sqlite.Database, groupID int64, visiting map[int64]bool) (string, []any, error) {
+	if visiting[groupID] {
+		return "", nil, fmt.Errorf("group %d is part of its own words", groupID)
+	}
+	visiting = maps.Clone(visiting)
+	if visiting == nil {
+		visiting = make(map[int64]bool)
+	}
+	visiting[groupID] = true
+
+	var language string
+	var filterJSON sql.NullString
+	query := `SELECT language, filter FROM groups WHERE id = ?`
+	err := db.QueryRowContext(ctx, query, groupID).Scan(&language, &filterJSON)
+	if err != nil && err != sql.ErrNoRows {
+		return "", nil, fmt.Errorf("error getting group: %v", err)
+	}
+
+	members := `SELECT word_id FROM word_groups WHERE group_id = ?`
+	args := []any{groupID}
+	if filterJSON.Valid {
+		filter, err := parseGroupFilter(filterJSON)
+		if err != nil {
+			return "", nil, err
+		}
+		where, filterArgs, err := wordFilterSQL(ctx, db, *filter, visiting)
+		if err != nil {
+			return "", nil, fmt.Errorf("error in the filter of group %d: %v", groupID, err)
+		}
+		members = `SELECT w.id ` + wordsWithStats + ` WHERE ` + where + ` AND w.language = ?`
+		args = append(filterArgs, language)
+	}
+
+	children, err := childGroupIDs(ctx, db, groupID)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, childID := range children {
+		childMembers, childArgs, err := groupMembers(ctx, db, childID, visiting)
+		if err != nil {
+			return "", nil, err
+		}
+		members += ` UNION ` + childMembers
+		args = append(args, childArgs...)
+	}
+	return members, args, nil
+}
+
+// childGroupIDs returns the ids of the groups directly under a group that
+// are not in the trash
+func childGroupIDs(ctx context.Context, db *sqlite.Database, parentID int64) ([]int64, error) {
+	query := `SELECT id FROM groups WHERE parent_id = ? AND deleted_at IS NULL ORDER BY position, id`
+	rows, err := db.QueryContext(ctx, query, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing child groups: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning child group: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating child groups: %v", err)
+	}
+	return ids, nil
+}
+
+// parseGroupFilter reads the saved filter of a group, which is NULL for a
+// group that is not smart
+func parseGroupFilter(filterJSON sql.NullString) (*models.WordFilter, error) {
+	if !filterJSON.Valid {
+		return nil, nil
+	}
+	filter := &models.WordFilter{}
+	if err := json.Unmarshal([]byte(filterJSON.String), filter); err != nil {
+		return nil, fmt.Errorf("error unmarshaling group filter: %v", err)
+	}
+	return filter, nil
+}
+
+// listWords returns a page of the words matching filter with their review
+// stats, and the number of matching words. sortBy must be a key of
+// wordSortFields or, for the words of a group, position, falling back to
+// defaultSort.
+func listWords(ctx context.Context, db *sqlite.Database, filter models.WordFilter, page, pageSize int, sortBy, defaultSort, order string) ([]*models.WordWithStats, int, error) {
+	where, args, err := wordFilterSQL(ctx, db, filter, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if _, ok := wordSortFields[sortBy]; !ok && sortBy != "position" {
+		sortBy = defaultSort
+	}
+	dbSortField := wordSortFields[sortBy]
+	var sortJoin string
+	var sortArgs []any
+	if sortBy == "position" {
+		// Words are only positioned within a group
+		dbSortField = "w.id"
+		if filter.GroupID != 0 {
+			dbSortField = groupWordPosition
+			sortJoin = groupWordOrderJoin
+			sortArgs = []any{filter.GroupID}
+		}
+	}
+	order = strings.ToUpper(order)
+	if order != "ASC" && order != "DESC" {
+		order = "ASC"
+	}
+
+	// Calculate offset
+	offset := (page - 1) * pageSize
+
+	var total int
+	countQuery := `SELECT COUNT(*) ` + wordsWithStats + ` WHERE ` + where
+	if err := db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("error counting words: %v", err)
+	}
+
+	query := `
+		SELECT
+			w.id, w.language, w.term, w.reading, w.ruby, w.transliteration, w.gloss, w.parts,
+			COALESCE(correct_reviews.count, 0) as correct_count,
+			COALESCE(wrong_reviews.count, 0) as wrong_count` + wordsWithStats + sortJoin + `
+		WHERE ` + where + `
+		ORDER BY ` + dbSortField + ` ` + order + `, w.id ` + order + `
+		LIMIT ? OFFSET ?`
+
+	args = append(append(sortArgs, args...), pageSize, offset)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing words: %v", err)
+	}
+	defer rows.Close()
+
+	words := []*models.WordWithStats{}
+	for rows.Next() {
+		var word models.WordWithStats
+		var partsJSON []byte
+		var ruby sql.NullString
+		var correctCount, wrongCount int
+
+		err := rows.Scan(
+			&word.ID,
+			&word.Language,
+			&word.Kanji,
+			&word.Reading,
+			&ruby,
+			&word.Romaji,
+			&word.English,
+			&partsJSON,
+			&correctCount,
+			&wrongCount,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error scanning word: %v", err)
+		}
+
+		if err := json.Unmarshal(partsJSON, &word.Parts); err != nil {
+			return nil, 0, fmt.Errorf("error unmarshaling parts: %v", err)
+		}
+		if word.Ruby, err = parseRuby(ruby); err != nil {
+			return nil, 0, err
+		}
+
+		word.Stats = models.WordStats{
+			CorrectCount: correctCount,
+			WrongCount:   wrongCount,
+		}
+		if totalAttempts := correctCount + wrongCount; totalAttempts > 0 {
+			word.Stats.Accuracy = float64(correctCount) / float64(totalAttempts) * 100
+		}
+
+		words = append(words, &word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating words: %v", err)
+	}
+
+	return words, total, nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"backend-go/internal/domain/models"	
+	"backend-go/internal/kanjidic"
 	"backend-go/internal/repository/sqlite"
 
 )
@@ -20,42 +21,64 @@ func NewWordRepository(db *sqlite.Database) *WordRepository {
 	return &WordRepository{db: db}
 }
 
+// Create stores a word; words created without a language are Japanese
 func (r *WordRepository) Create(ctx context.Context, word *models.Word) error {
+	if word.Language == "" {
+		word.Language = models.DefaultLanguage
+	}
 	parts, err := json.Marshal(word.Parts)
 	if err != nil {
 		return fmt.Errorf("error marshaling parts: %v", err)
 	}
+	ruby, err := rubyJSON(word.Ruby)
+	if err != nil {
+		return err
+	}
 
 	query := `
-		INSERT INTO words (kanji, romaji, english, parts)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO terms (language, term, reading, ruby, transliteration, gloss, parts, sort_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		RETURNING id`
 
-	err = r.db.QueryRowContext(ctx, query,
-		word.Kanji,
-		word.Romaji,
-		word.English,
-		parts,
-	).Scan(&word.ID)
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		err := r.db.QueryRowContext(ctx, query,
+			word.Language,
+			word.Kanji,
+			word.Reading,
+			ruby,
+			word.Romaji,
+			word.English,
+			parts,
+			sortKey(word),
+		).Scan(&word.ID)
 
-	if err != nil {
-		return fmt.Errorf("error creating word: %v", err)
-	}
+		if err != nil {
+			return fmt.Errorf("error creating word: %v", err)
+		}
 
-	return nil
+		if err := r.linkKanji(ctx, word); err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, word.ID, models.RevisionCreate, nil)
+	})
 }
 
 func (r *WordRepository) GetByID(ctx context.Context, id int64) (*models.Word, error) {
 	word := &models.Word{}
 	var partsJSON []byte
+	var ruby sql.NullString
 
-	query := `SELECT id, kanji, romaji, english, parts FROM words WHERE id = ?`
+	query := `SELECT id, language, term, reading, ruby, transliteration, gloss, parts, sort_key FROM terms WHERE id = ? AND deleted_at IS NULL`
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&word.ID,
+		&word.Language,
 		&word.Kanji,
+		&word.Reading,
+		&ruby,
 		&word.Romaji,
 		&word.English,
 		&partsJSON,
+		&word.SortKey,
 	)
 
 	if err == sql.ErrNoRows {
@@ -68,48 +91,73 @@ func (r *WordRepository) GetByID(ctx context.Context, id int64) (*models.Word, e
 	if err := json.Unmarshal(partsJSON, &word.Parts); err != nil {
 		return nil, fmt.Errorf("error unmarshaling parts: %v", err)
 	}
+	if word.Ruby, err = parseRuby(ruby); err != nil {
+		return nil, err
+	}
 
 	return word, nil
 }
 
-func (r *WordRepository) List(ctx context.Context, page, pageSize int, sortBy, order string) ([]*models.WordWithStats, int, error) {
-	// Validate and sanitize sort parameters
-	allowedSortFields := map[string]string{
-		"kanji":         "w.kanji",
-		"romaji":        "w.romaji",
-		"english":       "w.english",
-		"correct_count": "COALESCE(correct_reviews.count, 0)",
-		"wrong_count":   "COALESCE(wrong_reviews.count, 0)",
+// GetByKanjiRomaji looks a word up by its natural key, the kanji and romaji
+// pair within a language; an empty language is Japanese
+func (r *WordRepository) GetByKanjiRomaji(ctx context.Context, kanji, romaji, language string) (*models.Word, error) {
+	if language == "" {
+		language = models.DefaultLanguage
 	}
-
-	dbSortField, ok := allowedSortFields[sortBy]
-	if !ok {
-		dbSortField = "w.kanji"
+	var id int64
+	query := `
+		SELECT id FROM terms
+		WHERE term = ? AND transliteration = ? AND language = ? AND deleted_at IS NULL
+		ORDER BY id LIMIT 1`
+	err := r.db.QueryRowContext(ctx, query, kanji, romaji, language).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting word: %v", err)
 	}
 
-	order = strings.ToUpper(order)
-	if order != "ASC" && order != "DESC" {
-		order = "ASC"
+	return r.GetByID(ctx, id)
+}
+
+// List returns a page of the words matching filter with their review stats
+func (r *WordRepository) List(ctx context.Context, page, pageSize int, sortBy, order string, filter models.WordFilter) ([]*models.WordWithStats, int, error) {
+	return listWords(ctx, r.db, filter, page, pageSize, sortBy, "kanji", order)
+}
+
+// Search returns words matching the query across kanji, romaji, english and
+// the values of parts, ordered by FTS5 relevance (bm25). A non-empty language
+// keeps the words of that language.
+func (r *WordRepository) Search(ctx context.Context, query, language string, page, pageSize int) ([]*models.WordWithStats, int, error) {
+	match := buildMatchExpression(query)
+	if match == "" {
+		return []*models.WordWithStats{}, 0, nil
 	}
+	args := []any{match, language, language}
 
 	// Calculate offset
 	offset := (page - 1) * pageSize
 
 	// Query to get total count
 	var total int
-	countQuery := `SELECT COUNT(*) FROM words`
-	err := r.db.QueryRowContext(ctx, countQuery).Scan(&total)
+	countQuery := `
+		SELECT COUNT(*)
+		FROM words_fts
+		JOIN terms w ON w.id = words_fts.rowid
+		WHERE words_fts MATCH ? AND w.deleted_at IS NULL AND (? = '' OR w.language = ?)`
+	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error counting words: %v", err)
+		return nil, 0, fmt.Errorf("error counting search results: %v", err)
 	}
 
-	// Main query with stats
-	query := `
+	// Main query with stats, most relevant first
+	searchQuery := `
 		SELECT 
-			w.id, w.kanji, w.romaji, w.english, w.parts,
+			w.id, w.language, w.term, w.reading, w.ruby, w.transliteration, w.gloss, w.parts,
 			COALESCE(correct_reviews.count, 0) as correct_count,
 			COALESCE(wrong_reviews.count, 0) as wrong_count
-		FROM words w
+		FROM words_fts
+		JOIN terms w ON w.id = words_fts.rowid
 		LEFT JOIN (
 			SELECT word_id, COUNT(*) as count
 			FROM word_review_items
@@ -122,12 +170,13 @@ func (r *WordRepository) List(ctx context.Context, page, pageSize int, sortBy, o
 			WHERE correct = false
 			GROUP BY word_id
 		) wrong_reviews ON w.id = wrong_reviews.word_id
-		ORDER BY ` + dbSortField + ` ` + order + `
+		WHERE words_fts MATCH ? AND w.deleted_at IS NULL AND (? = '' OR w.language = ?)
+		ORDER BY words_fts.rank, w.term
 		LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, pageSize, offset)
+	rows, err := r.db.QueryContext(ctx, searchQuery, append(args, pageSize, offset)...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error listing words: %v", err)
+		return nil, 0, fmt.Errorf("error searching words: %v", err)
 	}
 	defer rows.Close()
 
@@ -135,11 +184,15 @@ func (r *WordRepository) List(ctx context.Context, page, pageSize int, sortBy, o
 	for rows.Next() {
 		var word models.WordWithStats
 		var partsJSON []byte
+		var ruby sql.NullString
 		var correctCount, wrongCount int
 
 		err := rows.Scan(
 			&word.ID,
+			&word.Language,
 			&word.Kanji,
+			&word.Reading,
+			&ruby,
 			&word.Romaji,
 			&word.English,
 			&partsJSON,
@@ -153,13 +206,15 @@ func (r *WordRepository) List(ctx context.Context, page, pageSize int, sortBy, o
 		if err := json.Unmarshal(partsJSON, &word.Parts); err != nil {
 			return nil, 0, fmt.Errorf("error unmarshaling parts: %v", err)
 		}
+		if word.Ruby, err = parseRuby(ruby); err != nil {
+			return nil, 0, err
+		}
 
-		// Calculate accuracy
 		word.Stats = models.WordStats{
 			CorrectCount: correctCount,
 			WrongCount:   wrongCount,
 		}
-		
+
 		totalAttempts := correctCount + wrongCount
 		if totalAttempts > 0 {
 			word.Stats.Accuracy = float64(correctCount) / float64(totalAttempts) * 100
@@ -175,54 +230,196 @@ func (r *WordRepository) List(ctx context.Context, page, pageSize int, sortBy, o
 	return words, total, nil
 }
 
+// buildMatchExpression turns free text into an FTS5 query where every term
+// is quoted (so FTS5 operators in user input are treated literally) and
+// prefix-matched, e.g. `tab food` becomes `"tab"* "food"*`.
+func buildMatchExpression(query string) string {
+	var terms []string
+	for _, term := range strings.Fields(query) {
+		terms = append(terms, `"`+strings.ReplaceAll(term, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
+
 func (r *WordRepository) Update(ctx context.Context, word *models.Word) error {
+	if word.Language == "" {
+		word.Language = models.DefaultLanguage
+	}
 	parts, err := json.Marshal(word.Parts)
 	if err != nil {
 		return fmt.Errorf("error marshaling parts: %v", err)
 	}
+	ruby, err := rubyJSON(word.Ruby)
+	if err != nil {
+		return err
+	}
 
 	query := `
-		UPDATE words 
-		SET kanji = ?, romaji = ?, english = ?, parts = ?
-		WHERE id = ?`
-
-	result, err := r.db.ExecContext(ctx, query,
-		word.Kanji,
-		word.Romaji,
-		word.English,
-		parts,
-		word.ID,
-	)
-	if err != nil {
-		return fmt.Errorf("error updating word: %v", err)
+		UPDATE terms 
+		SET language = ?, term = ?, reading = ?, ruby = ?, transliteration = ?, gloss = ?, parts = ?, sort_key = ?
+		WHERE id = ? AND deleted_at IS NULL`
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.GetByID(ctx, word.ID)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			return fmt.Errorf("word not found")
+		}
+
+		result, err := r.db.ExecContext(ctx, query,
+			word.Language,
+			word.Kanji,
+			word.Reading,
+			ruby,
+			word.Romaji,
+			word.English,
+			parts,
+			sortKey(word),
+			word.ID,
+		)
+		if err != nil {
+			return fmt.Errorf("error updating word: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("word not found")
+		}
+
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM word_kanji WHERE word_id = ?`, word.ID); err != nil {
+			return fmt.Errorf("error unlinking word kanji: %v", err)
+		}
+		if err := r.linkKanji(ctx, word); err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, word.ID, models.RevisionUpdate, before)
+	})
+}
+
+// Delete moves a word to the trash. It keeps its groups, kanji, sentences
+// and review history until it is purged, but no longer counts towards the
+// words of its groups.
+func (r *WordRepository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := r.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE terms SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`
+		result, err := r.db.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("error deleting word: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("word not found")
+		}
+
+		if err := r.updateGroupsWordsCount(ctx, id); err != nil {
+			return err
+		}
+		return recordRevision(ctx, r.db, models.RevisionWord, id, models.RevisionDelete, before, nil)
+	})
+}
+
+// Restore takes a word out of the trash and reports whether it was there
+func (r *WordRepository) Restore(ctx context.Context, id int64) (bool, error) {
+	restored := false
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query := `UPDATE terms SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
+		result, err := r.db.ExecContext(ctx, query, id)
+		if err != nil {
+			return fmt.Errorf("error restoring word: %v", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("error getting affected rows: %v", err)
+		}
+		if rows == 0 {
+			return nil
+		}
+		restored = true
+
+		if err := r.updateGroupsWordsCount(ctx, id); err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, id, models.RevisionRestore, nil)
+	})
+	return restored, err
+}
+
+// updateGroupsWordsCount recomputes the words count of the groups of a word
+func (r *WordRepository) updateGroupsWordsCount(ctx context.Context, id int64) error {
+	query := `
+		UPDATE groups
+		SET words_count = ` + groupWordsCount + `
+		WHERE id IN (SELECT group_id FROM word_groups WHERE word_id = ?)`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("error updating words count: %v", err)
 	}
+	return nil
+}
 
-	rows, err := result.RowsAffected()
+// recordRevision records a change to a word, snapshotting the word as it
+// now is
+func (r *WordRepository) recordRevision(ctx context.Context, id int64, action string, before *models.Word) error {
+	after, err := r.GetByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("error getting affected rows: %v", err)
-	}
-	if rows == 0 {
-		return fmt.Errorf("word not found")
+		return err
 	}
-
-	return nil
+	return recordRevision(ctx, r.db, models.RevisionWord, id, action, before, after)
 }
 
-func (r *WordRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM words WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, id)
+// SetSortKey replaces the sort key of a word and reports whether it changed
+func (r *WordRepository) SetSortKey(ctx context.Context, id int64, sortKey string) (bool, error) {
+	query := `UPDATE terms SET sort_key = ? WHERE id = ? AND sort_key <> ?`
+	result, err := r.db.ExecContext(ctx, query, sortKey, id, sortKey)
 	if err != nil {
-		return fmt.Errorf("error deleting word: %v", err)
+		return false, fmt.Errorf("error setting word sort key: %v", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("error getting affected rows: %v", err)
+		return false, fmt.Errorf("error getting affected rows: %v", err)
 	}
-	if rows == 0 {
-		return fmt.Errorf("word not found")
+	return rows > 0, nil
+}
+
+// sortKey is the sort key set by the language pack of a word, or its term
+// for words stored without one
+func sortKey(word *models.Word) string {
+	if word.SortKey != "" {
+		return word.SortKey
 	}
+	return word.Kanji
+}
 
+// linkKanji records the kanji a word is written with. Positions count
+// characters from 1 like the backfill of migration 000009.
+func (r *WordRepository) linkKanji(ctx context.Context, word *models.Word) error {
+	query := `INSERT OR IGNORE INTO word_kanji (word_id, character, position) VALUES (?, ?, ?)`
+	position := 0
+	for _, char := range word.Kanji {
+		position++
+		if !kanjidic.IsKanji(char) {
+			continue
+		}
+		if _, err := r.db.ExecContext(ctx, query, word.ID, string(char), position); err != nil {
+			return fmt.Errorf("error linking kanji %c to word: %v", char, err)
+		}
+	}
 	return nil
 }
 
@@ -249,4 +446,79 @@ func (r *WordRepository) GetStats(ctx context.Context, wordID int64) (*models.Wo
 	}
 
 	return stats, nil
-} 
\ No newline at end of file
+} 
+
+// ListWithoutReading returns the Japanese words whose reading has not been
+// filled in
+func (r *WordRepository) ListWithoutReading(ctx context.Context) ([]*models.Word, error) {
+	query := `
+		SELECT id, language, term, reading, ruby, transliteration, gloss, parts
+		FROM terms
+		WHERE reading = '' AND language = 'ja' AND deleted_at IS NULL
+		ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("error listing words without reading: %v", err)
+	}
+	defer rows.Close()
+
+	var words []*models.Word
+	for rows.Next() {
+		var word models.Word
+		var partsJSON []byte
+		var ruby sql.NullString
+
+		err := rows.Scan(
+			&word.ID,
+			&word.Language,
+			&word.Kanji,
+			&word.Reading,
+			&ruby,
+			&word.Romaji,
+			&word.English,
+			&partsJSON,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning word: %v", err)
+		}
+
+		if err := json.Unmarshal(partsJSON, &word.Parts); err != nil {
+			return nil, fmt.Errorf("error unmarshaling parts: %v", err)
+		}
+		if word.Ruby, err = parseRuby(ruby); err != nil {
+			return nil, err
+		}
+
+		words = append(words, &word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating words: %v", err)
+	}
+
+	return words, nil
+}
+
+// rubyJSON encodes furigana for the ruby column, which is NULL when a word
+// has none
+func rubyJSON(ruby []models.RubySegment) (any, error) {
+	if len(ruby) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(ruby)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling ruby: %v", err)
+	}
+	return string(data), nil
+}
+
+func parseRuby(value sql.NullString) ([]models.RubySegment, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	var ruby []models.RubySegment
+	if err := json.Unmarshal([]byte(value.String), &ruby); err != nil {
+		return nil, fmt.Errorf("error unmarshaling ruby: %v", err)
+	}
+	return ruby, nil
+}
@@ -1,53 +1,371 @@
 package sqlite
 
 import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
-// RunMigrations executes all SQL migration files in the specified directory
-func (db *Database) RunMigrations(migrationsDir string) error {
-	// Read migration files
-	files, err := os.ReadDir(migrationsDir)
+// migrationFilePattern matches NNNNNN_name.up.sql and NNNNNN_name.down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change: an up script and the down script
+// that reverts it
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum returns the SHA-256 of the up script. It is recorded when the
+// migration is applied so later edits to the file can be detected.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// MigrationStatus describes one migration as seen by the schema_migrations table
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// Drifted is set when the applied checksum no longer matches the file
+	Drifted bool
+	// Missing is set when an applied migration has no file anymore
+	Missing bool
+}
+
+type appliedMigration struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// LoadMigrations reads the up/down pairs at the root of migrations, ordered by version
+func LoadMigrations(migrations fs.FS) ([]Migration, error) {
+	files, err := fs.ReadDir(migrations, ".")
 	if err != nil {
-		return fmt.Errorf("error reading migrations directory: %v", err)
+		return nil, fmt.Errorf("error reading migrations directory: %v", err)
 	}
 
-	// Get only .sql files and sort them
-	var migrations []string
+	byVersion := make(map[int64]*Migration)
 	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".sql") {
-			migrations = append(migrations, file.Name())
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".sql") {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(file.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s: expected NNNNNN_name.up.sql or NNNNNN_name.down.sql", file.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", file.Name())
+		}
+
+		content, err := fs.ReadFile(migrations, file.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration file %s: %v", file.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	loaded := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %06d_%s has no up file", migration.Version, migration.Name)
+		}
+		if migration.Down == "" {
+			return nil, fmt.Errorf("migration %06d_%s has no down file", migration.Version, migration.Name)
+		}
+		loaded = append(loaded, *migration)
+	}
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].Version < loaded[j].Version
+	})
+
+	return loaded, nil
+}
+
+// RunMigrations applies every pending migration in migrationFiles. It refuses
+// to run when an applied migration has been edited or removed since.
+func (db *Database) RunMigrations(migrationFiles fs.FS) error {
+	_, err := db.MigrateUp(migrationFiles)
+	return err
+}
+
+// MigrateUp applies all pending migrations and returns the ones it applied
+func (db *Database) MigrateUp(migrationFiles fs.FS) ([]Migration, error) {
+	migrations, applied, err := db.prepareMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.applyPending(migrations, applied, migrations[len(migrations)-1].Version)
+}
+
+// MigrateDown reverts the most recent steps applied migrations and returns
+// the ones it reverted
+func (db *Database) MigrateDown(migrationFiles fs.FS, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("number of migrations to revert must be positive")
+	}
+
+	migrations, applied, err := db.prepareMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for i := len(migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		if _, ok := applied[migrations[i].Version]; !ok {
+			continue
+		}
+		if err := db.revertMigration(migrations[i]); err != nil {
+			return reverted, err
+		}
+		reverted = append(reverted, migrations[i])
+	}
+
+	return reverted, nil
+}
+
+// MigrateTo applies or reverts migrations until version is the latest one
+// applied. Version 0 reverts everything. It returns the migrations it ran.
+func (db *Database) MigrateTo(migrationFiles fs.FS, version int64) ([]Migration, error) {
+	migrations, applied, err := db.prepareMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 {
+		known := false
+		for _, migration := range migrations {
+			if migration.Version == version {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown migration version %d", version)
+		}
+	}
+
+	var ran []Migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if migrations[i].Version <= version {
+			break
+		}
+		if _, ok := applied[migrations[i].Version]; !ok {
+			continue
+		}
+		if err := db.revertMigration(migrations[i]); err != nil {
+			return ran, err
 		}
+		ran = append(ran, migrations[i])
 	}
-	sort.Strings(migrations)
 
-	// Execute each migration file
+	appliedUp, err := db.applyPending(migrations, applied, version)
+	return append(ran, appliedUp...), err
+}
+
+// MigrationStatus reports every known migration, applied or not. Unlike the
+// other migration methods it does not fail on checksum drift; drifted and
+// missing migrations are flagged instead.
+func (db *Database) MigrationStatus(migrationFiles fs.FS) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.ensureMigrationsTable(); err != nil {
+		return nil, err
+	}
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
 	for _, migration := range migrations {
-		migrationPath := filepath.Join(migrationsDir, migration)
-		content, err := os.ReadFile(migrationPath)
-		if err != nil {
-			return fmt.Errorf("error reading migration file %s: %v", migration, err)
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.appliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Drifted = record.checksum != migration.Checksum()
+			delete(applied, migration.Version)
 		}
+		statuses = append(statuses, status)
+	}
 
-		// Execute the migration within a transaction
-		tx, err := db.DB.Begin()
-		if err != nil {
-			return fmt.Errorf("error starting transaction for %s: %v", migration, err)
+	for _, record := range applied {
+		appliedAt := record.appliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   record.version,
+			Name:      record.name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// prepareMigrations loads the migration files and the applied versions, and
+// fails if the two disagree about what has been applied
+func (db *Database) prepareMigrations(migrationFiles fs.FS) ([]Migration, map[int64]appliedMigration, error) {
+	migrations, err := LoadMigrations(migrationFiles)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, nil, fmt.Errorf("no migrations found")
+	}
+
+	if err := db.ensureMigrationsTable(); err != nil {
+		return nil, nil, err
+	}
+	applied, err := db.appliedMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		files[migration.Version] = migration
+	}
+	for version, record := range applied {
+		migration, ok := files[version]
+		if !ok {
+			return nil, nil, fmt.Errorf("applied migration %06d_%s has no migration file", version, record.name)
+		}
+		if record.checksum != migration.Checksum() {
+			return nil, nil, fmt.Errorf("migration %06d_%s has changed since it was applied (checksum %s, file %s)",
+				version, migration.Name, record.checksum, migration.Checksum())
 		}
+	}
+
+	return migrations, applied, nil
+}
+
+func (db *Database) ensureMigrationsTable() error {
+	_, err := db.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("error creating schema_migrations table: %v", err)
+	}
+	return nil
+}
 
-		if _, err := tx.Exec(string(content)); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("error executing migration %s: %v", migration, err)
+func (db *Database) appliedMigrations() (map[int64]appliedMigration, error) {
+	rows, err := db.DB.Query(`SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying schema_migrations: %v", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var record appliedMigration
+		if err := rows.Scan(&record.version, &record.name, &record.checksum, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("error scanning schema_migrations: %v", err)
 		}
+		applied[record.version] = record
+	}
+	return applied, rows.Err()
+}
 
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("error committing migration %s: %v", migration, err)
+// applyPending applies, in order, every migration up to and including
+// version that has not been applied yet
+func (db *Database) applyPending(migrations []Migration, applied map[int64]appliedMigration, version int64) ([]Migration, error) {
+	var ran []Migration
+	for _, migration := range migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
 		}
+		if err := db.applyMigration(migration); err != nil {
+			return ran, err
+		}
+		ran = append(ran, migration)
 	}
+	return ran, nil
+}
+
+func (db *Database) applyMigration(migration Migration) error {
+	return db.inMigrationTx(migration, "applying", func(tx *sql.Tx) error {
+		if _, err := tx.Exec(migration.Up); err != nil {
+			return err
+		}
+		_, err := tx.Exec(
+			`INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`,
+			migration.Version, migration.Name, migration.Checksum(), time.Now().UTC(),
+		)
+		return err
+	})
+}
 
+func (db *Database) revertMigration(migration Migration) error {
+	return db.inMigrationTx(migration, "reverting", func(tx *sql.Tx) error {
+		if _, err := tx.Exec(migration.Down); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`DELETE FROM schema_migrations WHERE version = ?`, migration.Version)
+		return err
+	})
+}
+
+// inMigrationTx runs fn in its own transaction so a failing script leaves
+// neither a half-applied schema nor a schema_migrations row behind
+func (db *Database) inMigrationTx(migration Migration, action string, fn func(tx *sql.Tx) error) error {
+	tx, err := db.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction for migration %06d_%s: %v", migration.Version, migration.Name, err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error %s migration %06d_%s: %v", action, migration.Version, migration.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing migration %06d_%s: %v", migration.Version, migration.Name, err)
+	}
 	return nil
-} 
\ No newline at end of file
+}
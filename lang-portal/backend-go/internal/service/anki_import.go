@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"backend-go/internal/anki"
+	"backend-go/internal/domain/models"
+)
+
+// The study activity imported Anki reviews are recorded under
+const (
+	ankiActivityName = "Anki"
+	ankiActivityURL  = "https://apps.ankiweb.net/"
+)
+
+// maxAnkiImportErrors caps the note errors returned by an import
+const maxAnkiImportErrors = 1000
+
+// AnkiFieldMapping tells which note fields hold the word fields. Fields named
+// in Parts are stored in parts under their key; when Parts is empty every
+// other non-empty field is stored under its field name.
+type AnkiFieldMapping struct {
+	Kanji   string
+	Romaji  string
+	English string
+	Parts   []AnkiPartsField
+}
+
+type AnkiPartsField struct {
+	Field string
+	Key   string
+}
+
+// DefaultAnkiFieldMapping matches the note type written by ExportGroupAnki
+func DefaultAnkiFieldMapping() AnkiFieldMapping {
+	return AnkiFieldMapping{Kanji: "Kanji", Romaji: "Romaji", English: "English"}
+}
+
+type AnkiImportParams struct {
+	Reader  io.Reader
+	Mapping AnkiFieldMapping
+	// IncludeHistory converts the review log into study sessions
+	IncludeHistory bool
+}
+
+// AnkiDeckResult reports what one deck of a package turned into
+type AnkiDeckResult struct {
+	Deck         string `json:"deck"`
+	GroupID      int64  `json:"group_id"`
+	GroupCreated bool   `json:"group_created"`
+	Notes        int    `json:"notes"`
+	Created      int    `json:"created"`
+	Existing     int    `json:"existing"`
+	Invalid      int    `json:"invalid"`
+	AddedToGroup int    `json:"added_to_group"`
+	Sessions     int    `json:"sessions"`
+	Reviews      int    `json:"reviews"`
+}
+
+// AnkiNoteError is a note that could not be imported
+type AnkiNoteError struct {
+	Deck     string `json:"deck"`
+	GUID     string `json:"guid"`
+	NoteType string `json:"note_type"`
+	Error    string `json:"error"`
+}
+
+type AnkiImportResult struct {
+	Decks           []AnkiDeckResult `json:"decks"`
+	Errors          []AnkiNoteError  `json:"errors"`
+	ErrorsTruncated bool             `json:"errors_truncated,omitempty"`
+	// Warnings lists imported notes whose romaji does not match the reading
+	Warnings []AnkiNoteError `json:"warnings,omitempty"`
+}
+
+// ImportAnki imports an .apkg or .colpkg package. Every deck becomes a group
+// of the same name (reusing an existing one), and every note a word, reusing
+// words that already exist with the same kanji and romaji. A deck named
+// after a smart group returns ErrSmartGroup and imports nothing.
+//
+// With IncludeHistory, the review log of each deck is grouped by day into
+// study sessions of the "Anki" activity, any answer but Again counting as
+// correct, and the schedules of the imported groups are rebuilt from the
+// history. Sessions that were already imported are skipped, so importing
+// the same package again does not duplicate history. Everything is written
+// in a single transaction.
+func (s *AnkiService) ImportAnki(ctx context.Context, params AnkiImportParams) (*AnkiImportResult, error) {
+	pkg, err := anki.ReadPackage(params.Reader)
+	if errors.Is(err, anki.ErrUnsupportedPackage) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(pkg.Decks) == 0 {
+		return nil, fmt.Errorf("%w: the package has no notes", ErrInvalidImport)
+	}
+
+	result := &AnkiImportResult{Decks: []AnkiDeckResult{}, Errors: []AnkiNoteError{}}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var activity *models.StudyActivity
+		if params.IncludeHistory {
+			var err error
+			if activity, err = s.ankiActivity(ctx); err != nil {
+				return err
+			}
+		}
+
+		for _, deck := range pkg.Decks {
+			deckResult, err := s.importAnkiDeck(ctx, deck, params.Mapping, activity, result)
+			if err != nil {
+				return fmt.Errorf("error importing deck %q: %w", deck.Name, err)
+			}
+			result.Decks = append(result.Decks, *deckResult)
+
+			// Imported reviews bypass the scheduler, so rebuild schedules from history
+			if deckResult.Reviews > 0 && s.scheduling != nil {
+				if _, err := s.scheduling.ReplayGroup(ctx, deckResult.GroupID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *AnkiService) importAnkiDeck(ctx context.Context, deck anki.Deck, mapping AnkiFieldMapping, activity *models.StudyActivity, result *AnkiImportResult) (*AnkiDeckResult, error) {
+	deckResult := &AnkiDeckResult{Deck: deck.Name, Notes: len(deck.Notes)}
+
+	group, err := s.groupRepo.GetByName(ctx, deck.Name)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		group = &models.Group{Name: deck.Name}
+		if err := s.groupRepo.Create(ctx, group); err != nil {
+			return nil, err
+		}
+		deckResult.GroupCreated = true
+	}
+	if err := checkNotSmart(group); err != nil {
+		return nil, err
+	}
+	deckResult.GroupID = group.ID
+
+	var reviews []ankiWordReview
+	for _, note := range deck.Notes {
+		word, err := wordFromAnkiNote(note, mapping)
+		var warning string
+		if err == nil {
+			warning, err = s.packs.PrepareWord(word)
+		}
+		if err != nil {
+			deckResult.Invalid++
+			if len(result.Errors) < maxAnkiImportErrors {
+				result.Errors = append(result.Errors, AnkiNoteError{Deck: deck.Name, GUID: note.GUID, NoteType: note.NoteType, Error: err.Error()})
+			} else {
+				result.ErrorsTruncated = true
+			}
+			continue
+		}
+		if warning != "" && len(result.Warnings) < maxAnkiImportErrors {
+			result.Warnings = append(result.Warnings, AnkiNoteError{Deck: deck.Name, GUID: note.GUID, NoteType: note.NoteType, Error: warning})
+		}
+
+		created, err := findOrCreateWord(ctx, s.wordRepo, word)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			deckResult.Created++
+		} else {
+			deckResult.Existing++
+		}
+
+		added, err := addToGroup(ctx, s.groupRepo, group.ID, word.ID)
+		if err != nil {
+			return nil, err
+		}
+		if added {
+			deckResult.AddedToGroup++
+		}
+
+		for _, review := range note.Reviews {
+			// Ease 0 marks manual reschedules rather than answers
+			if review.Ease == 0 {
+				continue
+			}
+			reviews = append(reviews, ankiWordReview{wordID: word.ID, review: review})
+		}
+	}
+
+	if activity != nil {
+		if err := s.importAnkiReviews(ctx, group.ID, activity.ID, reviews, deckResult); err != nil {
+			return nil, err
+		}
+	}
+	return deckResult, nil
+}
+
+type ankiWordReview struct {
+	wordID int64
+	review anki.Review
+}
+
+// importAnkiReviews records the reviews of a deck as one study session per
+// day, starting at the first review of that day
+func (s *AnkiService) importAnkiReviews(ctx context.Context, groupID, activityID int64, reviews []ankiWordReview, deckResult *AnkiDeckResult) error {
+	sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].review.Time.Before(reviews[j].review.Time) })
+
+	for start := 0; start < len(reviews); {
+		day := reviews[start].review.Time.UTC().Truncate(24 * time.Hour)
+		end := start
+		for end < len(reviews) && reviews[end].review.Time.UTC().Truncate(24*time.Hour).Equal(day) {
+			end++
+		}
+		dayReviews := reviews[start:end]
+		start = end
+
+		startedAt := dayReviews[0].review.Time.UTC().Truncate(time.Second)
+		existing, err := s.sessionRepo.FindSession(ctx, groupID, activityID, startedAt)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			continue
+		}
+
+		session := &models.StudySession{GroupID: groupID, StudyActivityID: activityID, CreatedAt: startedAt}
+		if err := s.sessionRepo.Create(ctx, session); err != nil {
+			return err
+		}
+		deckResult.Sessions++
+
+		for _, entry := range dayReviews {
+			item := &models.WordReviewItem{
+				WordID:         entry.wordID,
+				StudySessionID: session.ID,
+				Correct:        entry.review.Ease != anki.EaseAgain,
+				CreatedAt:      entry.review.Time.UTC(),
+			}
+			if err := s.sessionRepo.AddReview(ctx, item); err != nil {
+				return err
+			}
+			deckResult.Reviews++
+		}
+	}
+	return nil
+}
+
+// ankiActivity returns the study activity imported reviews are recorded
+// under, creating it on first use
+func (s *AnkiService) ankiActivity(ctx context.Context) (*models.StudyActivity, error) {
+	activity, err := s.activityRepo.GetByName(ctx, ankiActivityName)
+	if err != nil {
+		return nil, err
+	}
+	if activity != nil {
+		return activity, nil
+	}
+
+	activity = &models.StudyActivity{Name: ankiActivityName, URL: ankiActivityURL}
+	if err := s.activityRepo.Create(ctx, activity); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// wordFromAnkiNote maps the fields of a note onto a word
+func wordFromAnkiNote(note anki.Note, mapping AnkiFieldMapping) (*models.Word, error) {
+	field := func(name string) (string, error) {
+		value, ok := note.Field(name)
+		if !ok {
+			return "", fmt.Errorf("note type %q has no %q field", note.NoteType, name)
+		}
+		return value, nil
+	}
+
+	word := &models.Word{Parts: make(map[string]any)}
+	var err error
+	if word.Kanji, err = field(mapping.Kanji); err != nil {
+		return nil, err
+	}
+	if word.Romaji, err = field(mapping.Romaji); err != nil {
+		return nil, err
+	}
+	if word.English, err = field(mapping.English); err != nil {
+		return nil, err
+	}
+
+	if len(mapping.Parts) > 0 {
+		for _, part := range mapping.Parts {
+			value, err := field(part.Field)
+			if err != nil {
+				return nil, err
+			}
+			key := part.Key
+			if key == "" {
+				key = part.Field
+			}
+			if value != "" {
+				word.Parts[key] = value
+			}
+		}
+		return word, nil
+	}
+
+	for i, name := range note.FieldNames {
+		switch {
+		case i >= len(note.Fields) || note.Fields[i] == "":
+		case strings.EqualFold(name, mapping.Kanji), strings.EqualFold(name, mapping.Romaji), strings.EqualFold(name, mapping.English):
+		default:
+			word.Parts[name] = note.Fields[i]
+		}
+	}
+	return word, nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"backend-go/internal/anki"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+// ErrInvalidExport is returned when export options cannot be honored
+var ErrInvalidExport = errors.New("invalid export")
+
+// ankiNoteType names the note type of exported decks
+const ankiNoteType = "Lang Portal Word"
+
+// ankiWordFields are the note fields every exported word has, before any
+// parts fields
+var ankiWordFields = []string{"Kanji", "Romaji", "English"}
+
+type AnkiExportParams struct {
+	// PartsFields are parts keys exported as extra note fields, in order
+	PartsFields []string
+	// IncludeHistory carries the review history over as Anki's review log
+	// and schedules the cards accordingly
+	IncludeHistory bool
+}
+
+// AnkiService moves groups in and out of Anki deck packages
+type AnkiService struct {
+	tx           repository.Transactor
+	wordRepo     repository.WordRepository
+	groupRepo    repository.GroupRepository
+	activityRepo repository.StudyActivityRepository
+	sessionRepo  repository.StudySessionRepository
+	scheduleRepo repository.ScheduleRepository
+	scheduling   *SchedulingService
+	packs        *LanguagePacks
+}
+
+func NewAnkiService(
+	tx repository.Transactor,
+	wordRepo repository.WordRepository,
+	groupRepo repository.GroupRepository,
+	activityRepo repository.StudyActivityRepository,
+	sessionRepo repository.StudySessionRepository,
+	scheduleRepo repository.ScheduleRepository,
+	scheduling *SchedulingService,
+	packs *LanguagePacks,
+) *AnkiService {
+	return &AnkiService{
+		tx:           tx,
+		wordRepo:     wordRepo,
+		groupRepo:    groupRepo,
+		activityRepo: activityRepo,
+		sessionRepo:  sessionRepo,
+		scheduleRepo: scheduleRepo,
+		scheduling:   scheduling,
+		packs:        packs,
+	}
+}
+
+// ExportGroupAnki writes a group as an .apkg package with one deck named
+// after the group and one note per word. With IncludeHistory, the reviews
+// of each word are replayed through SM-2 (the algorithm Anki uses) to build
+// the review log and the current state of its card; otherwise every card is
+// new.
+func (s *AnkiService) ExportGroupAnki(ctx context.Context, groupID int64, params AnkiExportParams, w io.Writer) error {
+	fields, err := ankiExportFields(params.PartsFields)
+	if err != nil {
+		return err
+	}
+
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("error getting group: %v", err)
+	}
+	if group == nil {
+		return ErrGroupNotFound
+	}
+
+	history := make(map[int64][]*models.WordReviewItem)
+	if params.IncludeHistory {
+		reviews, err := s.scheduleRepo.ListHistory(ctx, groupID)
+		if err != nil {
+			return fmt.Errorf("error getting review history: %v", err)
+		}
+		for _, review := range reviews {
+			history[review.WordID] = append(history[review.WordID], review)
+		}
+	}
+
+	deck := anki.Deck{
+		Name:        group.Name,
+		Description: fmt.Sprintf("Exported from lang-portal group %d", group.ID),
+		ModelName:   ankiNoteType,
+		Fields:      fields,
+	}
+	err = eachGroupWordPage(ctx, s.groupRepo, groupID, func(words []*models.WordWithStats) error {
+		for _, word := range words {
+			note := anki.Note{
+				// Notes are keyed like words, so a re-export updates them in Anki
+				GUID:   anki.GUID(word.Kanji + "\x1f" + word.Romaji),
+				Fields: []string{word.Kanji, word.Romaji, word.English},
+			}
+			for _, key := range params.PartsFields {
+				note.Fields = append(note.Fields, csvCellValue(word.Parts[key]))
+			}
+			if reviews := history[word.ID]; len(reviews) > 0 {
+				note.Card, note.Reviews = ankiReviewLog(word.ID, reviews)
+			}
+			deck.Notes = append(deck.Notes, note)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := anki.WritePackage(w, deck); err != nil {
+		return fmt.Errorf("error writing package: %v", err)
+	}
+	return nil
+}
+
+// ankiExportFields returns the note fields for the requested parts keys.
+// Anki field names must be unique regardless of case and cannot contain
+// template syntax.
+func ankiExportFields(partsFields []string) ([]string, error) {
+	fields := append([]string{}, ankiWordFields...)
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		seen[strings.ToLower(field)] = true
+	}
+
+	for _, key := range partsFields {
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("%w: empty parts field", ErrInvalidExport)
+		}
+		if strings.ContainsAny(key, ":{}\"") || strings.ContainsAny(key[:1], "#/^") {
+			return nil, fmt.Errorf("%w: %q cannot be used as an Anki field name", ErrInvalidExport, key)
+		}
+		if seen[strings.ToLower(key)] {
+			return nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidExport, key)
+		}
+		seen[strings.ToLower(key)] = true
+		fields = append(fields, key)
+	}
+	return fields, nil
+}
+
+// ankiReviewLog replays the reviews of a word through SM-2 and returns the
+// resulting card together with one review log entry per review
+func ankiReviewLog(wordID int64, reviews []*models.WordReviewItem) (anki.Card, []anki.Review) {
+	scheduler := SM2Scheduler{}
+	state := models.WordSchedule{WordID: wordID}
+	log := make([]anki.Review, 0, len(reviews))
+
+	for _, review := range reviews {
+		reviewType := anki.ReviewTypeReview
+		switch {
+		case isNewCard(state):
+			reviewType = anki.ReviewTypeLearn
+		case state.Repetitions == 0:
+			reviewType = anki.ReviewTypeRelearn
+		}
+		ease := anki.EaseGood
+		if !review.Correct {
+			ease = anki.EaseAgain
+		}
+
+		next := scheduler.Next(state, GradeFromCorrect(review.Correct), review.CreatedAt)
+		log = append(log, anki.Review{
+			Time:         review.CreatedAt,
+			Ease:         ease,
+			Interval:     next.IntervalDays,
+			LastInterval: state.IntervalDays,
+			Factor:       ankiFactor(next.EaseFactor),
+			Type:         reviewType,
+		})
+		state = next
+	}
+
+	card := anki.Card{
+		Type:     anki.CardTypeReview,
+		Due:      state.DueAt,
+		Interval: state.IntervalDays,
+		Factor:   ankiFactor(state.EaseFactor),
+		Reps:     len(reviews),
+		Lapses:   state.Lapses,
+	}
+	return card, log
+}
+
+// ankiFactor converts an SM-2 ease factor to Anki's permille factor
+func ankiFactor(easeFactor float64) int {
+	return int(easeFactor*1000 + 0.5)
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"backend-go/internal/anki"
+	"backend-go/internal/domain/models"
+)
+
+// openAnkiCollection extracts the collection of an exported package and opens it
+func openAnkiCollection(t *testing.T, apkg []byte) *sql.DB {
+	archive, err := zip.NewReader(bytes.NewReader(apkg), int64(len(apkg)))
+	if err != nil {
+		t.Fatalf("Package is not a zip archive: %v", err)
+	}
+
+	var collection []byte
+	for _, file := range archive.File {
+		if file.Name != anki.CollectionFile {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("Failed to open collection: %v", err)
+		}
+		collection, _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	if collection == nil {
+		t.Fatalf("Package has no %s", anki.CollectionFile)
+	}
+
+	path := filepath.Join(t.TempDir(), anki.CollectionFile)
+	if err := os.WriteFile(path, collection, 0o644); err != nil {
+		t.Fatalf("Failed to write collection: %v", err)
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to open collection: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type ankiTestRepos struct {
+	words    *mockWordRepository
+	groups   *mockGroupRepository
+	sessions *mockStudySessionRepository
+	schedule *mockScheduleRepository
+}
+
+func newTestAnkiService() (*AnkiService, ankiTestRepos) {
+	repos := ankiTestRepos{
+		words:    NewMockWordRepository(),
+		groups:   NewMockGroupRepository(),
+		sessions: NewMockStudySessionRepository(),
+		schedule: NewMockScheduleRepository(),
+	}
+	scheduling := NewSchedulingService(NewMockTransactor(), repos.schedule, NewMockGroupSettingsRepository(), repos.groups)
+	service := NewAnkiService(NewMockTransactor(), repos.words, repos.groups, NewMockStudyActivityRepository(),
+		repos.sessions, repos.schedule, scheduling, DefaultLanguagePacks())
+	return service, repos
+}
+
+func TestAnkiService_ExportGroupAnki(t *testing.T) {
+	service, repos := newTestAnkiService()
+	groupRepo, scheduleRepo := repos.groups, repos.schedule
+	ctx := context.Background()
+
+	group := &models.Group{Name: "Core Verbs"}
+	groupRepo.Create(ctx, group)
+	groupRepo.AddWord(ctx, group.ID, 1)
+	groupRepo.AddWord(ctx, group.ID, 2)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	scheduleRepo.history = []*models.WordReviewItem{
+		{ID: 1, WordID: 1, Correct: true, CreatedAt: start},
+		{ID: 2, WordID: 1, Correct: true, CreatedAt: start.AddDate(0, 0, 1)},
+		{ID: 3, WordID: 1, Correct: false, CreatedAt: start.AddDate(0, 0, 7)},
+	}
+
+	var buf bytes.Buffer
+	err := service.ExportGroupAnki(ctx, group.ID, AnkiExportParams{PartsFields: []string{"verb_type"}, IncludeHistory: true}, &buf)
+	if err != nil {
+		t.Fatalf("Failed to export group: %v", err)
+	}
+	db := openAnkiCollection(t, buf.Bytes())
+
+	var notes int
+	db.QueryRow("SELECT COUNT(*) FROM notes").Scan(&notes)
+	if notes != 2 {
+		t.Errorf("Expected 2 notes, got %d", notes)
+	}
+
+	var reviewed, reps, lapses, interval int
+	db.QueryRow("SELECT COUNT(*), MAX(reps), MAX(lapses), MAX(ivl) FROM cards WHERE type = ?", anki.CardTypeReview).
+		Scan(&reviewed, &reps, &lapses, &interval)
+	if reviewed != 1 || reps != 3 || lapses != 1 || interval != 1 {
+		t.Errorf("Expected one reviewed card with 3 reps, 1 lapse and a 1 day interval, got %d cards, %d reps, %d lapses, %d days", reviewed, reps, lapses, interval)
+	}
+
+	rows, err := db.Query("SELECT id, ease, type FROM revlog ORDER BY id")
+	if err != nil {
+		t.Fatalf("Failed to read review log: %v", err)
+	}
+	defer rows.Close()
+	wantEase := []int{anki.EaseGood, anki.EaseGood, anki.EaseAgain}
+	wantType := []int{anki.ReviewTypeLearn, anki.ReviewTypeReview, anki.ReviewTypeReview}
+	i := 0
+	for rows.Next() {
+		var id int64
+		var ease, reviewType int
+		rows.Scan(&id, &ease, &reviewType)
+		if i < len(wantEase) && (ease != wantEase[i] || reviewType != wantType[i]) {
+			t.Errorf("Review %d: expected ease %d type %d, got ease %d type %d", i, wantEase[i], wantType[i], ease, reviewType)
+		}
+		if i == 0 && id != start.UnixMilli() {
+			t.Errorf("Expected the first review id to be its time %d, got %d", start.UnixMilli(), id)
+		}
+		i++
+	}
+	if i != 3 {
+		t.Errorf("Expected 3 review log entries, got %d", i)
+	}
+}
+
+func TestAnkiService_ExportGroupAnkiErrors(t *testing.T) {
+	service, repos := newTestAnkiService()
+	groupRepo := repos.groups
+	ctx := context.Background()
+
+	group := &models.Group{Name: "Vocab"}
+	groupRepo.Create(ctx, group)
+
+	err := service.ExportGroupAnki(ctx, 42, AnkiExportParams{}, io.Discard)
+	if !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("Expected ErrGroupNotFound, got %v", err)
+	}
+
+	for _, fields := range [][]string{{"English"}, {"topic", "Topic"}, {"{{topic}}"}} {
+		err := service.ExportGroupAnki(ctx, group.ID, AnkiExportParams{PartsFields: fields}, io.Discard)
+		if !errors.Is(err, ErrInvalidExport) {
+			t.Errorf("Expected ErrInvalidExport for %v, got %v", fields, err)
+		}
+	}
+}
+
+func TestAnkiService_ImportAnki(t *testing.T) {
+	service, repos := newTestAnkiService()
+	ctx := context.Background()
+
+	day := time.Date(2025, 2, 3, 8, 0, 0, 0, time.UTC)
+	deck := anki.Deck{
+		Name:   "Japanese::Food",
+		Fields: []string{"Front", "Reading", "Meaning", "Topic"},
+		Notes: []anki.Note{
+			{
+				Fields: []string{"食べる", "taberu", "to eat", "food"},
+				Card:   anki.Card{Type: anki.CardTypeReview, Due: day.AddDate(0, 0, 6), Interval: 6, Factor: 2500, Reps: 2},
+				Reviews: []anki.Review{
+					{Time: day, Ease: anki.EaseGood, Interval: 1},
+					{Time: day.Add(time.Minute), Ease: anki.EaseAgain},
+					{Time: day.AddDate(0, 0, 1), Ease: anki.EaseGood, Interval: 6},
+				},
+			},
+			{Fields: []string{"水", "mizu", "water", ""}},
+			{Fields: []string{"肉", "niku", "", "food"}},
+		},
+	}
+	var apkg bytes.Buffer
+	if err := anki.WritePackage(&apkg, deck); err != nil {
+		t.Fatalf("Failed to write package: %v", err)
+	}
+
+	params := AnkiImportParams{
+		Mapping:        AnkiFieldMapping{Kanji: "front", Romaji: "reading", English: "meaning"},
+		IncludeHistory: true,
+	}
+	params.Reader = bytes.NewReader(apkg.Bytes())
+	result, err := service.ImportAnki(ctx, params)
+	if err != nil {
+		t.Fatalf("Failed to import package: %v", err)
+	}
+
+	if len(result.Decks) != 1 {
+		t.Fatalf("Expected 1 deck, got %+v", result.Decks)
+	}
+	got := result.Decks[0]
+	if got.Deck != "Japanese::Food" || !got.GroupCreated || got.Created != 2 || got.Invalid != 1 || got.AddedToGroup != 2 {
+		t.Errorf("Unexpected deck result: %+v", got)
+	}
+	if got.Sessions != 2 || got.Reviews != 3 {
+		t.Errorf("Expected one session per day with 3 reviews, got %d sessions and %d reviews", got.Sessions, got.Reviews)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Error != "english translation is required" {
+		t.Errorf("Expected the note without meaning to be reported, got %+v", result.Errors)
+	}
+
+	word, _ := repos.words.GetByKanjiRomaji(ctx, "食べる", "taberu", "")
+	if word == nil {
+		t.Fatal("Expected 食べる to be imported")
+	}
+	if word.Parts["Topic"] != "food" {
+		t.Errorf("Expected unmapped fields in parts, got %v", word.Parts)
+	}
+	if _, ok := word.Parts["Reading"]; ok {
+		t.Error("Expected mapped fields to be left out of parts")
+	}
+
+	var correct, wrong int
+	for _, reviews := range repos.sessions.reviews {
+		for _, review := range reviews {
+			if review.Correct {
+				correct++
+			} else {
+				wrong++
+			}
+		}
+	}
+	if correct != 2 || wrong != 1 {
+		t.Errorf("Expected 2 correct and 1 wrong review, got %d and %d", correct, wrong)
+	}
+
+	// Importing the same package again adds nothing
+	params.Reader = bytes.NewReader(apkg.Bytes())
+	result, err = service.ImportAnki(ctx, params)
+	if err != nil {
+		t.Fatalf("Failed to import package again: %v", err)
+	}
+	got = result.Decks[0]
+	if got.GroupCreated || got.Created != 0 || got.Existing != 2 || got.AddedToGroup != 0 || got.Sessions != 0 || got.Reviews != 0 {
+		t.Errorf("Expected the second import to find everything, got %+v", got)
+	}
+}
+
+func TestAnkiService_ImportAnkiErrors(t *testing.T) {
+	service, _ := newTestAnkiService()
+
+	_, err := service.ImportAnki(context.Background(), AnkiImportParams{
+		Reader:  bytes.NewReader([]byte("not a zip")),
+		Mapping: DefaultAnkiFieldMapping(),
+	})
+	if !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("Expected ErrInvalidImport, got %v", err)
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"backend-go/internal/conjugation"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+var (
+	// ErrWordNotFound is returned when a referenced word does not exist
+	ErrWordNotFound = errors.New("word not found")
+	// ErrSessionNotFound is returned when a referenced study session does
+	// not exist
+	ErrSessionNotFound = errors.New("study session not found")
+	// ErrNotConjugable is returned for words without a usable verb_type or
+	// adjective_type, and for forms their class does not have
+	ErrNotConjugable = conjugation.ErrNotConjugable
+)
+
+const (
+	defaultDrillCount = 10
+	maxDrillCount     = 50
+)
+
+// ConjugationService conjugates verbs and adjectives with the language pack
+// of each word and drills their forms.
+// Drill answers are stored as word reviews tagged with the form, which keeps
+// them out of the spaced-repetition schedule of the word itself.
+type ConjugationService struct {
+	wordRepo    repository.WordRepository
+	groupRepo   repository.GroupRepository
+	sessionRepo repository.StudySessionRepository
+	packs       *LanguagePacks
+}
+
+func NewConjugationService(
+	wordRepo repository.WordRepository,
+	groupRepo repository.GroupRepository,
+	sessionRepo repository.StudySessionRepository,
+	packs *LanguagePacks,
+) *ConjugationService {
+	return &ConjugationService{
+		wordRepo:    wordRepo,
+		groupRepo:   groupRepo,
+		sessionRepo: sessionRepo,
+		packs:       packs,
+	}
+}
+
+// GetWordConjugations returns every form of a word with the drill results
+// recorded for it
+func (s *ConjugationService) GetWordConjugations(ctx context.Context, wordID int64) (*models.WordConjugations, error) {
+	word, err := s.getWord(ctx, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	class, conjugations, err := s.conjugate(word)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.sessionRepo.ListFormStats(ctx, 0, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting form stats: %v", err)
+	}
+	byForm := make(map[string]models.WordStats, len(stats))
+	for _, stat := range stats {
+		byForm[stat.Form] = stat.WordStats
+	}
+	for i := range conjugations {
+		conjugations[i].Stats = byForm[conjugations[i].Form]
+	}
+
+	return &models.WordConjugations{
+		WordID:       word.ID,
+		Kanji:        word.Kanji,
+		Reading:      word.Reading,
+		English:      word.English,
+		Class:        class,
+		Conjugations: conjugations,
+	}, nil
+}
+
+type DrillParams struct {
+	Count int
+	// Forms limits the drill to these forms; empty drills every form but the
+	// dictionary form
+	Forms []string
+}
+
+// Drill picks word and form pairs from the conjugable words of a group,
+// preferring the pairs drilled least and then those answered worst
+func (s *ConjugationService) Drill(ctx context.Context, groupID int64, params DrillParams) ([]*models.ConjugationExercise, error) {
+	if params.Count < 1 {
+		params.Count = defaultDrillCount
+	}
+	if params.Count > maxDrillCount {
+		params.Count = maxDrillCount
+	}
+
+	group, err := s.verifyGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	pack, err := s.packs.Get(group.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(params.Forms))
+	for _, name := range params.Forms {
+		form, err := parseForm(pack, name)
+		if err != nil {
+			return nil, err
+		}
+		wanted[form] = true
+	}
+
+	var wordIDs []int64
+	err = eachGroupWordPage(ctx, s.groupRepo, groupID, func(words []*models.WordWithStats) error {
+		for _, word := range words {
+			wordIDs = append(wordIDs, word.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.sessionRepo.ListFormStats(ctx, groupID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error getting form stats: %v", err)
+	}
+	type key struct {
+		wordID int64
+		form   string
+	}
+	drilled := make(map[key]models.WordStats, len(stats))
+	for _, stat := range stats {
+		drilled[key{stat.WordID, stat.Form}] = stat.WordStats
+	}
+
+	exercises := []*models.ConjugationExercise{}
+	for _, wordID := range wordIDs {
+		word, err := s.getWord(ctx, wordID)
+		if err != nil {
+			return nil, err
+		}
+		class, conjugations, err := s.conjugate(word)
+		if errors.Is(err, ErrNotConjugable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, c := range conjugations {
+			// The dictionary form is only drilled when asked for
+			if (len(wanted) > 0 && !wanted[c.Form]) || (len(wanted) == 0 && i == 0) {
+				continue
+			}
+			c.Stats = drilled[key{word.ID, c.Form}]
+			exercises = append(exercises, &models.ConjugationExercise{
+				WordID:  word.ID,
+				Kanji:   word.Kanji,
+				Reading: word.Reading,
+				English: word.English,
+				Class:   class,
+				Form:    c.Form,
+				Answer:  c,
+			})
+		}
+	}
+
+	rand.Shuffle(len(exercises), func(i, j int) {
+		exercises[i], exercises[j] = exercises[j], exercises[i]
+	})
+	sort.SliceStable(exercises, func(i, j int) bool {
+		a, b := exercises[i].Answer.Stats, exercises[j].Answer.Stats
+		if attempts := a.CorrectCount + a.WrongCount; attempts != b.CorrectCount+b.WrongCount {
+			return attempts < b.CorrectCount+b.WrongCount
+		}
+		return a.Accuracy < b.Accuracy
+	})
+	if len(exercises) > params.Count {
+		exercises = exercises[:params.Count]
+	}
+
+	return exercises, nil
+}
+
+type ConjugationReviewParams struct {
+	WordID int64
+	Form   string
+	// Answer is checked against the form by the language pack of the word,
+	// e.g. in kanji, kana or romaji for Japanese. Without an answer Correct
+	// is recorded as given.
+	Answer  string
+	Correct bool
+}
+
+// ConjugationReviewResult is a recorded drill answer with the expected form
+type ConjugationReviewResult struct {
+	Review   *models.WordReviewItem `json:"review"`
+	Expected models.Conjugation     `json:"expected"`
+}
+
+// RecordReview stores a drill answer for a form of a word in a study session
+func (s *ConjugationService) RecordReview(ctx context.Context, sessionID int64, params ConjugationReviewParams) (*ConjugationReviewResult, error) {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying session: %v", err)
+	}
+	if session == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	word, err := s.getWord(ctx, params.WordID)
+	if err != nil {
+		return nil, err
+	}
+	pack, err := s.packs.Get(word.Language)
+	if err != nil {
+		return nil, err
+	}
+	form, err := parseForm(pack, params.Form)
+	if err != nil {
+		return nil, err
+	}
+	_, conjugations, err := pack.Conjugate(word)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected *models.Conjugation
+	for i := range conjugations {
+		if conjugations[i].Form == form {
+			expected = &conjugations[i]
+		}
+	}
+	if expected == nil {
+		return nil, fmt.Errorf("%w: %s has no %s form", ErrNotConjugable, word.Kanji, form)
+	}
+
+	correct := params.Correct
+	if strings.TrimSpace(params.Answer) != "" {
+		correct = pack.CheckAnswer(params.Answer, *expected)
+	}
+
+	review := &models.WordReviewItem{
+		WordID:         word.ID,
+		StudySessionID: sessionID,
+		Correct:        correct,
+		Form:           form,
+	}
+	if err := s.sessionRepo.AddReview(ctx, review); err != nil {
+		return nil, fmt.Errorf("error adding conjugation review: %v", err)
+	}
+
+	return &ConjugationReviewResult{Review: review, Expected: *expected}, nil
+}
+
+// GroupFormStats returns the drill results of a group summed per form
+func (s *ConjugationService) GroupFormStats(ctx context.Context, groupID int64) ([]*models.FormStats, error) {
+	if _, err := s.verifyGroup(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.sessionRepo.ListFormStats(ctx, groupID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error getting form stats: %v", err)
+	}
+
+	byForm := make(map[string]*models.FormStats)
+	totals := []*models.FormStats{}
+	for _, stat := range stats {
+		total := byForm[stat.Form]
+		if total == nil {
+			total = &models.FormStats{Form: stat.Form}
+			byForm[stat.Form] = total
+			totals = append(totals, total)
+		}
+		total.CorrectCount += stat.CorrectCount
+		total.WrongCount += stat.WrongCount
+	}
+	for _, total := range totals {
+		total.Accuracy = float64(total.CorrectCount) / float64(total.CorrectCount+total.WrongCount) * 100
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Form < totals[j].Form })
+
+	return totals, nil
+}
+
+// conjugate returns the class of a word and its forms, conjugated by the
+// pack of its language
+func (s *ConjugationService) conjugate(word *models.Word) (string, []models.Conjugation, error) {
+	pack, err := s.packs.Get(word.Language)
+	if err != nil {
+		return "", nil, err
+	}
+	return pack.Conjugate(word)
+}
+
+func (s *ConjugationService) getWord(ctx context.Context, wordID int64) (*models.Word, error) {
+	word, err := s.wordRepo.GetByID(ctx, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting word: %v", err)
+	}
+	if word == nil {
+		return nil, ErrWordNotFound
+	}
+	return word, nil
+}
+
+func (s *ConjugationService) verifyGroup(ctx context.Context, groupID int64) (*models.Group, error) {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying group: %v", err)
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// parseForm returns the form of a language pack with the given name
+func parseForm(pack LanguagePack, name string) (string, error) {
+	form := strings.ToLower(strings.TrimSpace(name))
+	for _, known := range pack.Forms() {
+		if form == known {
+			return form, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown form %q", ErrNotConjugable, name)
+}
+
+func partString(parts map[string]any, key string) string {
+	value, _ := parts[key].(string)
+	return strings.TrimSpace(value)
+}
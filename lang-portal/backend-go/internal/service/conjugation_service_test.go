@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func TestConjugationService_Drill(t *testing.T) {
+	wordRepo := NewMockWordRepository()
+	groupRepo := NewMockGroupRepository()
+	sessionRepo := NewMockStudySessionRepository()
+	service := NewConjugationService(wordRepo, groupRepo, sessionRepo, DefaultLanguagePacks())
+	ctx := context.Background()
+
+	group := &models.Group{Name: "Basic Verbs"}
+	if err := groupRepo.Create(ctx, group); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+	words := []*models.Word{
+		{Kanji: "食べる", Reading: "たべる", Romaji: "taberu", English: "to eat", Parts: map[string]any{"verb_type": "ru-verb"}},
+		{Kanji: "本", Reading: "ほん", Romaji: "hon", English: "book", Parts: map[string]any{"type": "noun"}},
+	}
+	for _, word := range words {
+		if err := wordRepo.Create(ctx, word); err != nil {
+			t.Fatalf("Failed to create test word: %v", err)
+		}
+		groupRepo.AddWord(ctx, group.ID, word.ID)
+	}
+	session := &models.StudySession{GroupID: group.ID, StudyActivityID: 1}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+
+	exercises, err := service.Drill(ctx, group.ID, DrillParams{Count: 50})
+	if err != nil {
+		t.Fatalf("Drill() error = %v", err)
+	}
+	// Every verb form but the dictionary form, none for the noun
+	if len(exercises) != 13 {
+		t.Fatalf("Drill() returned %d exercises, want 13", len(exercises))
+	}
+
+	result, err := service.RecordReview(ctx, session.ID, ConjugationReviewParams{WordID: 1, Form: "te", Answer: "tabete"})
+	if err != nil {
+		t.Fatalf("RecordReview() error = %v", err)
+	}
+	if !result.Review.Correct || result.Review.Form != "te" || result.Expected.Kanji != "食べて" {
+		t.Errorf("RecordReview() = %+v, %+v", result.Review, result.Expected)
+	}
+	if _, err := service.RecordReview(ctx, session.ID, ConjugationReviewParams{WordID: 1, Form: "polite", Answer: "食べるます"}); err != nil {
+		t.Fatalf("RecordReview() error = %v", err)
+	}
+
+	// Drilled forms go to the back of the queue
+	exercises, err = service.Drill(ctx, group.ID, DrillParams{Count: 2, Forms: []string{"te", "polite", "passive"}})
+	if err != nil {
+		t.Fatalf("Drill() error = %v", err)
+	}
+	if len(exercises) != 2 || exercises[0].Form != "passive" || exercises[1].Form != "polite" {
+		t.Errorf("Drill() picked %+v, want passive then polite", exercises)
+	}
+
+	stats, err := service.GroupFormStats(ctx, group.ID)
+	if err != nil {
+		t.Fatalf("GroupFormStats() error = %v", err)
+	}
+	if len(stats) != 2 || stats[0].Form != "polite" || stats[0].WrongCount != 1 || stats[1].Form != "te" || stats[1].Accuracy != 100 {
+		t.Errorf("GroupFormStats() = %+v", stats)
+	}
+
+	if _, err := service.RecordReview(ctx, session.ID, ConjugationReviewParams{WordID: 2, Form: "te", Correct: true}); !errors.Is(err, ErrNotConjugable) {
+		t.Errorf("RecordReview() on a noun error = %v, want ErrNotConjugable", err)
+	}
+	if _, err := service.Drill(ctx, group.ID, DrillParams{Forms: []string{"imperative"}}); !errors.Is(err, ErrNotConjugable) {
+		t.Errorf("Drill() with an unknown form error = %v, want ErrNotConjugable", err)
+	}
+}
+
+func TestConjugationService_GetWordConjugations(t *testing.T) {
+	wordRepo := NewMockWordRepository()
+	service := NewConjugationService(wordRepo, NewMockGroupRepository(), NewMockStudySessionRepository(), DefaultLanguagePacks())
+	ctx := context.Background()
+
+	word := &models.Word{Kanji: "来る", Reading: "くる", Romaji: "kuru", English: "to come", Parts: map[string]any{"verb_type": "irregular"}}
+	if err := wordRepo.Create(ctx, word); err != nil {
+		t.Fatalf("Failed to create test word: %v", err)
+	}
+
+	result, err := service.GetWordConjugations(ctx, word.ID)
+	if err != nil {
+		t.Fatalf("GetWordConjugations() error = %v", err)
+	}
+	if result.Class != "kuru" {
+		t.Errorf("GetWordConjugations() class = %s, want kuru", result.Class)
+	}
+	for _, c := range result.Conjugations {
+		if c.Form == "plain_negative" && (c.Kanji != "来ない" || c.Reading != "こない" || c.Romaji != "konai") {
+			t.Errorf("GetWordConjugations() plain_negative = %+v", c)
+		}
+	}
+
+	if _, err := service.GetWordConjugations(ctx, 42); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("GetWordConjugations() error = %v, want ErrWordNotFound", err)
+	}
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/jmdict"
+	"backend-go/internal/kanjidic"
+	"backend-go/internal/repository"
+	"backend-go/internal/transliteration"
+)
+
+var (
+	// ErrDictionaryEntryNotFound is returned for an unknown JMdict entry
+	ErrDictionaryEntryNotFound = errors.New("dictionary entry not found")
+	// ErrInvalidDictionaryWord is returned when the spelling or senses asked
+	// for are not part of the dictionary entry
+	ErrInvalidDictionaryWord = errors.New("invalid dictionary word")
+	// ErrWordExists is returned when creating a word that is already stored
+	// with the same kanji and romaji
+	ErrWordExists = errors.New("word already exists")
+)
+
+// dictionaryVerbTypes map JMdict verb codes to the verb_type of a word. Every
+// other v5 code is a godan verb; する verbs written with する are irregular
+// while nouns taking する conjugate as suru verbs.
+var dictionaryVerbTypes = map[string]string{
+	"v1":   "ichidan",
+	"v1-s": "ichidan",
+	"vk":   "irregular",
+	"vs-i": "irregular",
+	"vs-s": "irregular",
+	"vs":   "suru",
+}
+
+// dictionaryAdjectiveTypes map JMdict adjective codes to the adjective_type
+// of a word
+var dictionaryAdjectiveTypes = map[string]string{
+	"adj-i":  "i-adjective",
+	"adj-ix": "i-adjective",
+	"adj-na": "na-adjective",
+}
+
+// DictionaryService looks words up in JMdict and turns entries into words
+type DictionaryService struct {
+	tx             repository.Transactor
+	dictionaryRepo repository.DictionaryRepository
+	wordRepo       repository.WordRepository
+	packs          *LanguagePacks
+}
+
+func NewDictionaryService(tx repository.Transactor, dictionaryRepo repository.DictionaryRepository, wordRepo repository.WordRepository, packs *LanguagePacks) *DictionaryService {
+	return &DictionaryService{
+		tx:             tx,
+		dictionaryRepo: dictionaryRepo,
+		wordRepo:       wordRepo,
+		packs:          packs,
+	}
+}
+
+type DictionaryImportResult struct {
+	Entries int `json:"entries"`
+}
+
+// ImportJMdict loads a JMdict XML file into the dictionary, replacing the
+// entries already there with the same number. The file is streamed and
+// written in a single transaction.
+func (s *DictionaryService) ImportJMdict(ctx context.Context, r io.Reader) (*DictionaryImportResult, error) {
+	result := &DictionaryImportResult{}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return jmdict.Read(r, func(e *jmdict.Entry) error {
+			entry := &models.DictionaryEntry{
+				ID:       e.Sequence,
+				Kanji:    e.Kanji,
+				Readings: e.Readings,
+				Senses:   make([]models.DictionarySense, len(e.Senses)),
+				Common:   e.Common,
+			}
+			for i, sense := range e.Senses {
+				entry.Senses[i] = models.DictionarySense{
+					PartsOfSpeech: make([]models.PartOfSpeech, len(sense.PartsOfSpeech)),
+					Misc:          sense.Misc,
+					Glosses:       sense.Glosses,
+				}
+				for j, pos := range sense.PartsOfSpeech {
+					entry.Senses[i].PartsOfSpeech[j] = models.PartOfSpeech{Code: pos.Code, Description: pos.Description}
+				}
+			}
+
+			if err := s.dictionaryRepo.Upsert(ctx, entry); err != nil {
+				return err
+			}
+			result.Entries++
+			return nil
+		})
+	})
+	if errors.Is(err, jmdict.ErrInvalidFile) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type LookupDictionaryParams struct {
+	// Query is the start of a kanji spelling, a reading in kana or romaji,
+	// or an English gloss
+	Query    string
+	Page     int
+	PageSize int
+}
+
+type LookupDictionaryResult struct {
+	Entries     []*models.DictionaryEntry
+	TotalItems  int
+	CurrentPage int
+	TotalPages  int
+}
+
+// LookupDictionary finds the entries starting with the query, exact matches
+// and common words first. Text with kanji is looked up in the kanji
+// spellings and kana in the readings; other text both as romaji and as
+// English.
+func (s *DictionaryService) LookupDictionary(ctx context.Context, params LookupDictionaryParams) (*LookupDictionaryResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+
+	entries, total, err := s.dictionaryRepo.Search(ctx, dictionaryQuery(params.Query), params.Page, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error searching dictionary: %v", err)
+	}
+
+	return &LookupDictionaryResult{
+		Entries:     entries,
+		TotalItems:  total,
+		CurrentPage: params.Page,
+		TotalPages:  (total + params.PageSize - 1) / params.PageSize,
+	}, nil
+}
+
+func dictionaryQuery(text string) models.DictionaryQuery {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.ContainsFunc(text, kanjidic.IsKanji):
+		return models.DictionaryQuery{Kanji: text}
+	case transliteration.IsKana(text):
+		romaji, err := transliteration.Romanize(text, transliteration.Wapuro)
+		if err != nil {
+			return models.DictionaryQuery{}
+		}
+		return models.DictionaryQuery{Romaji: romaji}
+	default:
+		return models.DictionaryQuery{Romaji: text, English: text}
+	}
+}
+
+// WordFromDictionaryParams picks what a word created from a dictionary entry
+// is made of
+type WordFromDictionaryParams struct {
+	// Kanji is the spelling of the word, one of the kanji spellings or
+	// readings of the entry. It defaults to the first kanji spelling, or the
+	// first reading for entries without kanji or usually written in kana.
+	Kanji string
+	// Reading is one of the readings of the entry, by default the first
+	Reading string
+	// Senses are the indexes of the senses the English is made of, by
+	// default the first one
+	Senses []int
+}
+
+// CreateWordFromDictionary creates a word from a JMdict entry. The English
+// joins the glosses of the chosen senses, and the parts of speech of those
+// senses set parts.verb_type or parts.adjective_type; parts also keeps the
+// JMdict codes under "pos" and the entry number under "jmdict_id".
+func (s *DictionaryService) CreateWordFromDictionary(ctx context.Context, entryID int64, params WordFromDictionaryParams) (*models.Word, []string, error) {
+	entry, err := s.dictionaryRepo.GetByID(ctx, entryID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting dictionary entry: %v", err)
+	}
+	if entry == nil {
+		return nil, nil, ErrDictionaryEntryNotFound
+	}
+
+	word, err := wordFromDictionary(entry, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	warning, err := s.packs.PrepareWord(word)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := s.wordRepo.GetByKanjiRomaji(ctx, word.Kanji, word.Romaji, word.Language)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting word: %v", err)
+	}
+	if existing != nil {
+		return nil, nil, fmt.Errorf("%w: %s (%s) is word %d", ErrWordExists, word.Kanji, word.Romaji, existing.ID)
+	}
+
+	if err := s.wordRepo.Create(ctx, word); err != nil {
+		return nil, nil, fmt.Errorf("error creating word: %v", err)
+	}
+	return word, warnings(warning), nil
+}
+
+func wordFromDictionary(entry *models.DictionaryEntry, params WordFromDictionaryParams) (*models.Word, error) {
+	if len(entry.Readings) == 0 || len(entry.Senses) == 0 {
+		return nil, fmt.Errorf("%w: entry %d has no reading or sense", ErrInvalidDictionaryWord, entry.ID)
+	}
+
+	senseIndexes := params.Senses
+	if len(senseIndexes) == 0 {
+		senseIndexes = []int{0}
+	}
+	var senses []models.DictionarySense
+	for _, index := range senseIndexes {
+		if index < 0 || index >= len(entry.Senses) {
+			return nil, fmt.Errorf("%w: entry %d has no sense %d", ErrInvalidDictionaryWord, entry.ID, index)
+		}
+		senses = append(senses, entry.Senses[index])
+	}
+
+	reading := params.Reading
+	if reading == "" {
+		reading = entry.Readings[0]
+	} else if !slices.Contains(entry.Readings, reading) {
+		return nil, fmt.Errorf("%w: %s is not a reading of entry %d", ErrInvalidDictionaryWord, reading, entry.ID)
+	}
+
+	kanji := params.Kanji
+	switch {
+	case kanji == "" && len(entry.Kanji) > 0 && !slices.Contains(senses[0].Misc, "uk"):
+		kanji = entry.Kanji[0]
+	case kanji == "":
+		kanji = reading
+	case !slices.Contains(entry.Kanji, kanji) && !slices.Contains(entry.Readings, kanji):
+		return nil, fmt.Errorf("%w: %s is not a spelling of entry %d", ErrInvalidDictionaryWord, kanji, entry.ID)
+	}
+
+	english := make([]string, len(senses))
+	for i, sense := range senses {
+		english[i] = strings.Join(sense.Glosses, ", ")
+	}
+
+	return &models.Word{
+		Language: "ja",
+		Kanji:    kanji,
+		Reading:  reading,
+		English:  strings.Join(english, "; "),
+		Parts:    partsFromDictionary(entry.ID, senses),
+	}, nil
+}
+
+// partsFromDictionary maps the parts of speech of the senses to the parts of
+// a word, the first verb or adjective code deciding its type
+func partsFromDictionary(entryID int64, senses []models.DictionarySense) map[string]any {
+	parts := map[string]any{"jmdict_id": entryID}
+	var codes []string
+	for _, sense := range senses {
+		for _, pos := range sense.PartsOfSpeech {
+			if slices.Contains(codes, pos.Code) {
+				continue
+			}
+			codes = append(codes, pos.Code)
+
+			verbType, ok := dictionaryVerbTypes[pos.Code]
+			if !ok && strings.HasPrefix(pos.Code, "v5") {
+				verbType, ok = "godan", true
+			}
+			if _, set := parts["verb_type"]; ok && !set {
+				parts["verb_type"] = verbType
+			}
+			if adjectiveType, ok := dictionaryAdjectiveTypes[pos.Code]; ok {
+				if _, set := parts["adjective_type"]; !set {
+					parts["adjective_type"] = adjectiveType
+				}
+			}
+		}
+	}
+	if len(codes) > 0 {
+		parts["pos"] = codes
+	}
+	return parts
+}
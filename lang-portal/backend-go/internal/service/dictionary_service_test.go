@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+const testJMdict = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE JMdict [
+<!ENTITY v1 "Ichidan verb">
+<!ENTITY v5k-s "Godan verb - Iku/Yuku special class">
+<!ENTITY vi "intransitive verb">
+<!ENTITY vt "transitive verb">
+<!ENTITY n "noun (common) (futsuumeishi)">
+<!ENTITY vs "noun or participle which takes the aux. verb suru">
+<!ENTITY adj-na "adjectival nouns or quasi-adjectives (keiyodoshi)">
+<!ENTITY uk "word usually written using kana alone">
+]>
+<JMdict>
+<entry>
+<ent_seq>1358280</ent_seq>
+<k_ele><keb>食べる</keb><ke_pri>ichi1</ke_pri></k_ele>
+<k_ele><keb>喰べる</keb></k_ele>
+<r_ele><reb>たべる</reb><re_pri>ichi1</re_pri></r_ele>
+<sense><pos>&v1;</pos><pos>&vt;</pos><gloss>to eat</gloss></sense>
+<sense><gloss>to live on (e.g. a salary)</gloss><gloss>to live off</gloss></sense>
+</entry>
+<entry>
+<ent_seq>1578850</ent_seq>
+<k_ele><keb>行く</keb></k_ele>
+<r_ele><reb>いく</reb></r_ele>
+<r_ele><reb>ゆく</reb></r_ele>
+<sense><pos>&v5k-s;</pos><pos>&vi;</pos><gloss>to go</gloss></sense>
+</entry>
+<entry>
+<ent_seq>1206730</ent_seq>
+<k_ele><keb>勉強</keb></k_ele>
+<r_ele><reb>べんきょう</reb></r_ele>
+<sense><pos>&n;</pos><pos>&vs;</pos><gloss>study</gloss></sense>
+</entry>
+<entry>
+<ent_seq>1008050</ent_seq>
+<k_ele><keb>綺麗</keb></k_ele>
+<r_ele><reb>きれい</reb></r_ele>
+<sense><pos>&adj-na;</pos><misc>&uk;</misc><gloss>pretty</gloss></sense>
+</entry>
+</JMdict>`
+
+func newTestDictionaryService(t *testing.T) (*DictionaryService, *mockWordRepository) {
+	t.Helper()
+	wordRepo := NewMockWordRepository()
+	service := NewDictionaryService(NewMockTransactor(), NewMockDictionaryRepository(), wordRepo, DefaultLanguagePacks())
+
+	result, err := service.ImportJMdict(context.Background(), strings.NewReader(testJMdict))
+	if err != nil {
+		t.Fatalf("ImportJMdict() error = %v", err)
+	}
+	if result.Entries != 4 {
+		t.Fatalf("ImportJMdict() imported %d entries, want 4", result.Entries)
+	}
+	return service, wordRepo
+}
+
+func TestDictionaryQuery(t *testing.T) {
+	tests := []struct {
+		text string
+		want models.DictionaryQuery
+	}{
+		{"食べ", models.DictionaryQuery{Kanji: "食べ"}},
+		{"たべる", models.DictionaryQuery{Romaji: "taberu"}},
+		{"コーヒー", models.DictionaryQuery{Romaji: "ko-hi-"}},
+		{" eat ", models.DictionaryQuery{Romaji: "eat", English: "eat"}},
+	}
+	for _, tt := range tests {
+		if got := dictionaryQuery(tt.text); got != tt.want {
+			t.Errorf("dictionaryQuery(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDictionaryService_CreateWordFromDictionary(t *testing.T) {
+	tests := []struct {
+		name    string
+		entryID int64
+		params  WordFromDictionaryParams
+		want    models.Word
+	}{
+		{
+			name:    "verb",
+			entryID: 1358280,
+			want: models.Word{Language: "ja", Kanji: "食べる", Reading: "たべる", Romaji: "taberu", English: "to eat",
+				Parts: map[string]any{"jmdict_id": int64(1358280), "verb_type": "ichidan", "pos": []string{"v1", "vt"}}},
+		},
+		{
+			name:    "chosen senses",
+			entryID: 1358280,
+			params:  WordFromDictionaryParams{Kanji: "喰べる", Senses: []int{0, 1}},
+			want: models.Word{Language: "ja", Kanji: "喰べる", Reading: "たべる", Romaji: "taberu", English: "to eat; to live on (e.g. a salary), to live off",
+				Parts: map[string]any{"jmdict_id": int64(1358280), "verb_type": "ichidan", "pos": []string{"v1", "vt"}}},
+		},
+		{
+			name:    "godan with another reading",
+			entryID: 1578850,
+			params:  WordFromDictionaryParams{Reading: "ゆく"},
+			want: models.Word{Language: "ja", Kanji: "行く", Reading: "ゆく", Romaji: "yuku", English: "to go",
+				Parts: map[string]any{"jmdict_id": int64(1578850), "verb_type": "godan", "pos": []string{"v5k-s", "vi"}}},
+		},
+		{
+			name:    "suru noun",
+			entryID: 1206730,
+			want: models.Word{Language: "ja", Kanji: "勉強", Reading: "べんきょう", Romaji: "benkyou", English: "study",
+				Parts: map[string]any{"jmdict_id": int64(1206730), "verb_type": "suru", "pos": []string{"n", "vs"}}},
+		},
+		{
+			name:    "usually kana",
+			entryID: 1008050,
+			want: models.Word{Language: "ja", Kanji: "きれい", Reading: "きれい", Romaji: "kirei", English: "pretty",
+				Parts: map[string]any{"jmdict_id": int64(1008050), "adjective_type": "na-adjective", "pos": []string{"adj-na"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := newTestDictionaryService(t)
+			word, _, err := service.CreateWordFromDictionary(context.Background(), tt.entryID, tt.params)
+			if err != nil {
+				t.Fatalf("CreateWordFromDictionary() error = %v", err)
+			}
+			tt.want.ID = word.ID
+			tt.want.Ruby = word.Ruby
+			// Japanese words sort by their reading
+			tt.want.SortKey = tt.want.Reading
+			if !reflect.DeepEqual(*word, tt.want) {
+				t.Errorf("CreateWordFromDictionary() = %+v, want %+v", *word, tt.want)
+			}
+		})
+	}
+}
+
+func TestDictionaryService_CreateWordFromDictionaryErrors(t *testing.T) {
+	service, _ := newTestDictionaryService(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		entryID int64
+		params  WordFromDictionaryParams
+		wantErr error
+	}{
+		{"unknown entry", 42, WordFromDictionaryParams{}, ErrDictionaryEntryNotFound},
+		{"unknown spelling", 1358280, WordFromDictionaryParams{Kanji: "飲む"}, ErrInvalidDictionaryWord},
+		{"unknown reading", 1358280, WordFromDictionaryParams{Reading: "のむ"}, ErrInvalidDictionaryWord},
+		{"unknown sense", 1358280, WordFromDictionaryParams{Senses: []int{2}}, ErrInvalidDictionaryWord},
+	}
+	for _, tt := range tests {
+		if _, _, err := service.CreateWordFromDictionary(ctx, tt.entryID, tt.params); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: CreateWordFromDictionary() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+
+	if _, _, err := service.CreateWordFromDictionary(ctx, 1358280, WordFromDictionaryParams{}); err != nil {
+		t.Fatalf("CreateWordFromDictionary() error = %v", err)
+	}
+	if _, _, err := service.CreateWordFromDictionary(ctx, 1358280, WordFromDictionaryParams{}); !errors.Is(err, ErrWordExists) {
+		t.Errorf("CreateWordFromDictionary() twice error = %v, want ErrWordExists", err)
+	}
+}
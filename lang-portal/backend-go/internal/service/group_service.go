@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 
@@ -10,13 +11,50 @@ import (
 	"backend-go/internal/responses"
 )
 
+var (
+	// ErrGroupNotFound is returned when a referenced group does not exist
+	ErrGroupNotFound = errors.New("group not found")
+	// ErrGroupExists is returned when creating or renaming a group to the
+	// name of another group
+	ErrGroupExists = errors.New("group already exists")
+	// ErrWordNotInGroup is returned when moving or copying words a group
+	// does not hold
+	ErrWordNotInGroup = errors.New("word not in group")
+	// ErrGroupLanguageMismatch is returned when adding a word to a group of
+	// another language
+	ErrGroupLanguageMismatch = errors.New("word is not in the language of the group")
+	// ErrInvalidGroup is returned for a group or membership change that
+	// fails validation
+	ErrInvalidGroup = errors.New("invalid group")
+	// ErrSmartGroup is returned when adding words to or removing words from
+	// a smart group, whose words are those its filter matches
+	ErrSmartGroup = errors.New("the words of a smart group follow its filter")
+	// ErrGroupHasChildren is returned when deleting a group with groups
+	// under it
+	ErrGroupHasChildren = errors.New("group has groups under it")
+)
+
 type GroupService struct {
-	groupRepo repository.GroupRepository
+	tx           repository.Transactor
+	groupRepo    repository.GroupRepository
+	wordRepo     repository.WordRepository
+	languageRepo repository.LanguageRepository
+	revisionRepo repository.RevisionRepository
 }
 
-func NewGroupService(groupRepo repository.GroupRepository) *GroupService {
+func NewGroupService(
+	tx repository.Transactor,
+	groupRepo repository.GroupRepository,
+	wordRepo repository.WordRepository,
+	languageRepo repository.LanguageRepository,
+	revisionRepo repository.RevisionRepository,
+) *GroupService {
 	return &GroupService{
-		groupRepo: groupRepo,
+		tx:           tx,
+		groupRepo:    groupRepo,
+		wordRepo:     wordRepo,
+		languageRepo: languageRepo,
+		revisionRepo: revisionRepo,
 	}
 }
 
@@ -25,6 +63,8 @@ type ListGroupsParams struct {
 	PageSize int
 	SortBy   string
 	Order    string
+	// Language, when set, keeps the groups of that language
+	Language string
 }
 
 type ListGroupsResult struct {
@@ -42,7 +82,7 @@ func (s *GroupService) ListGroups(ctx context.Context, params ListGroupsParams)
 		params.PageSize = 10
 	}
 
-	groups, total, err := s.groupRepo.List(ctx, params.Page, params.PageSize, params.SortBy, params.Order)
+	groups, total, err := s.groupRepo.List(ctx, params.Page, params.PageSize, params.SortBy, params.Order, params.Language)
 	if err != nil {
 		return nil, fmt.Errorf("error listing groups: %v", err)
 	}
@@ -63,7 +103,7 @@ func (s *GroupService) GetGroup(ctx context.Context, id int64) (*models.Group, e
 		return nil, fmt.Errorf("error getting group: %v", err)
 	}
 	if group == nil {
-		return nil, fmt.Errorf("group not found")
+		return nil, ErrGroupNotFound
 	}
 	return group, nil
 }
@@ -85,41 +125,333 @@ func (s *GroupService) GetGroupWithStats(ctx context.Context, id int64) (*models
 	}, nil
 }
 
+// CreateGroup stores a new, empty group, or a smart group when given a
+// filter. Groups are created in Japanese unless given a language, and names
+// are unique. A group given a parent comes last under it.
 func (s *GroupService) CreateGroup(ctx context.Context, group *models.Group) error {
 	if err := validateGroup(group); err != nil {
 		return err
 	}
-
-	if err := s.groupRepo.Create(ctx, group); err != nil {
-		return fmt.Errorf("error creating group: %v", err)
+	if err := checkLanguage(ctx, s.languageRepo, &group.Language); err != nil {
+		return err
 	}
 
-	return nil
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.checkNameFree(ctx, group.Name, 0); err != nil {
+			return err
+		}
+		if err := s.checkFilter(ctx, group); err != nil {
+			return err
+		}
+		if err := s.checkTree(ctx, group); err != nil {
+			return err
+		}
+		if err := s.groupRepo.Create(ctx, group); err != nil {
+			return fmt.Errorf("error creating group: %v", err)
+		}
+		return nil
+	})
 }
 
+// UpdateGroup renames a group and, given a filter, replaces the filter of
+// a smart group. Its language and words are left as they are; a group with
+// words of its own cannot become a smart group.
 func (s *GroupService) UpdateGroup(ctx context.Context, group *models.Group) error {
 	if err := validateGroup(group); err != nil {
 		return err
 	}
 
-	if err := s.groupRepo.Update(ctx, group); err != nil {
-		return fmt.Errorf("error updating group: %v", err)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.GetGroup(ctx, group.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkNameFree(ctx, group.Name, group.ID); err != nil {
+			return err
+		}
+		if group.Filter == nil {
+			group.Filter = existing.Filter
+		} else if !existing.Smart() {
+			return fmt.Errorf("%w: group %d is not a smart group", ErrInvalidGroup, group.ID)
+		}
+		group.Language, group.ParentID = existing.Language, existing.ParentID
+		if err := s.checkFilter(ctx, group); err != nil {
+			return err
+		}
+		if err := s.checkTree(ctx, group); err != nil {
+			return err
+		}
+		if err := s.groupRepo.Update(ctx, group); err != nil {
+			return fmt.Errorf("error updating group: %v", err)
+		}
+		return nil
+	})
+}
+
+// checkNameFree returns ErrGroupExists when a group other than id is named
+// name
+func (s *GroupService) checkNameFree(ctx context.Context, name string, id int64) error {
+	existing, err := s.groupRepo.GetByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("error getting group: %v", err)
+	}
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("%w: %q", ErrGroupExists, name)
+	}
+	return nil
+}
+
+// checkFilter checks the filter of a smart group and converts its values
+// to what they are compared with. The groups it names must exist and have
+// words of their own, as smart groups do not nest.
+func (s *GroupService) checkFilter(ctx context.Context, group *models.Group) error {
+	if !group.Smart() {
+		return nil
+	}
+	filter, err := normalizeWordFilter(*group.Filter)
+	if err != nil {
+		return err
 	}
 
+	for _, id := range namedGroups(&models.Group{Filter: &filter}) {
+		named, err := s.groupRepo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error getting group: %v", err)
+		}
+		if named == nil {
+			return fmt.Errorf("%w: group %d not found", ErrInvalidFilter, id)
+		}
+		if named.Smart() {
+			return fmt.Errorf("%w: group %d is a smart group", ErrInvalidFilter, id)
+		}
+	}
+
+	group.Filter = &filter
 	return nil
 }
 
-func (s *GroupService) DeleteGroup(ctx context.Context, id int64) error {
-	if err := s.groupRepo.Delete(ctx, id); err != nil {
-		return fmt.Errorf("error deleting group: %v", err)
+// MoveGroup moves a group under another group of its language, or to the
+// top for a nil parentID, at a position among the groups there; a nil
+// position puts it last
+func (s *GroupService) MoveGroup(ctx context.Context, id int64, parentID *int64, position *int) (*models.Group, error) {
+	at := math.MaxInt
+	if position != nil {
+		if *position < 0 {
+			return nil, fmt.Errorf("%w: position must not be negative", ErrInvalidGroup)
+		}
+		at = *position
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.GetGroup(ctx, id)
+		if err != nil {
+			return err
+		}
+		group.ParentID = parentID
+		if err := s.checkTree(ctx, group); err != nil {
+			return err
+		}
+		if err := s.groupRepo.SetParent(ctx, id, parentID, at); err != nil {
+			return fmt.Errorf("error moving group: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetGroup(ctx, id)
+}
+
+// checkTree checks the parent of a group and that the group would not end
+// up among its own words: none of the groups it holds, through the groups
+// under it or those its filter names, may hold it or its parent
+func (s *GroupService) checkTree(ctx context.Context, group *models.Group) error {
+	holders := map[int64]bool{}
+	if group.ID != 0 {
+		holders[group.ID] = true
+	}
+	if group.ParentID != nil {
+		if *group.ParentID == group.ID {
+			return fmt.Errorf("%w: group %q cannot go under itself", ErrInvalidGroup, group.Name)
+		}
+		parent, err := s.groupRepo.GetByID(ctx, *group.ParentID)
+		if err != nil {
+			return fmt.Errorf("error getting group: %v", err)
+		}
+		if parent == nil {
+			return fmt.Errorf("%w: parent %d", ErrGroupNotFound, *group.ParentID)
+		}
+		if parent.Language != group.Language {
+			return fmt.Errorf("%w: group %q is in %q, parent %d in %q", ErrGroupLanguageMismatch, group.Name, group.Language, parent.ID, parent.Language)
+		}
+		holders[parent.ID] = true
+	}
+
+	var held []int64
+	if group.ID != 0 {
+		children, err := s.groupRepo.ListChildren(ctx, group.ID)
+		if err != nil {
+			return fmt.Errorf("error listing child groups: %v", err)
+		}
+		for _, child := range children {
+			held = append(held, child.ID)
+		}
+	}
+	held = append(held, namedGroups(group)...)
+
+	seen := map[int64]bool{}
+	for _, id := range held {
+		cycle, err := s.holdsAny(ctx, id, holders, seen)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return fmt.Errorf("%w: group %q would be part of its own words", ErrInvalidGroup, group.Name)
+		}
+	}
+	return nil
+}
+
+// holdsAny reports whether group id is one of targets or holds one of them
+// through the groups under it or named by its filter
+func (s *GroupService) holdsAny(ctx context.Context, id int64, targets, seen map[int64]bool) (bool, error) {
+	if targets[id] {
+		return true, nil
+	}
+	if seen[id] {
+		return false, nil
+	}
+	seen[id] = true
+
+	group, err := s.groupRepo.GetByID(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("error getting group: %v", err)
+	}
+	if group == nil {
+		return false, nil
+	}
+	children, err := s.groupRepo.ListChildren(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("error listing child groups: %v", err)
+	}
+
+	held := namedGroups(group)
+	for _, child := range children {
+		held = append(held, child.ID)
+	}
+	for _, heldID := range held {
+		found, err := s.holdsAny(ctx, heldID, targets, seen)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// namedGroups returns the groups the filter of a smart group names
+func namedGroups(group *models.Group) []int64 {
+	if !group.Smart() {
+		return nil
+	}
+	var ids []int64
+	if group.Filter.GroupID != 0 {
+		ids = append(ids, group.Filter.GroupID)
+	}
+	return append(ids, group.Filter.ExcludeGroupIDs...)
+}
+
+// checkNotSmart returns ErrSmartGroup for a smart group
+func checkNotSmart(group *models.Group) error {
+	if group.Smart() {
+		return fmt.Errorf("%w: group %d", ErrSmartGroup, group.ID)
 	}
 	return nil
 }
 
+// DeleteGroup moves a group to the trash. Groups with groups under them
+// are not deleted; those are moved or deleted first.
+func (s *GroupService) DeleteGroup(ctx context.Context, id int64) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.GetGroup(ctx, id); err != nil {
+			return err
+		}
+		children, err := s.groupRepo.ListChildren(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error listing child groups: %v", err)
+		}
+		if len(children) > 0 {
+			return fmt.Errorf("%w: group %d has %d", ErrGroupHasChildren, id, len(children))
+		}
+		if err := s.groupRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("error deleting group: %v", err)
+		}
+		return nil
+	})
+}
+
+// GetGroupTree returns a group with its stats and, in order, the groups
+// under it with theirs, all rolled up through the groups under them
+func (s *GroupService) GetGroupTree(ctx context.Context, id int64) (*models.GroupTree, error) {
+	group, err := s.GetGroupWithStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	children, err := s.groupRepo.ListChildren(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error listing child groups: %v", err)
+	}
+
+	tree := &models.GroupTree{GroupWithStats: *group, Children: []*models.GroupTree{}}
+	for _, child := range children {
+		subtree, err := s.GetGroupTree(ctx, child.ID)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, subtree)
+	}
+	return tree, nil
+}
+
+// GetGroupHistory returns a page of the revisions of a group, newest first.
+// The history of a deleted group remains readable.
+func (s *GroupService) GetGroupHistory(ctx context.Context, id int64, page, pageSize int) (*ListRevisionsResult, error) {
+	result, err := listRevisions(ctx, s.revisionRepo, models.RevisionGroup, id, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if result.TotalItems == 0 {
+		if _, err := s.GetGroup(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// RevertGroup restores the name of a group to the one a revision left it
+// with, recorded as a revert. Its words are not part of its history, and
+// the group stays where it is in the tree.
+func (s *GroupService) RevertGroup(ctx context.Context, id, revisionID int64) (*models.Group, error) {
+	if _, err := s.GetGroup(ctx, id); err != nil {
+		return nil, err
+	}
+	group := &models.Group{}
+	ctx, err := loadRevision(ctx, s.revisionRepo, models.RevisionGroup, id, revisionID, group)
+	if err != nil {
+		return nil, err
+	}
+	group.ID = id
+
+	if err := s.UpdateGroup(ctx, group); err != nil {
+		return nil, err
+	}
+	return s.GetGroup(ctx, id)
+}
+
 type ListGroupWordsParams struct {
 	GroupID  int64
 	Page     int
 	PageSize int
+	Filter   models.WordFilter
 }
 
 type ListGroupWordsResult struct {
@@ -137,7 +469,7 @@ func (s *GroupService) ListGroupWords(ctx context.Context, params ListGroupWords
 		params.PageSize = 10
 	}
 
-	words, total, err := s.groupRepo.ListWords(ctx, params.GroupID, params.Page, params.PageSize)
+	words, total, err := s.groupRepo.ListWords(ctx, params.GroupID, params.Page, params.PageSize, params.Filter)
 	if err != nil {
 		return nil, fmt.Errorf("error listing group words: %v", err)
 	}
@@ -166,9 +498,212 @@ func (s *GroupService) RemoveWordFromGroup(ctx context.Context, groupID, wordID
 	return nil
 }
 
+// AddWordsToGroup adds words of the group's language to a group, all or
+// none of them, after its other words. Words already in the group are
+// skipped; the number of words added is returned.
+func (s *GroupService) AddWordsToGroup(ctx context.Context, groupID int64, wordIDs []int64) (int, error) {
+	return s.addWords(ctx, groupID, wordIDs, math.MaxInt)
+}
+
+// InsertWordsIntoGroup adds words to a group like AddWordsToGroup, in the
+// order given at a position among its words, from 0; the words from there
+// on move down. A position past the last adds them last.
+func (s *GroupService) InsertWordsIntoGroup(ctx context.Context, groupID int64, wordIDs []int64, position int) (int, error) {
+	if position < 0 {
+		return 0, fmt.Errorf("%w: position must not be negative", ErrInvalidGroup)
+	}
+	return s.addWords(ctx, groupID, wordIDs, position)
+}
+
+// addWords adds words to a group at a position, or last for one past the
+// last
+func (s *GroupService) addWords(ctx context.Context, groupID int64, wordIDs []int64, position int) (int, error) {
+	if err := validateWordIDs(wordIDs); err != nil {
+		return 0, err
+	}
+
+	var added int
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.GetGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if err := checkNotSmart(group); err != nil {
+			return err
+		}
+		if err := s.checkWords(ctx, group, wordIDs); err != nil {
+			return err
+		}
+
+		added, err = s.groupRepo.AddWords(ctx, groupID, wordIDs, position)
+		if err != nil {
+			return fmt.Errorf("error adding words to group: %v", err)
+		}
+		return nil
+	})
+	return added, err
+}
+
+// RemoveWordsFromGroup removes words from a group. Words not in the group
+// are skipped; the number of words removed is returned.
+func (s *GroupService) RemoveWordsFromGroup(ctx context.Context, groupID int64, wordIDs []int64) (int, error) {
+	if err := validateWordIDs(wordIDs); err != nil {
+		return 0, err
+	}
+
+	var removed int
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.GetGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if err := checkNotSmart(group); err != nil {
+			return err
+		}
+
+		removed, err = s.groupRepo.RemoveWords(ctx, groupID, wordIDs)
+		if err != nil {
+			return fmt.Errorf("error removing words from group: %v", err)
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// ReorderWords puts words of a group first, in the order given, followed
+// by its other words in the order they had. Every word must have been
+// added to the group itself; the number of words given is returned.
+func (s *GroupService) ReorderWords(ctx context.Context, groupID int64, wordIDs []int64) (int, error) {
+	if err := validateWordIDs(wordIDs); err != nil {
+		return 0, err
+	}
+	seen := make(map[int64]bool, len(wordIDs))
+	for _, wordID := range wordIDs {
+		if seen[wordID] {
+			return 0, fmt.Errorf("%w: word %d is listed twice", ErrInvalidGroup, wordID)
+		}
+		seen[wordID] = true
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.GetGroup(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if err := checkNotSmart(group); err != nil {
+			return err
+		}
+
+		reordered, err := s.groupRepo.ReorderWords(ctx, groupID, wordIDs)
+		if err != nil {
+			return fmt.Errorf("error reordering group words: %v", err)
+		}
+		if reordered != len(wordIDs) {
+			return fmt.Errorf("%w: %d of the words are not in group %d", ErrWordNotInGroup, len(wordIDs)-reordered, groupID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(wordIDs), nil
+}
+
+// MoveWords moves words of a group to another group of the same language.
+// Every word must be in the source group, and neither group may be smart.
+// The number of words the target group gained is returned.
+func (s *GroupService) MoveWords(ctx context.Context, sourceID, targetID int64, wordIDs []int64) (int, error) {
+	return s.transferWords(ctx, sourceID, targetID, wordIDs, true)
+}
+
+// CopyWords adds words of a group to another group of the same language,
+// leaving them in the source group. Every word must be in the source group,
+// which may be a smart group to keep a snapshot of what it matches. The
+// number of words the target group gained is returned.
+func (s *GroupService) CopyWords(ctx context.Context, sourceID, targetID int64, wordIDs []int64) (int, error) {
+	return s.transferWords(ctx, sourceID, targetID, wordIDs, false)
+}
+
+func (s *GroupService) transferWords(ctx context.Context, sourceID, targetID int64, wordIDs []int64, move bool) (int, error) {
+	if err := validateWordIDs(wordIDs); err != nil {
+		return 0, err
+	}
+	if sourceID == targetID {
+		return 0, fmt.Errorf("%w: the target group is the source group", ErrInvalidGroup)
+	}
+
+	var added int
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		source, err := s.GetGroup(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		target, err := s.GetGroup(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		if err := checkNotSmart(target); err != nil {
+			return err
+		}
+		if move {
+			if err := checkNotSmart(source); err != nil {
+				return err
+			}
+		}
+		for _, wordID := range wordIDs {
+			member, err := s.groupRepo.HasWord(ctx, sourceID, wordID)
+			if err != nil {
+				return fmt.Errorf("error checking group membership: %v", err)
+			}
+			if !member {
+				return fmt.Errorf("%w: word %d is not in group %d", ErrWordNotInGroup, wordID, sourceID)
+			}
+		}
+		if err := s.checkWords(ctx, target, wordIDs); err != nil {
+			return err
+		}
+
+		added, err = s.groupRepo.AddWords(ctx, targetID, wordIDs, math.MaxInt)
+		if err != nil {
+			return fmt.Errorf("error adding words to group: %v", err)
+		}
+		if move {
+			if _, err := s.groupRepo.RemoveWords(ctx, sourceID, wordIDs); err != nil {
+				return fmt.Errorf("error removing words from group: %v", err)
+			}
+		}
+		return nil
+	})
+	return added, err
+}
+
+// checkWords checks that the words exist and are in the language of group
+func (s *GroupService) checkWords(ctx context.Context, group *models.Group, wordIDs []int64) error {
+	for _, wordID := range wordIDs {
+		word, err := s.wordRepo.GetByID(ctx, wordID)
+		if err != nil {
+			return fmt.Errorf("error getting word: %v", err)
+		}
+		if word == nil {
+			return fmt.Errorf("%w: %d", ErrWordNotFound, wordID)
+		}
+		if word.Language != group.Language {
+			return fmt.Errorf("%w: word %d is in %q, group %d in %q", ErrGroupLanguageMismatch, wordID, word.Language, group.ID, group.Language)
+		}
+	}
+	return nil
+}
+
 func validateGroup(group *models.Group) error {
 	if group.Name == "" {
-		return fmt.Errorf("group name is required")
+		return fmt.Errorf("%w: name is required", ErrInvalidGroup)
+	}
+	return nil
+}
+
+func validateWordIDs(wordIDs []int64) error {
+	if len(wordIDs) == 0 {
+		return fmt.Errorf("%w: word_ids is required", ErrInvalidGroup)
 	}
 	return nil
 }
@@ -179,15 +714,21 @@ type GroupWordsResponse struct {
 }
 
 // GetGroupWords retrieves all words belonging to a specific group with their review statistics
-func (s *GroupService) GetGroupWords(ctx context.Context, groupID int64, page int, sortBy, order string) (*GroupWordsResponse, *responses.Pagination, error) {
+func (s *GroupService) GetGroupWords(ctx context.Context, groupID int64, page int, sortBy, order string, filter models.WordFilter) (*GroupWordsResponse, *responses.Pagination, error) {
 	// Validate group exists
 	group, err := s.groupRepo.GetByID(ctx, groupID)
 	if err != nil {
 		return nil, nil, err
 	}
+	if group == nil {
+		return nil, nil, ErrGroupNotFound
+	}
+	if page < 1 {
+		page = 1
+	}
 
 	// Get paginated words with stats
-	words, total, err := s.groupRepo.GetGroupWords(ctx, groupID, page, sortBy, order)
+	words, total, err := s.groupRepo.GetGroupWords(ctx, groupID, page, sortBy, order, filter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -224,9 +765,9 @@ type GroupStudySessionsResponse struct {
 // GetGroupStudySessions retrieves study sessions for a specific group with performance metrics
 func (s *GroupService) GetGroupStudySessions(ctx context.Context, groupID int64, page, pageSize int) (*GroupStudySessionsResponse, error) {
 	// Validate group exists
-	group, err := s.groupRepo.GetByID(ctx, groupID)
+	group, err := s.GetGroup(ctx, groupID)
 	if err != nil {
-		return nil, fmt.Errorf("error getting group: %v", err)
+		return nil, err
 	}
 
 	// Get paginated study sessions
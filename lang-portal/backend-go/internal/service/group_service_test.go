@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func newTestGroupService(t *testing.T) (*GroupService, *mockGroupRepository) {
+	t.Helper()
+	ctx := context.Background()
+	wordRepo := NewMockWordRepository()
+	for _, word := range []*models.Word{
+		{Kanji: "犬", Romaji: "inu", English: "dog"},
+		{Kanji: "猫", Romaji: "neko", English: "cat"},
+		{Language: "es", Kanji: "perro", English: "dog"},
+	} {
+		wordRepo.Create(ctx, word)
+	}
+	groupRepo := NewMockGroupRepository()
+	groupRepo.Create(ctx, &models.Group{Name: "Animals"})
+	groupRepo.Create(ctx, &models.Group{Name: "Pets"})
+	groupRepo.AddWord(ctx, 1, 1)
+
+	return NewGroupService(NewMockTransactor(), groupRepo, wordRepo, NewMockLanguageRepository(), NewMockRevisionRepository()), groupRepo
+}
+
+func TestGroupService_CreateGroup(t *testing.T) {
+	service, _ := newTestGroupService(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		group   *models.Group
+		wantErr error
+	}{
+		{"new group", &models.Group{Name: "Food"}, nil},
+		{"Spanish group", &models.Group{Name: "Animales", Language: "es"}, nil},
+		{"taken name", &models.Group{Name: "Pets"}, ErrGroupExists},
+		{"missing name", &models.Group{}, ErrInvalidGroup},
+		{"unknown language", &models.Group{Name: "Tiere", Language: "de"}, ErrInvalidLanguage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.CreateGroup(ctx, tt.group)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreateGroup() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroupService_TransferWords(t *testing.T) {
+	service, groupRepo := newTestGroupService(t)
+	ctx := context.Background()
+
+	if _, err := service.AddWordsToGroup(ctx, 1, []int64{3}); !errors.Is(err, ErrGroupLanguageMismatch) {
+		t.Errorf("AddWordsToGroup() of a Spanish word error = %v, want ErrGroupLanguageMismatch", err)
+	}
+	if _, err := service.AddWordsToGroup(ctx, 1, []int64{42}); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("AddWordsToGroup() of an unknown word error = %v, want ErrWordNotFound", err)
+	}
+	if _, err := service.CopyWords(ctx, 1, 2, []int64{2}); !errors.Is(err, ErrWordNotInGroup) {
+		t.Errorf("CopyWords() of a word not in the group error = %v, want ErrWordNotInGroup", err)
+	}
+	if _, err := service.MoveWords(ctx, 1, 42, []int64{1}); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("MoveWords() to an unknown group error = %v, want ErrGroupNotFound", err)
+	}
+
+	moved, err := service.MoveWords(ctx, 1, 2, []int64{1})
+	if err != nil || moved != 1 {
+		t.Fatalf("MoveWords() = %d, %v, want 1", moved, err)
+	}
+	if inSource, _ := groupRepo.HasWord(ctx, 1, 1); inSource {
+		t.Error("MoveWords() left the word in the source group")
+	}
+	if inTarget, _ := groupRepo.HasWord(ctx, 2, 1); !inTarget {
+		t.Error("MoveWords() did not add the word to the target group")
+	}
+}
+
+func TestGroupService_MoveGroup(t *testing.T) {
+	service, groupRepo := newTestGroupService(t)
+	ctx := context.Background()
+	groupRepo.Create(ctx, &models.Group{Name: "Animales", Language: "es"})
+
+	animals, pets, spanish := int64(1), int64(2), int64(3)
+	if _, err := service.MoveGroup(ctx, pets, &animals, nil); err != nil {
+		t.Fatalf("MoveGroup() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		id       int64
+		parentID *int64
+		wantErr  error
+	}{
+		{"under itself", animals, &animals, ErrInvalidGroup},
+		{"under a group it holds", animals, &pets, ErrInvalidGroup},
+		{"under a group of another language", pets, &spanish, ErrGroupLanguageMismatch},
+		{"unknown group", 42, nil, ErrGroupNotFound},
+		{"to the top", pets, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.MoveGroup(ctx, tt.id, tt.parentID, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("MoveGroup() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
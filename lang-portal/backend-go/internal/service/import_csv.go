@@ -0,0 +1,397 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+const (
+	// csvExportPageSize is how many group words are read per query while exporting
+	csvExportPageSize = 500
+	// maxCSVPreviewRows caps the parsed rows returned by a dry run
+	maxCSVPreviewRows = 1000
+)
+
+// errDryRun rolls back the transaction of a dry-run import
+var errDryRun = errors.New("dry run")
+
+// CSVColumnMapping tells which header columns hold the word fields. Columns
+// named in Parts are stored in parts under their key; when Parts is empty
+// every unmapped column is stored under its header name.
+type CSVColumnMapping struct {
+	Kanji   string
+	Romaji  string
+	English string
+	Parts   []CSVPartsColumn
+}
+
+type CSVPartsColumn struct {
+	Column string
+	Key    string
+}
+
+// DefaultCSVColumnMapping matches the header written by ExportGroupCSV
+func DefaultCSVColumnMapping() CSVColumnMapping {
+	return CSVColumnMapping{Kanji: "kanji", Romaji: "romaji", English: "english"}
+}
+
+type ImportCSVParams struct {
+	Reader    io.Reader
+	Delimiter rune
+	Mapping   CSVColumnMapping
+	// GroupID, when set, adds every imported word to that group
+	GroupID int64
+	// DryRun parses and checks every row, then rolls everything back
+	DryRun bool
+}
+
+// ImportCSVRow is one parsed data row. Line is the line number in the file.
+type ImportCSVRow struct {
+	Line         int            `json:"line"`
+	Kanji        string         `json:"kanji"`
+	Romaji       string         `json:"romaji"`
+	English      string         `json:"english"`
+	Parts        map[string]any `json:"parts,omitempty"`
+	Status       string         `json:"status"`
+	WordID       int64          `json:"word_id,omitempty"`
+	AddedToGroup bool           `json:"added_to_group"`
+	Warning      string         `json:"warning,omitempty"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// ImportCSVResult summarizes a CSV import. Rows holds every parsed row for a
+// dry run (up to a limit) and only the invalid rows otherwise.
+type ImportCSVResult struct {
+	DryRun        bool           `json:"dry_run"`
+	GroupID       int64          `json:"group_id,omitempty"`
+	TotalRows     int            `json:"total_rows"`
+	Created       int            `json:"created"`
+	Existing      int            `json:"existing"`
+	Invalid       int            `json:"invalid"`
+	AddedToGroup  int            `json:"added_to_group"`
+	Rows          []ImportCSVRow `json:"rows"`
+	RowsTruncated bool           `json:"rows_truncated,omitempty"`
+}
+
+// csvColumns is a mapping resolved against a header row
+type csvColumns struct {
+	kanji, romaji, english int
+	parts                  []csvPartsIndex
+}
+
+type csvPartsIndex struct {
+	index int
+	key   string
+}
+
+// ImportCSV reads words from a CSV or TSV stream one row at a time, creating
+// new words and reusing existing ones with the same kanji and romaji. The
+// whole file is written in a single transaction; invalid rows are reported
+// and skipped.
+func (s *ImportService) ImportCSV(ctx context.Context, params ImportCSVParams) (*ImportCSVResult, error) {
+	reader := csv.NewReader(params.Reader)
+	if params.Delimiter != 0 {
+		reader.Comma = params.Delimiter
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: error reading header: %v", ErrInvalidImport, err)
+	}
+	columns, err := resolveCSVColumns(header, params.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportCSVResult{DryRun: params.DryRun, GroupID: params.GroupID, Rows: []ImportCSVRow{}}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Words imported into a group are in the group's language
+		language := models.DefaultLanguage
+		if params.GroupID != 0 {
+			group, err := s.groupRepo.GetByID(ctx, params.GroupID)
+			if err != nil {
+				return fmt.Errorf("error getting group: %v", err)
+			}
+			if group == nil {
+				return ErrGroupNotFound
+			}
+			if err := checkNotSmart(group); err != nil {
+				return err
+			}
+			language = group.Language
+		}
+
+		for {
+			record, readErr := reader.Read()
+			if readErr == io.EOF {
+				break
+			}
+			var parseErr *csv.ParseError
+			if readErr != nil && !(errors.As(readErr, &parseErr) && parseErr.Err == csv.ErrFieldCount) {
+				return fmt.Errorf("%w: %v", ErrInvalidImport, readErr)
+			}
+			line, _ := reader.FieldPos(0)
+
+			result.TotalRows++
+			row := ImportCSVRow{Line: line}
+			if readErr != nil {
+				row.Error = fmt.Sprintf("expected %d columns, got %d", len(header), len(record))
+			} else if err := s.importCSVRecord(ctx, record, columns, params.GroupID, language, &row); err != nil {
+				return err
+			}
+
+			switch row.Status {
+			case ImportStatusCreated:
+				result.Created++
+			case ImportStatusExisting:
+				result.Existing++
+			default:
+				row.Status = ImportStatusInvalid
+				result.Invalid++
+			}
+			if row.AddedToGroup {
+				result.AddedToGroup++
+			}
+
+			if params.DryRun || row.Status == ImportStatusInvalid {
+				if len(result.Rows) < maxCSVPreviewRows {
+					result.Rows = append(result.Rows, row)
+				} else {
+					result.RowsTruncated = true
+				}
+			}
+		}
+
+		if params.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// importCSVRecord fills row from record and writes the word. Validation
+// problems are reported on the row; only storage errors are returned.
+func (s *ImportService) importCSVRecord(ctx context.Context, record []string, columns csvColumns, groupID int64, language string, row *ImportCSVRow) error {
+	row.Kanji = strings.TrimSpace(record[columns.kanji])
+	row.Romaji = strings.TrimSpace(record[columns.romaji])
+	row.English = strings.TrimSpace(record[columns.english])
+	row.Parts = make(map[string]any)
+	for _, column := range columns.parts {
+		if value := strings.TrimSpace(record[column.index]); value != "" {
+			row.Parts[column.key] = csvPartsValue(value)
+		}
+	}
+
+	word := &models.Word{Language: language, Kanji: row.Kanji, Romaji: row.Romaji, English: row.English, Parts: row.Parts}
+	warning, err := s.packs.PrepareWord(word)
+	if err != nil {
+		row.Error = err.Error()
+		return nil
+	}
+	row.Romaji = word.Romaji
+	row.Warning = warning
+
+	created, err := findOrCreateWord(ctx, s.wordRepo, word)
+	if err != nil {
+		return err
+	}
+	row.WordID = word.ID
+	row.Status = ImportStatusExisting
+	if created {
+		row.Status = ImportStatusCreated
+	}
+
+	if groupID != 0 {
+		if row.AddedToGroup, err = addToGroup(ctx, s.groupRepo, groupID, word.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportGroupCSV writes the words of a group as CSV (or TSV with a tab
+// delimiter): kanji, romaji, english, then one column per parts key. Words
+// are read a page at a time, once to collect the parts keys and once to
+// write the rows.
+func (s *ImportService) ExportGroupCSV(ctx context.Context, groupID int64, delimiter rune, w io.Writer) error {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("error getting group: %v", err)
+	}
+	if group == nil {
+		return ErrGroupNotFound
+	}
+
+	keySet := make(map[string]bool)
+	err = eachGroupWordPage(ctx, s.groupRepo, groupID, func(words []*models.WordWithStats) error {
+		for _, word := range words {
+			for key := range word.Parts {
+				keySet[key] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	writer := csv.NewWriter(w)
+	if delimiter != 0 {
+		writer.Comma = delimiter
+	}
+	if err := writer.Write(append([]string{"kanji", "romaji", "english"}, keys...)); err != nil {
+		return fmt.Errorf("error writing header: %v", err)
+	}
+
+	record := make([]string, 3+len(keys))
+	err = eachGroupWordPage(ctx, s.groupRepo, groupID, func(words []*models.WordWithStats) error {
+		for _, word := range words {
+			record[0], record[1], record[2] = word.Kanji, word.Romaji, word.English
+			for i, key := range keys {
+				record[3+i] = csvCellValue(word.Parts[key])
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("error writing word %d: %v", word.ID, err)
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// eachGroupWordPage calls fn with the words of a group, one page at a time
+func eachGroupWordPage(ctx context.Context, groupRepo repository.GroupRepository, groupID int64, fn func(words []*models.WordWithStats) error) error {
+	for page := 1; ; page++ {
+		words, total, err := groupRepo.ListWords(ctx, groupID, page, csvExportPageSize, models.WordFilter{})
+		if err != nil {
+			return fmt.Errorf("error listing group words: %v", err)
+		}
+		if err := fn(words); err != nil {
+			return err
+		}
+		if len(words) == 0 || page*csvExportPageSize >= total {
+			return nil
+		}
+	}
+}
+
+// resolveCSVColumns finds the mapped columns in the header. Names match
+// case-insensitively and a UTF-8 byte order mark on the first cell is ignored.
+func resolveCSVColumns(header []string, mapping CSVColumnMapping) (csvColumns, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		header[i] = strings.TrimSpace(name)
+		key := strings.ToLower(header[i])
+		if _, dup := index[key]; !dup {
+			index[key] = i
+		}
+	}
+
+	find := func(field, name string) (int, error) {
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return 0, fmt.Errorf("%w: no %q column for %s in the header", ErrInvalidImport, name, field)
+		}
+		return i, nil
+	}
+
+	var columns csvColumns
+	var err error
+	if columns.kanji, err = find("kanji", mapping.Kanji); err != nil {
+		return columns, err
+	}
+	if columns.romaji, err = find("romaji", mapping.Romaji); err != nil {
+		return columns, err
+	}
+	if columns.english, err = find("english", mapping.English); err != nil {
+		return columns, err
+	}
+
+	if len(mapping.Parts) > 0 {
+		for _, part := range mapping.Parts {
+			i, err := find("parts", part.Column)
+			if err != nil {
+				return columns, err
+			}
+			key := part.Key
+			if key == "" {
+				key = header[i]
+			}
+			columns.parts = append(columns.parts, csvPartsIndex{index: i, key: key})
+		}
+		return columns, nil
+	}
+
+	for i, name := range header {
+		if i == columns.kanji || i == columns.romaji || i == columns.english || name == "" {
+			continue
+		}
+		columns.parts = append(columns.parts, csvPartsIndex{index: i, key: name})
+	}
+	return columns, nil
+}
+
+// csvPartsValue decodes cells holding a JSON object or array, as written by
+// ExportGroupCSV for structured parts, and keeps everything else as text
+func csvPartsValue(cell string) any {
+	if strings.HasPrefix(cell, "{") || strings.HasPrefix(cell, "[") {
+		var value any
+		if err := json.Unmarshal([]byte(cell), &value); err == nil {
+			return value
+		}
+	}
+	return cell
+}
+
+func csvCellValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestImportService_ImportCSV(t *testing.T) {
+	service, wordRepo, groupRepo, groupID := newTestImportService(t)
+	ctx := context.Background()
+
+	file := "\ufeffWord\tReading\tMeaning\tTopic\tComponents\n" +
+		"猫\tneko\tcat\tanimals\t\n" +
+		"勉強\tbenkyou\tstudy\t\t\"[{\"\"kanji\"\":\"\"勉\"\",\"\"romaji\"\":\"\"ben\"\"}]\"\n" +
+		"犬\tinu\t\tanimals\t\n" +
+		"猫\tneko\tcat\n"
+
+	result, err := service.ImportCSV(ctx, ImportCSVParams{
+		Reader:    strings.NewReader(file),
+		Delimiter: '\t',
+		Mapping: CSVColumnMapping{
+			Kanji:   "word",
+			Romaji:  "reading",
+			English: "meaning",
+		},
+		GroupID: groupID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to import CSV: %v", err)
+	}
+
+	if result.TotalRows != 4 || result.Created != 2 || result.Invalid != 2 || result.AddedToGroup != 2 {
+		t.Errorf("Unexpected totals: %+v", result)
+	}
+	if len(result.Rows) != 2 || result.Rows[0].Line != 4 || result.Rows[1].Line != 5 {
+		t.Errorf("Expected the invalid rows on lines 4 and 5, got %+v", result.Rows)
+	}
+
+	word, _ := wordRepo.GetByKanjiRomaji(ctx, "勉強", "benkyou", "")
+	if word == nil {
+		t.Fatal("Expected 勉強 to be created")
+	}
+	if _, ok := word.Parts["Components"].([]any); !ok {
+		t.Errorf("Expected JSON cell to be decoded into parts, got %v", word.Parts)
+	}
+	if _, ok := word.Parts["Topic"]; ok {
+		t.Error("Expected empty cells to be left out of parts")
+	}
+	if member, _ := groupRepo.HasWord(ctx, groupID, word.ID); !member {
+		t.Error("Expected imported word to be added to the group")
+	}
+}
+
+func TestImportService_ImportCSVPartsMapping(t *testing.T) {
+	service, _, _, _ := newTestImportService(t)
+	ctx := context.Background()
+
+	file := "kanji,romaji,english,Topic,Notes\n猫,neko,cat,animals,ignored\n"
+	result, err := service.ImportCSV(ctx, ImportCSVParams{
+		Reader: strings.NewReader(file),
+		Mapping: CSVColumnMapping{
+			Kanji:   "kanji",
+			Romaji:  "romaji",
+			English: "english",
+			Parts:   []CSVPartsColumn{{Column: "topic", Key: "topic"}},
+		},
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to import CSV: %v", err)
+	}
+
+	if !result.DryRun || len(result.Rows) != 1 {
+		t.Fatalf("Expected the parsed row in a dry run, got %+v", result)
+	}
+	if parts := result.Rows[0].Parts; len(parts) != 1 || parts["topic"] != "animals" {
+		t.Errorf("Expected only the mapped parts column, got %v", parts)
+	}
+}
+
+func TestImportService_ImportCSVErrors(t *testing.T) {
+	service, _, _, _ := newTestImportService(t)
+	ctx := context.Background()
+
+	tests := map[string]string{
+		"empty file":     "",
+		"missing column": "kanji,english\n猫,cat\n",
+		"bad quoting":    "kanji,romaji,english\n\"猫,neko,cat\n",
+	}
+	for name, file := range tests {
+		_, err := service.ImportCSV(ctx, ImportCSVParams{
+			Reader:  strings.NewReader(file),
+			Mapping: DefaultCSVColumnMapping(),
+		})
+		if !errors.Is(err, ErrInvalidImport) {
+			t.Errorf("%s: expected ErrInvalidImport, got %v", name, err)
+		}
+	}
+
+	_, err := service.ImportCSV(ctx, ImportCSVParams{
+		Reader:  strings.NewReader("kanji,romaji,english\n猫,neko,cat\n"),
+		Mapping: DefaultCSVColumnMapping(),
+		GroupID: 999,
+	})
+	if !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("Expected ErrGroupNotFound, got %v", err)
+	}
+}
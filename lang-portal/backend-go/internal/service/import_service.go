@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+// ErrInvalidImport is returned when an import request as a whole is unusable
+var ErrInvalidImport = errors.New("invalid import")
+
+// maxImportWords caps the number of words accepted by a single import
+const maxImportWords = 1000
+
+// Statuses of an imported item
+const (
+	ImportStatusCreated  = "created"
+	ImportStatusExisting = "existing"
+	ImportStatusInvalid  = "invalid"
+)
+
+// ImportWord is one entry of a vocab-importer word list. Parts is either the
+// importer's array of {kanji, romaji} components or an object like the one
+// stored on words.
+type ImportWord struct {
+	Kanji   string          `json:"kanji"`
+	Romaji  string          `json:"romaji"`
+	English string          `json:"english"`
+	Parts   json.RawMessage `json:"parts"`
+}
+
+// ImportItemResult reports what happened to one entry of an import
+type ImportItemResult struct {
+	Index        int    `json:"index"`
+	Kanji        string `json:"kanji"`
+	Romaji       string `json:"romaji"`
+	Status       string `json:"status"`
+	WordID       int64  `json:"word_id,omitempty"`
+	AddedToGroup bool   `json:"added_to_group"`
+	Warning      string `json:"warning,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+type ImportWordsResult struct {
+	GroupID      int64              `json:"group_id"`
+	Created      int                `json:"created"`
+	Existing     int                `json:"existing"`
+	Invalid      int                `json:"invalid"`
+	AddedToGroup int                `json:"added_to_group"`
+	Items        []ImportItemResult `json:"items"`
+}
+
+// ImportService brings word lists from outside tools into groups
+type ImportService struct {
+	tx        repository.Transactor
+	wordRepo  repository.WordRepository
+	groupRepo repository.GroupRepository
+	packs     *LanguagePacks
+}
+
+func NewImportService(
+	tx repository.Transactor,
+	wordRepo repository.WordRepository,
+	groupRepo repository.GroupRepository,
+	packs *LanguagePacks,
+) *ImportService {
+	return &ImportService{
+		tx:        tx,
+		wordRepo:  wordRepo,
+		groupRepo: groupRepo,
+		packs:     packs,
+	}
+}
+
+// ImportGroupWords creates the words of a vocab-importer list, reusing words
+// that already exist with the same kanji and romaji, and adds them all to the
+// group. Invalid entries are reported and skipped; every valid entry is
+// written in a single transaction.
+func (s *ImportService) ImportGroupWords(ctx context.Context, groupID int64, words []ImportWord) (*ImportWordsResult, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%w: no words to import", ErrInvalidImport)
+	}
+	if len(words) > maxImportWords {
+		return nil, fmt.Errorf("%w: at most %d words can be imported at once", ErrInvalidImport, maxImportWords)
+	}
+
+	result := &ImportWordsResult{GroupID: groupID, Items: make([]ImportItemResult, len(words))}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		group, err := s.groupRepo.GetByID(ctx, groupID)
+		if err != nil {
+			return fmt.Errorf("error getting group: %v", err)
+		}
+		if group == nil {
+			return ErrGroupNotFound
+		}
+		if err := checkNotSmart(group); err != nil {
+			return err
+		}
+
+		for i, entry := range words {
+			item := &result.Items[i]
+			*item = ImportItemResult{Index: i, Kanji: entry.Kanji, Romaji: entry.Romaji}
+
+			word := &models.Word{Language: group.Language, Kanji: entry.Kanji, Romaji: entry.Romaji, English: entry.English}
+			word.Parts, err = partsFromImport(entry.Parts)
+			if err == nil {
+				item.Warning, err = s.packs.PrepareWord(word)
+			}
+			if err != nil {
+				item.Status = ImportStatusInvalid
+				item.Error = err.Error()
+				result.Invalid++
+				continue
+			}
+
+			created, err := findOrCreateWord(ctx, s.wordRepo, word)
+			if err != nil {
+				return err
+			}
+			item.Romaji = word.Romaji
+			item.WordID = word.ID
+			if created {
+				item.Status = ImportStatusCreated
+				result.Created++
+			} else {
+				item.Status = ImportStatusExisting
+				result.Existing++
+			}
+
+			added, err := addToGroup(ctx, s.groupRepo, groupID, word.ID)
+			if err != nil {
+				return err
+			}
+			if added {
+				item.AddedToGroup = true
+				result.AddedToGroup++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// findOrCreateWord sets word.ID to the existing word of the same language
+// with the same kanji and romaji, or creates the word. It reports whether the
+// word was created.
+func findOrCreateWord(ctx context.Context, wordRepo repository.WordRepository, word *models.Word) (bool, error) {
+	existing, err := wordRepo.GetByKanjiRomaji(ctx, word.Kanji, word.Romaji, word.Language)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		word.ID = existing.ID
+		return false, nil
+	}
+
+	if err := wordRepo.Create(ctx, word); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// addToGroup adds the word to the group unless it is already a member, and
+// reports whether it was added
+func addToGroup(ctx context.Context, groupRepo repository.GroupRepository, groupID, wordID int64) (bool, error) {
+	member, err := groupRepo.HasWord(ctx, groupID, wordID)
+	if err != nil {
+		return false, err
+	}
+	if member {
+		return false, nil
+	}
+
+	if err := groupRepo.AddWord(ctx, groupID, wordID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// partsFromImport converts the parts of an imported word into the object
+// stored on words. The vocab-importer's component array is kept under
+// "components".
+func partsFromImport(raw json.RawMessage) (map[string]any, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("invalid parts: %v", err)
+	}
+
+	switch parts := value.(type) {
+	case map[string]any:
+		return parts, nil
+	case []any:
+		return map[string]any{"components": parts}, nil
+	default:
+		return nil, fmt.Errorf("parts must be an object or an array")
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func newTestImportService(t *testing.T) (*ImportService, *mockWordRepository, *mockGroupRepository, int64) {
+	wordRepo := NewMockWordRepository()
+	groupRepo := NewMockGroupRepository()
+
+	group := &models.Group{Name: "Vocab"}
+	if err := groupRepo.Create(context.Background(), group); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+
+	return NewImportService(NewMockTransactor(), wordRepo, groupRepo, DefaultLanguagePacks()), wordRepo, groupRepo, group.ID
+}
+
+func TestImportService_ImportGroupWords(t *testing.T) {
+	service, wordRepo, groupRepo, groupID := newTestImportService(t)
+	ctx := context.Background()
+
+	existing := &models.Word{Kanji: "旅行", Romaji: "ryokou", English: "travel", Parts: map[string]any{}}
+	wordRepo.Create(ctx, existing)
+
+	var words []ImportWord
+	err := json.Unmarshal([]byte(`[
+		{"kanji": "勉強", "romaji": "benkyou", "english": "study", "parts": [{"kanji": "勉", "romaji": "ben"}, {"kanji": "強", "romaji": "kyou"}]},
+		{"kanji": "旅行", "romaji": "ryokou", "english": "travel", "parts": []},
+		{"kanji": "勉強", "romaji": "benkyou", "english": "study", "parts": []},
+		{"kanji": "食事", "romaji": "shokuji", "parts": []},
+		{"kanji": "運動", "romaji": "undou", "english": "exercise", "parts": "運動"}
+	]`), &words)
+	if err != nil {
+		t.Fatalf("Failed to parse import: %v", err)
+	}
+
+	result, err := service.ImportGroupWords(ctx, groupID, words)
+	if err != nil {
+		t.Fatalf("Failed to import words: %v", err)
+	}
+
+	if result.Created != 1 || result.Existing != 2 || result.Invalid != 2 || result.AddedToGroup != 2 {
+		t.Errorf("Unexpected totals: %+v", result)
+	}
+
+	wantStatuses := []string{ImportStatusCreated, ImportStatusExisting, ImportStatusExisting, ImportStatusInvalid, ImportStatusInvalid}
+	for i, want := range wantStatuses {
+		if result.Items[i].Status != want {
+			t.Errorf("Item %d: expected status %s, got %s (%s)", i, want, result.Items[i].Status, result.Items[i].Error)
+		}
+	}
+	if result.Items[3].Error == "" {
+		t.Error("Expected a validation error for the word without english")
+	}
+
+	created, _ := wordRepo.GetByID(ctx, result.Items[0].WordID)
+	if components, ok := created.Parts["components"].([]any); !ok || len(components) != 2 {
+		t.Errorf("Expected importer parts stored as components, got %v", created.Parts)
+	}
+	if member, _ := groupRepo.HasWord(ctx, groupID, existing.ID); !member {
+		t.Error("Expected existing word to be added to the group")
+	}
+}
+
+func TestImportService_ImportGroupWordsErrors(t *testing.T) {
+	service, _, _, groupID := newTestImportService(t)
+	ctx := context.Background()
+
+	if _, err := service.ImportGroupWords(ctx, groupID, nil); !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("Expected ErrInvalidImport for empty import, got %v", err)
+	}
+
+	words := []ImportWord{{Kanji: "勉強", Romaji: "benkyou", English: "study", Parts: json.RawMessage(`[]`)}}
+	if _, err := service.ImportGroupWords(ctx, 999, words); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("Expected ErrGroupNotFound, got %v", err)
+	}
+}
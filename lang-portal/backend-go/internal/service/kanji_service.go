@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/kanjidic"
+	"backend-go/internal/repository"
+)
+
+var (
+	// ErrKanjiNotFound is returned for a kanji missing from the dictionary
+	ErrKanjiNotFound = errors.New("kanji not found")
+	// ErrInvalidKanji is returned when a lookup is not a single kanji
+	ErrInvalidKanji = errors.New("not a single kanji")
+)
+
+// KanjiService looks up the kanji dictionary and the words written with each
+// kanji
+type KanjiService struct {
+	tx        repository.Transactor
+	kanjiRepo repository.KanjiRepository
+	wordRepo  repository.WordRepository
+}
+
+func NewKanjiService(tx repository.Transactor, kanjiRepo repository.KanjiRepository, wordRepo repository.WordRepository) *KanjiService {
+	return &KanjiService{
+		tx:        tx,
+		kanjiRepo: kanjiRepo,
+		wordRepo:  wordRepo,
+	}
+}
+
+type KanjiImportResult struct {
+	Characters int `json:"characters"`
+}
+
+// ImportKanjidic loads a KANJIDIC2 XML file into the kanji dictionary,
+// replacing the entries of characters already there. The file is streamed
+// and written in a single transaction.
+func (s *KanjiService) ImportKanjidic(ctx context.Context, r io.Reader) (*KanjiImportResult, error) {
+	result := &KanjiImportResult{}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return kanjidic.Read(r, func(c *kanjidic.Character) error {
+			kanji := &models.Kanji{
+				Character:   c.Literal,
+				Grade:       c.Grade,
+				StrokeCount: c.StrokeCount,
+				Frequency:   c.Frequency,
+				JLPT:        c.JLPT,
+				OnReadings:  c.OnReadings,
+				KunReadings: c.KunReadings,
+				Nanori:      c.Nanori,
+				Meanings:    c.Meanings,
+			}
+			if err := s.kanjiRepo.Upsert(ctx, kanji); err != nil {
+				return err
+			}
+			result.Characters++
+			return nil
+		})
+	})
+	if errors.Is(err, kanjidic.ErrInvalidFile) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type KanjiResult struct {
+	Kanji *models.Kanji
+	// Words are a page of the words written with the kanji
+	Words       []*models.WordWithStats
+	TotalItems  int
+	CurrentPage int
+	TotalPages  int
+}
+
+// GetKanji returns a kanji of the dictionary with a page of the words
+// written with it, in kanji order
+func (s *KanjiService) GetKanji(ctx context.Context, character string, page, pageSize int) (*KanjiResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if r, size := utf8.DecodeRuneInString(character); size != len(character) || !kanjidic.IsKanji(r) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKanji, character)
+	}
+
+	kanji, err := s.kanjiRepo.GetByCharacter(ctx, character)
+	if err != nil {
+		return nil, fmt.Errorf("error getting kanji: %v", err)
+	}
+	if kanji == nil {
+		return nil, ErrKanjiNotFound
+	}
+
+	words, total, err := s.wordRepo.List(ctx, page, pageSize, "kanji", "asc", models.WordFilter{Character: character})
+	if err != nil {
+		return nil, fmt.Errorf("error listing kanji words: %v", err)
+	}
+
+	return &KanjiResult{
+		Kanji:       kanji,
+		Words:       words,
+		TotalItems:  total,
+		CurrentPage: page,
+		TotalPages:  (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// ListWordKanji returns the kanji a word is written with, in order. Kanji
+// missing from the dictionary only have their character.
+func (s *KanjiService) ListWordKanji(ctx context.Context, wordID int64) ([]*models.Kanji, error) {
+	word, err := s.wordRepo.GetByID(ctx, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting word: %v", err)
+	}
+	if word == nil {
+		return nil, ErrWordNotFound
+	}
+
+	characters := kanjidic.Characters(word.Kanji)
+	entries, err := s.kanjiRepo.ListByCharacters(ctx, characters)
+	if err != nil {
+		return nil, fmt.Errorf("error getting word kanji: %v", err)
+	}
+
+	kanji := make([]*models.Kanji, len(characters))
+	for i, character := range characters {
+		if kanji[i] = entries[character]; kanji[i] == nil {
+			kanji[i] = &models.Kanji{Character: character}
+		}
+	}
+	return kanji, nil
+}
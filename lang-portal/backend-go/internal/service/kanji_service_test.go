@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+const testKanjidic = `<?xml version="1.0" encoding="UTF-8"?>
+<kanjidic2>
+<header><file_version>4</file_version></header>
+<character>
+<literal>食</literal>
+<misc><grade>2</grade><stroke_count>9</stroke_count><freq>328</freq><jlpt>4</jlpt></misc>
+<reading_meaning><rmgroup>
+<reading r_type="ja_on">ショク</reading>
+<reading r_type="ja_kun">た.べる</reading>
+<meaning>eat</meaning>
+</rmgroup></reading_meaning>
+</character>
+<character>
+<literal>物</literal>
+<misc><grade>3</grade><stroke_count>8</stroke_count></misc>
+</character>
+</kanjidic2>`
+
+func newTestKanjiService(t *testing.T) (*KanjiService, *mockWordRepository) {
+	t.Helper()
+	wordRepo := NewMockWordRepository()
+	service := NewKanjiService(NewMockTransactor(), NewMockKanjiRepository(), wordRepo)
+
+	for _, word := range []*models.Word{
+		{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{}},
+		{Kanji: "食べ物", Romaji: "tabemono", English: "food", Parts: map[string]any{}},
+		{Kanji: "飲む", Romaji: "nomu", English: "to drink", Parts: map[string]any{}},
+	} {
+		if err := wordRepo.Create(context.Background(), word); err != nil {
+			t.Fatalf("Failed to create test word: %v", err)
+		}
+	}
+
+	result, err := service.ImportKanjidic(context.Background(), strings.NewReader(testKanjidic))
+	if err != nil {
+		t.Fatalf("ImportKanjidic() error = %v", err)
+	}
+	if result.Characters != 2 {
+		t.Fatalf("ImportKanjidic() imported %d kanji, want 2", result.Characters)
+	}
+	return service, wordRepo
+}
+
+func TestKanjiService_ImportKanjidicRejectsOtherFiles(t *testing.T) {
+	service := NewKanjiService(NewMockTransactor(), NewMockKanjiRepository(), NewMockWordRepository())
+	_, err := service.ImportKanjidic(context.Background(), strings.NewReader(`<JMdict></JMdict>`))
+	if !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("ImportKanjidic() error = %v, want ErrInvalidImport", err)
+	}
+}
+
+func TestKanjiService_GetKanji(t *testing.T) {
+	service, _ := newTestKanjiService(t)
+	ctx := context.Background()
+
+	result, err := service.GetKanji(ctx, "食", 1, 10)
+	if err != nil {
+		t.Fatalf("GetKanji() error = %v", err)
+	}
+	if result.Kanji.StrokeCount != 9 || len(result.Kanji.KunReadings) != 1 || result.TotalItems != 2 {
+		t.Errorf("GetKanji() = %+v with %d words, want 食 with 2 words", result.Kanji, result.TotalItems)
+	}
+
+	tests := []struct {
+		character string
+		wantErr   error
+	}{
+		{"飲", ErrKanjiNotFound},
+		{"た", ErrInvalidKanji},
+		{"食物", ErrInvalidKanji},
+		{"", ErrInvalidKanji},
+	}
+	for _, tt := range tests {
+		if _, err := service.GetKanji(ctx, tt.character, 1, 10); !errors.Is(err, tt.wantErr) {
+			t.Errorf("GetKanji(%q) error = %v, want %v", tt.character, err, tt.wantErr)
+		}
+	}
+}
+
+func TestKanjiService_ListWordKanji(t *testing.T) {
+	service, _ := newTestKanjiService(t)
+	ctx := context.Background()
+
+	kanji, err := service.ListWordKanji(ctx, 2)
+	if err != nil {
+		t.Fatalf("ListWordKanji() error = %v", err)
+	}
+	if len(kanji) != 2 || kanji[0].Character != "食" || kanji[1].Character != "物" || kanji[1].StrokeCount != 8 {
+		t.Errorf("ListWordKanji() = %+v, want 食 and 物", kanji)
+	}
+
+	// 飲 is not in the dictionary
+	kanji, err = service.ListWordKanji(ctx, 3)
+	if err != nil {
+		t.Fatalf("ListWordKanji() error = %v", err)
+	}
+	if len(kanji) != 1 || kanji[0].Character != "飲" || kanji[0].StrokeCount != 0 {
+		t.Errorf("ListWordKanji() = %+v, want only the character 飲", kanji)
+	}
+
+	if _, err := service.ListWordKanji(ctx, 42); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("ListWordKanji() of an unknown word error = %v, want ErrWordNotFound", err)
+	}
+}
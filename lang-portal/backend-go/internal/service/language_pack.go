@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"backend-go/internal/domain/models"
+)
+
+// LanguagePack holds the behaviour that depends on the language of a word:
+// how it is validated and romanized, how it is conjugated, how answers are
+// compared and how words sort.
+type LanguagePack interface {
+	// Code is the languages.code of the language
+	Code() string
+	// PrepareWord trims, completes and validates a word before it is
+	// stored. The returned warning reports a problem that does not stop the
+	// word from being stored.
+	PrepareWord(word *models.Word) (string, error)
+	// Conjugate returns the class of a word and its forms, the dictionary
+	// form first. Words the language cannot conjugate return
+	// ErrNotConjugable.
+	Conjugate(word *models.Word) (string, []models.Conjugation, error)
+	// Forms returns the name of every form Conjugate may return
+	Forms() []string
+	// CheckAnswer reports whether a typed answer spells the expected form,
+	// after the normalization the language allows (case, accents, kana or
+	// romaji)
+	CheckAnswer(answer string, expected models.Conjugation) bool
+	// SortKey returns the key words of the language are sorted by
+	SortKey(word *models.Word) string
+}
+
+// LanguagePacks are the language packs registered at startup, by language
+// code
+type LanguagePacks struct {
+	packs map[string]LanguagePack
+}
+
+func NewLanguagePacks(packs ...LanguagePack) *LanguagePacks {
+	p := &LanguagePacks{packs: make(map[string]LanguagePack, len(packs))}
+	for _, pack := range packs {
+		p.packs[pack.Code()] = pack
+	}
+	return p
+}
+
+// DefaultLanguagePacks registers the Japanese pack with the default romaji
+// options and the Spanish pack
+func DefaultLanguagePacks() *LanguagePacks {
+	return NewLanguagePacks(NewJapanesePack(DefaultRomajiOptions()), SpanishPack{})
+}
+
+// Codes returns the codes of the registered languages
+func (p *LanguagePacks) Codes() []string {
+	codes := make([]string, 0, len(p.packs))
+	for code := range p.packs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Get returns the pack of a language, Japanese for an empty code
+func (p *LanguagePacks) Get(code string) (LanguagePack, error) {
+	if code == "" {
+		code = models.DefaultLanguage
+	}
+	pack, ok := p.packs[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: no language pack for %q", ErrInvalidLanguage, code)
+	}
+	return pack, nil
+}
+
+// PrepareWord prepares a word with the pack of its language, Japanese when
+// it has none, and sets its sort key
+func (p *LanguagePacks) PrepareWord(word *models.Word) (string, error) {
+	if word.Language == "" {
+		word.Language = models.DefaultLanguage
+	}
+	pack, err := p.Get(word.Language)
+	if err != nil {
+		return "", err
+	}
+
+	warning, err := pack.PrepareWord(word)
+	if err != nil {
+		return "", err
+	}
+	word.SortKey = pack.SortKey(word)
+	return warning, nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/conjugation"
+	"backend-go/internal/conjugation/spanish"
+	"backend-go/internal/domain/models"
+)
+
+// SpanishPack conjugates regular verbs, matches answers regardless of case
+// and accents, and sorts ñ after n
+type SpanishPack struct{}
+
+func (SpanishPack) Code() string { return "es" }
+
+// PrepareWord trims the word and requires a term and a gloss. Spanish words
+// have no reading or furigana, and their transliteration is optional.
+func (SpanishPack) PrepareWord(word *models.Word) (string, error) {
+	word.Kanji = strings.TrimSpace(word.Kanji)
+	word.Romaji = strings.TrimSpace(word.Romaji)
+	word.English = strings.TrimSpace(word.English)
+	word.Reading = ""
+	word.Ruby = nil
+
+	if word.Kanji == "" {
+		return "", fmt.Errorf("term is required")
+	}
+	if word.English == "" {
+		return "", fmt.Errorf("gloss is required")
+	}
+	if word.Parts == nil {
+		return "", fmt.Errorf("parts is required")
+	}
+	return "", nil
+}
+
+// Conjugate conjugates verbs whose verb_type is regular, ar, er or ir
+func (SpanishPack) Conjugate(word *models.Word) (string, []models.Conjugation, error) {
+	name := partString(word.Parts, "verb_type")
+	if name == "" {
+		return "", nil, fmt.Errorf("%w: %s has no verb_type", ErrNotConjugable, word.Kanji)
+	}
+
+	class, err := spanish.ParseClass(name, word.Kanji)
+	if errors.Is(err, conjugation.ErrUnknownClass) {
+		return "", nil, fmt.Errorf("%w: %v", ErrNotConjugable, err)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	inflections, err := spanish.Conjugate(word.Kanji, class)
+	if err != nil {
+		return "", nil, err
+	}
+
+	conjugations := make([]models.Conjugation, len(inflections))
+	for i, inflection := range inflections {
+		conjugations[i] = models.Conjugation{Form: string(inflection.Form), Kanji: inflection.Text}
+	}
+	return string(class), conjugations, nil
+}
+
+func (SpanishPack) Forms() []string {
+	forms := spanish.Forms()
+	names := make([]string, len(forms))
+	for i, form := range forms {
+		names[i] = string(form)
+	}
+	return names
+}
+
+// CheckAnswer ignores case, extra spaces and accents, so that habló may be
+// typed as hablo and año as ano
+func (SpanishPack) CheckAnswer(answer string, expected models.Conjugation) bool {
+	answer = foldSpanish(answer, "n")
+	return answer != "" && answer == foldSpanish(expected.Kanji, "n")
+}
+
+// SortKey ignores case and accents but keeps ñ as a letter of its own after
+// n, the order of Spanish dictionaries
+func (SpanishPack) SortKey(word *models.Word) string {
+	// ~ sorts after every lowercase letter
+	return foldSpanish(word.Kanji, "n~")
+}
+
+var spanishAccents = strings.NewReplacer(
+	// Combining marks, for input that was decomposed
+	"n\u0303", "ñ", "\u0301", "", "\u0308", "",
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u",
+)
+
+// foldSpanish lowercases text, collapses its spaces, drops accents and
+// writes ñ as enye
+func foldSpanish(text, enye string) string {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	return strings.ReplaceAll(spanishAccents.Replace(text), "ñ", enye)
+}
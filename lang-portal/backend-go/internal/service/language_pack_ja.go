@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/conjugation"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/transliteration"
+)
+
+// JapanesePack fills in kana readings, furigana and romaji, conjugates verbs
+// and adjectives, accepts answers in kanji, kana or any romaji system and
+// sorts words by reading
+type JapanesePack struct {
+	romaji RomajiOptions
+}
+
+func NewJapanesePack(romaji RomajiOptions) JapanesePack {
+	return JapanesePack{romaji: romaji}
+}
+
+func (JapanesePack) Code() string { return "ja" }
+
+// PrepareWord fills in the kana reading of the word and missing romaji from
+// it, checks supplied romaji against it, lays out the furigana and validates
+// the word. A mismatch is returned as a warning, or as an error when
+// mismatches are rejected.
+func (p JapanesePack) PrepareWord(word *models.Word) (string, error) {
+	if err := fillReading(word); err != nil {
+		return "", err
+	}
+	warning, err := p.romaji.checkReading(word)
+	if err != nil {
+		return "", err
+	}
+	if err := fillRuby(word); err != nil {
+		return "", err
+	}
+	if err := validateWord(word); err != nil {
+		return "", err
+	}
+	return warning, nil
+}
+
+// Conjugate returns the class of a word and its forms written like the word,
+// in kana and in romaji. Words without a kana reading get no kana or romaji.
+func (p JapanesePack) Conjugate(word *models.Word) (string, []models.Conjugation, error) {
+	name := partString(word.Parts, "verb_type")
+	if name == "" {
+		name = partString(word.Parts, "adjective_type")
+	}
+	if name == "" {
+		return "", nil, fmt.Errorf("%w: %s has no verb_type or adjective_type", ErrNotConjugable, word.Kanji)
+	}
+
+	class, err := conjugation.ParseClass(name, word.Kanji)
+	if errors.Is(err, conjugation.ErrUnknownClass) {
+		return "", nil, fmt.Errorf("%w: %v", ErrNotConjugable, err)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	written, err := conjugation.Conjugate(word.Kanji, class)
+	if err != nil {
+		return "", nil, err
+	}
+
+	reading := word.Reading
+	if reading == "" && transliteration.IsKana(word.Kanji) {
+		reading = word.Kanji
+	}
+	var spoken []conjugation.Inflection
+	if reading != "" {
+		if spoken, err = conjugation.Conjugate(reading, class); err != nil {
+			return "", nil, err
+		}
+	}
+
+	conjugations := make([]models.Conjugation, len(written))
+	for i, inflection := range written {
+		conjugations[i] = models.Conjugation{Form: string(inflection.Form), Kanji: inflection.Text}
+		if spoken != nil {
+			conjugations[i].Reading = spoken[i].Text
+			// Every conjugated reading is kana, so romanizing cannot fail
+			conjugations[i].Romaji, _ = transliteration.Romanize(spoken[i].Text, p.romaji.System)
+		}
+	}
+	return string(class), conjugations, nil
+}
+
+// Forms returns the verb forms, which include every adjective form
+func (JapanesePack) Forms() []string {
+	forms := conjugation.Forms(conjugation.Godan)
+	names := make([]string, len(forms))
+	for i, form := range forms {
+		names[i] = string(form)
+	}
+	return names
+}
+
+// CheckAnswer accepts the form as written, its reading in hiragana or
+// katakana, and its romaji in any of the supported systems
+func (JapanesePack) CheckAnswer(answer string, expected models.Conjugation) bool {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return false
+	}
+	if answer == expected.Kanji {
+		return true
+	}
+	if expected.Reading == "" {
+		return false
+	}
+	return transliteration.ToHiragana(answer) == transliteration.ToHiragana(expected.Reading) ||
+		transliteration.Matches(answer, expected.Reading)
+}
+
+// SortKey is the reading in hiragana, which sorts in gojūon order, or the
+// word itself when its reading is unknown
+func (JapanesePack) SortKey(word *models.Word) string {
+	if word.Reading != "" {
+		return transliteration.ToHiragana(word.Reading)
+	}
+	return transliteration.ToHiragana(word.Kanji)
+}
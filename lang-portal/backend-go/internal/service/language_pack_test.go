@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func TestSpanishPack_CheckAnswer(t *testing.T) {
+	pack := SpanishPack{}
+	expected := models.Conjugation{Form: "preterite_el", Kanji: "habló"}
+
+	for answer, want := range map[string]bool{
+		"habló":   true,
+		"hablo":   true,
+		" Habló ": true,
+		"HABLO":   true,
+		"hablé":   false,
+		"":        false,
+	} {
+		if got := pack.CheckAnswer(answer, expected); got != want {
+			t.Errorf("CheckAnswer(%q, habló) = %v, want %v", answer, got, want)
+		}
+	}
+	if !pack.CheckAnswer("ano", models.Conjugation{Kanji: "año"}) {
+		t.Error("CheckAnswer(ano, año) = false, want true")
+	}
+	// A decomposed ñ is the same letter
+	if !pack.CheckAnswer("an\u0303o", models.Conjugation{Kanji: "año"}) {
+		t.Error("CheckAnswer() of a decomposed ñ = false, want true")
+	}
+}
+
+func TestLanguagePacks_SortKey(t *testing.T) {
+	packs := DefaultLanguagePacks()
+
+	spanish := []string{"ñu", "nube", "Ámbar", "anzuelo", "año", "oso"}
+	words := make([]*models.Word, len(spanish))
+	for i, term := range spanish {
+		words[i] = &models.Word{Language: "es", Kanji: term, English: term, Parts: map[string]any{}}
+		if _, err := packs.PrepareWord(words[i]); err != nil {
+			t.Fatalf("PrepareWord(%s) error = %v", term, err)
+		}
+	}
+	sort.Slice(words, func(i, j int) bool { return words[i].SortKey < words[j].SortKey })
+	var got []string
+	for _, word := range words {
+		got = append(got, word.Kanji)
+	}
+	want := []string{"Ámbar", "anzuelo", "año", "nube", "ñu", "oso"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Spanish words sorted as %v, want %v", got, want)
+		}
+	}
+
+	japanese := &models.Word{Kanji: "コーヒー", English: "coffee", Parts: map[string]any{}}
+	if _, err := packs.PrepareWord(japanese); err != nil {
+		t.Fatalf("PrepareWord() error = %v", err)
+	}
+	if japanese.Language != "ja" || japanese.SortKey != "こーひー" {
+		t.Errorf("PrepareWord() = %q, %q, want a Japanese word sorted by its reading in hiragana", japanese.Language, japanese.SortKey)
+	}
+
+	if _, err := packs.PrepareWord(&models.Word{Language: "fr", Kanji: "parler", English: "to speak", Parts: map[string]any{}}); !errors.Is(err, ErrInvalidLanguage) {
+		t.Errorf("PrepareWord() without a language pack error = %v, want %v", err, ErrInvalidLanguage)
+	}
+}
+
+func TestConjugationService_SpanishDrill(t *testing.T) {
+	wordRepo := NewMockWordRepository()
+	groupRepo := NewMockGroupRepository()
+	sessionRepo := NewMockStudySessionRepository()
+	service := NewConjugationService(wordRepo, groupRepo, sessionRepo, DefaultLanguagePacks())
+	ctx := context.Background()
+
+	group := &models.Group{Name: "Verbos", Language: "es"}
+	if err := groupRepo.Create(ctx, group); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+	words := []*models.Word{
+		{Language: "es", Kanji: "hablar", English: "to speak", Parts: map[string]any{"verb_type": "regular"}},
+		{Language: "es", Kanji: "ser", English: "to be", Parts: map[string]any{"verb_type": "irregular"}},
+	}
+	for _, word := range words {
+		if err := wordRepo.Create(ctx, word); err != nil {
+			t.Fatalf("Failed to create test word: %v", err)
+		}
+		groupRepo.AddWord(ctx, group.ID, word.ID)
+	}
+	session := &models.StudySession{GroupID: group.ID, StudyActivityID: 1}
+	if err := sessionRepo.Create(ctx, session); err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+
+	// Every form of the regular verb but the infinitive
+	exercises, err := service.Drill(ctx, group.ID, DrillParams{Count: 50})
+	if err != nil {
+		t.Fatalf("Drill() error = %v", err)
+	}
+	if len(exercises) != 32 {
+		t.Errorf("Drill() returned %d exercises, want 32", len(exercises))
+	}
+	if _, err := service.Drill(ctx, group.ID, DrillParams{Forms: []string{"te"}}); !errors.Is(err, ErrNotConjugable) {
+		t.Errorf("Drill() with a Japanese form error = %v, want ErrNotConjugable", err)
+	}
+
+	result, err := service.RecordReview(ctx, session.ID, ConjugationReviewParams{WordID: words[0].ID, Form: "preterite_el", Answer: "hablo"})
+	if err != nil {
+		t.Fatalf("RecordReview() error = %v", err)
+	}
+	if !result.Review.Correct || result.Expected.Kanji != "habló" {
+		t.Errorf("RecordReview() = %+v, %+v, want a correct answer for habló", result.Review, result.Expected)
+	}
+	if _, err := service.RecordReview(ctx, session.ID, ConjugationReviewParams{WordID: words[1].ID, Form: "present_yo", Answer: "soy"}); !errors.Is(err, ErrNotConjugable) {
+		t.Errorf("RecordReview() on an irregular verb error = %v, want ErrNotConjugable", err)
+	}
+}
+
+func TestWordService_BackfillSortKeys(t *testing.T) {
+	repo := newMockWordRepository()
+	service := NewWordService(repo, NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
+	ctx := context.Background()
+
+	words := []*models.Word{
+		{Kanji: "食べる", Reading: "たべる", Romaji: "taberu", English: "to eat", Parts: map[string]any{}},
+		{Language: "es", Kanji: "Año", English: "year", Parts: map[string]any{}},
+	}
+	for _, word := range words {
+		if err := repo.Create(ctx, word); err != nil {
+			t.Fatalf("Failed to create test word: %v", err)
+		}
+	}
+
+	updated, err := service.BackfillSortKeys(ctx)
+	if err != nil {
+		t.Fatalf("BackfillSortKeys() error = %v", err)
+	}
+	if updated != 2 || words[0].SortKey != "たべる" || words[1].SortKey != "an~o" {
+		t.Errorf("BackfillSortKeys() = %d, keys %q and %q", updated, words[0].SortKey, words[1].SortKey)
+	}
+	if updated, _ := service.BackfillSortKeys(ctx); updated != 0 {
+		t.Errorf("Second BackfillSortKeys() updated %d words, want 0", updated)
+	}
+}
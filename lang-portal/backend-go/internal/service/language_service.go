@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+// ErrInvalidLanguage is returned for a language code missing from the
+// languages table, or for a word moved to another language
+var ErrInvalidLanguage = errors.New("invalid language")
+
+type LanguageService struct {
+	languageRepo repository.LanguageRepository
+}
+
+func NewLanguageService(languageRepo repository.LanguageRepository) *LanguageService {
+	return &LanguageService{
+		languageRepo: languageRepo,
+	}
+}
+
+func (s *LanguageService) ListLanguages(ctx context.Context) ([]*models.Language, error) {
+	languages, err := s.languageRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing languages: %v", err)
+	}
+	return languages, nil
+}
+
+// checkLanguage defaults an empty language code to Japanese and checks that
+// the language exists
+func checkLanguage(ctx context.Context, languageRepo repository.LanguageRepository, code *string) error {
+	*code = strings.TrimSpace(*code)
+	if *code == "" {
+		*code = models.DefaultLanguage
+	}
+
+	language, err := languageRepo.GetByCode(ctx, *code)
+	if err != nil {
+		return fmt.Errorf("error getting language: %v", err)
+	}
+	if language == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidLanguage, *code)
+	}
+	return nil
+}
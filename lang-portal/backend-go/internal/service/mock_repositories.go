@@ -3,23 +3,68 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"backend-go/internal/domain/models"
 )
 
+type mockTransactor struct{}
+
+func NewMockTransactor() *mockTransactor {
+	return &mockTransactor{}
+}
+
+func (m *mockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type mockLanguageRepository struct {
+	languages []*models.Language
+}
+
+// NewMockLanguageRepository holds the languages the migrations create
+func NewMockLanguageRepository() *mockLanguageRepository {
+	return &mockLanguageRepository{
+		languages: []*models.Language{
+			{Code: "ja", Name: "Japanese", Script: "Jpan", Transliteration: "romaji"},
+			{Code: "es", Name: "Spanish", Script: "Latn"},
+		},
+	}
+}
+
+func (m *mockLanguageRepository) List(ctx context.Context) ([]*models.Language, error) {
+	return m.languages, nil
+}
+
+func (m *mockLanguageRepository) GetByCode(ctx context.Context, code string) (*models.Language, error) {
+	for _, language := range m.languages {
+		if language.Code == code {
+			return language, nil
+		}
+	}
+	return nil, nil
+}
+
 type mockWordRepository struct {
 	words map[int64]*models.Word
 	stats map[int64]*models.WordStats
+	trash map[int64]*models.Word
 }
 
 func NewMockWordRepository() *mockWordRepository {
 	return &mockWordRepository{
 		words: make(map[int64]*models.Word),
 		stats: make(map[int64]*models.WordStats),
+		trash: make(map[int64]*models.Word),
 	}
 }
 
 func (m *mockWordRepository) Create(ctx context.Context, word *models.Word) error {
+	if word.Language == "" {
+		word.Language = models.DefaultLanguage
+	}
 	word.ID = int64(len(m.words) + 1)
 	m.words[word.ID] = word
 	m.stats[word.ID] = &models.WordStats{}
@@ -34,20 +79,69 @@ func (m *mockWordRepository) GetByID(ctx context.Context, id int64) (*models.Wor
 	return word, nil
 }
 
-func (m *mockWordRepository) List(ctx context.Context, page, pageSize int, sortBy, order string) ([]*models.WordWithStats, int, error) {
+func (m *mockWordRepository) GetByKanjiRomaji(ctx context.Context, kanji, romaji, language string) (*models.Word, error) {
+	if language == "" {
+		language = models.DefaultLanguage
+	}
+	for _, word := range m.words {
+		if word.Kanji == kanji && word.Romaji == romaji && word.Language == language {
+			return word, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockWordRepository) List(ctx context.Context, page, pageSize int, sortBy, order string, filter models.WordFilter) ([]*models.WordWithStats, int, error) {
+	var words []*models.WordWithStats
+	for _, word := range m.words {
+		if filter.Character != "" && !strings.Contains(word.Kanji, filter.Character) {
+			continue
+		}
+		stats := m.stats[word.ID]
+		if stats == nil {
+			stats = &models.WordStats{}
+		}
+		words = append(words, &models.WordWithStats{
+			ID:       word.ID,
+			Language: word.Language,
+			Kanji:    word.Kanji,
+			Reading:  word.Reading,
+			Ruby:     word.Ruby,
+			Romaji:   word.Romaji,
+			English:  word.English,
+			Parts:    word.Parts,
+			Stats:    *stats,
+		})
+	}
+	return words, len(words), nil
+}
+
+func (m *mockWordRepository) Search(ctx context.Context, query, language string, page, pageSize int) ([]*models.WordWithStats, int, error) {
 	var words []*models.WordWithStats
+	query = strings.ToLower(query)
 	for _, word := range m.words {
+		if language != "" && word.Language != language {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(word.Kanji), query) &&
+			!strings.Contains(strings.ToLower(word.Romaji), query) &&
+			!strings.Contains(strings.ToLower(word.English), query) {
+			continue
+		}
 		stats := m.stats[word.ID]
 		if stats == nil {
 			stats = &models.WordStats{}
 		}
 		words = append(words, &models.WordWithStats{
-			ID:      word.ID,
-			Kanji:   word.Kanji,
-			Romaji:  word.Romaji,
-			English: word.English,
-			Parts:   word.Parts,
-			Stats:   *stats,
+			ID:       word.ID,
+			Language: word.Language,
+			Kanji:    word.Kanji,
+			Reading:  word.Reading,
+			Ruby:     word.Ruby,
+			Romaji:   word.Romaji,
+			English:  word.English,
+			Parts:    word.Parts,
+			Stats:    *stats,
 		})
 	}
 	return words, len(words), nil
@@ -57,19 +151,52 @@ func (m *mockWordRepository) Update(ctx context.Context, word *models.Word) erro
 	if _, exists := m.words[word.ID]; !exists {
 		return fmt.Errorf("word not found")
 	}
+	if word.Language == "" {
+		word.Language = models.DefaultLanguage
+	}
 	m.words[word.ID] = word
 	return nil
 }
 
 func (m *mockWordRepository) Delete(ctx context.Context, id int64) error {
-	if _, exists := m.words[id]; !exists {
+	word, exists := m.words[id]
+	if !exists {
 		return fmt.Errorf("word not found")
 	}
+	m.trash[id] = word
 	delete(m.words, id)
-	delete(m.stats, id)
 	return nil
 }
 
+func (m *mockWordRepository) Restore(ctx context.Context, id int64) (bool, error) {
+	word, exists := m.trash[id]
+	if !exists {
+		return false, nil
+	}
+	m.words[id] = word
+	delete(m.trash, id)
+	return true, nil
+}
+
+func (m *mockWordRepository) ListWithoutReading(ctx context.Context) ([]*models.Word, error) {
+	var words []*models.Word
+	for _, word := range m.words {
+		if word.Reading == "" && word.Language == models.DefaultLanguage {
+			words = append(words, word)
+		}
+	}
+	return words, nil
+}
+
+func (m *mockWordRepository) SetSortKey(ctx context.Context, id int64, sortKey string) (bool, error) {
+	word, exists := m.words[id]
+	if !exists || word.SortKey == sortKey {
+		return false, nil
+	}
+	word.SortKey = sortKey
+	return true, nil
+}
+
 func (m *mockWordRepository) GetStats(ctx context.Context, wordID int64) (*models.WordStats, error) {
 	stats, exists := m.stats[wordID]
 	if !exists {
@@ -103,6 +230,7 @@ type mockGroupRepository struct {
 	groups     map[int64]*models.Group
 	stats      map[int64]*models.GroupStats
 	wordGroups map[int64]map[int64]bool
+	trash      map[int64]*models.Group
 }
 
 func NewMockGroupRepository() *mockGroupRepository {
@@ -110,6 +238,7 @@ func NewMockGroupRepository() *mockGroupRepository {
 		groups:     make(map[int64]*models.Group),
 		stats:      make(map[int64]*models.GroupStats),
 		wordGroups: make(map[int64]map[int64]bool),
+		trash:      make(map[int64]*models.Group),
 	}
 }
 
@@ -124,6 +253,10 @@ func (m *mockGroupRepository) AddWord(ctx context.Context, groupID, wordID int64
 	return nil
 }
 
+func (m *mockGroupRepository) HasWord(ctx context.Context, groupID, wordID int64) (bool, error) {
+	return m.wordGroups[groupID][wordID], nil
+}
+
 func (m *mockGroupRepository) RemoveWord(ctx context.Context, groupID, wordID int64) error {
 	if _, exists := m.groups[groupID]; !exists {
 		return fmt.Errorf("group not found")
@@ -132,18 +265,93 @@ func (m *mockGroupRepository) RemoveWord(ctx context.Context, groupID, wordID in
 	return nil
 }
 
-func (m *mockGroupRepository) ListWords(ctx context.Context, groupID int64) ([]int64, error) {
+func (m *mockGroupRepository) AddWords(ctx context.Context, groupID int64, wordIDs []int64, position int) (int, error) {
+	added := 0
+	for _, wordID := range wordIDs {
+		if !m.wordGroups[groupID][wordID] {
+			if err := m.AddWord(ctx, groupID, wordID); err != nil {
+				return 0, err
+			}
+			added++
+		}
+	}
+	m.groups[groupID].WordsCount = len(m.wordGroups[groupID])
+	return added, nil
+}
+
+func (m *mockGroupRepository) RemoveWords(ctx context.Context, groupID int64, wordIDs []int64) (int, error) {
+	removed := 0
+	for _, wordID := range wordIDs {
+		if m.wordGroups[groupID][wordID] {
+			delete(m.wordGroups[groupID], wordID)
+			removed++
+		}
+	}
+	if group, exists := m.groups[groupID]; exists {
+		group.WordsCount = len(m.wordGroups[groupID])
+	}
+	return removed, nil
+}
+
+func (m *mockGroupRepository) ReorderWords(ctx context.Context, groupID int64, wordIDs []int64) (int, error) {
+	reordered := 0
+	for _, wordID := range wordIDs {
+		if m.wordGroups[groupID][wordID] {
+			reordered++
+		}
+	}
+	return reordered, nil
+}
+
+func (m *mockGroupRepository) ListWords(ctx context.Context, groupID int64, page, pageSize int, filter models.WordFilter) ([]*models.WordWithStats, int, error) {
 	if _, exists := m.groups[groupID]; !exists {
-		return nil, fmt.Errorf("group not found")
+		return nil, 0, fmt.Errorf("group not found")
 	}
-	var wordIDs []int64
+	var words []*models.WordWithStats
 	for wordID := range m.wordGroups[groupID] {
-		wordIDs = append(wordIDs, wordID)
+		words = append(words, &models.WordWithStats{ID: wordID})
+	}
+	return words, len(words), nil
+}
+
+func (m *mockGroupRepository) GetGroupWords(ctx context.Context, groupID int64, page int, sortBy, order string, filter models.WordFilter) ([]*models.WordWithStats, int, error) {
+	return m.ListWords(ctx, groupID, page, 10, filter)
+}
+
+func (m *mockGroupRepository) ListStudySessions(ctx context.Context, groupID int64, page, pageSize int) ([]models.StudySessionWithStats, int, error) {
+	return []models.StudySessionWithStats{}, 0, nil
+}
+
+func (m *mockGroupRepository) ListChildren(ctx context.Context, parentID int64) ([]*models.Group, error) {
+	children := []*models.Group{}
+	for _, group := range m.groups {
+		if group.ParentID != nil && *group.ParentID == parentID {
+			children = append(children, group)
+		}
 	}
-	return wordIDs, nil
+	slices.SortFunc(children, func(a, b *models.Group) int {
+		if a.Position != b.Position {
+			return a.Position - b.Position
+		}
+		return int(a.ID - b.ID)
+	})
+	return children, nil
+}
+
+func (m *mockGroupRepository) SetParent(ctx context.Context, id int64, parentID *int64, position int) error {
+	group, exists := m.groups[id]
+	if !exists {
+		return fmt.Errorf("group not found")
+	}
+	group.ParentID = parentID
+	group.Position = position
+	return nil
 }
 
 func (m *mockGroupRepository) Create(ctx context.Context, group *models.Group) error {
+	if group.Language == "" {
+		group.Language = models.DefaultLanguage
+	}
 	group.ID = int64(len(m.groups) + 1)
 	m.groups[group.ID] = group
 	return nil
@@ -157,9 +365,21 @@ func (m *mockGroupRepository) GetByID(ctx context.Context, id int64) (*models.Gr
 	return group, nil
 }
 
-func (m *mockGroupRepository) List(ctx context.Context, page, pageSize int, sortBy, order string) ([]*models.Group, int, error) {
+func (m *mockGroupRepository) GetByName(ctx context.Context, name string) (*models.Group, error) {
+	for _, group := range m.groups {
+		if group.Name == name {
+			return group, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockGroupRepository) List(ctx context.Context, page, pageSize int, sortBy, order, language string) ([]*models.Group, int, error) {
 	var groups []*models.Group
 	for _, group := range m.groups {
+		if language != "" && group.Language != language {
+			continue
+		}
 		groups = append(groups, group)
 	}
 	return groups, len(groups), nil
@@ -174,13 +394,25 @@ func (m *mockGroupRepository) Update(ctx context.Context, group *models.Group) e
 }
 
 func (m *mockGroupRepository) Delete(ctx context.Context, id int64) error {
-	if _, exists := m.groups[id]; !exists {
+	group, exists := m.groups[id]
+	if !exists {
 		return fmt.Errorf("group not found")
 	}
+	m.trash[id] = group
 	delete(m.groups, id)
 	return nil
 }
 
+func (m *mockGroupRepository) Restore(ctx context.Context, id int64) (bool, error) {
+	group, exists := m.trash[id]
+	if !exists {
+		return false, nil
+	}
+	m.groups[id] = group
+	delete(m.trash, id)
+	return true, nil
+}
+
 func (m *mockGroupRepository) GetStats(ctx context.Context, groupID int64) (*models.GroupStats, error) {
 	stats, exists := m.stats[groupID]
 	if !exists {
@@ -192,11 +424,13 @@ func (m *mockGroupRepository) GetStats(ctx context.Context, groupID int64) (*mod
 func NewMockStudyActivityRepository() *mockStudyActivityRepository {
 	return &mockStudyActivityRepository{
 		activities: make(map[int64]*models.StudyActivity),
+		trash:      make(map[int64]*models.StudyActivity),
 	}
 }
 
 type mockStudyActivityRepository struct {
 	activities map[int64]*models.StudyActivity
+	trash      map[int64]*models.StudyActivity
 }
 
 func (m *mockStudyActivityRepository) Create(ctx context.Context, activity *models.StudyActivity) error {
@@ -213,6 +447,15 @@ func (m *mockStudyActivityRepository) GetByID(ctx context.Context, id int64) (*m
 	return activity, nil
 }
 
+func (m *mockStudyActivityRepository) GetByName(ctx context.Context, name string) (*models.StudyActivity, error) {
+	for _, activity := range m.activities {
+		if activity.Name == name {
+			return activity, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *mockStudyActivityRepository) List(ctx context.Context) ([]*models.StudyActivity, error) {
 	var activities []*models.StudyActivity
 	for _, activity := range m.activities {
@@ -230,13 +473,25 @@ func (m *mockStudyActivityRepository) Update(ctx context.Context, activity *mode
 }
 
 func (m *mockStudyActivityRepository) Delete(ctx context.Context, id int64) error {
-	if _, exists := m.activities[id]; !exists {
+	activity, exists := m.activities[id]
+	if !exists {
 		return fmt.Errorf("activity not found")
 	}
+	m.trash[id] = activity
 	delete(m.activities, id)
 	return nil
 }
 
+func (m *mockStudyActivityRepository) Restore(ctx context.Context, id int64) (bool, error) {
+	activity, exists := m.trash[id]
+	if !exists {
+		return false, nil
+	}
+	m.activities[id] = activity
+	delete(m.trash, id)
+	return true, nil
+}
+
 type mockStudySessionRepository struct {
 	sessions map[int64]*models.StudySession
 	reviews  map[int64][]*models.WordReviewItem
@@ -263,6 +518,15 @@ func (m *mockStudySessionRepository) GetByID(ctx context.Context, id int64) (*mo
 	return session, nil
 }
 
+func (m *mockStudySessionRepository) FindSession(ctx context.Context, groupID, activityID int64, createdAt time.Time) (*models.StudySession, error) {
+	for _, session := range m.sessions {
+		if session.GroupID == groupID && session.StudyActivityID == activityID && session.CreatedAt.Equal(createdAt) {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *mockStudySessionRepository) ListByGroup(ctx context.Context, groupID int64, page, pageSize int) ([]*models.StudySession, int, error) {
 	var sessions []*models.StudySession
 	for _, session := range m.sessions {
@@ -287,6 +551,40 @@ func (m *mockStudySessionRepository) ListReviews(ctx context.Context, sessionID
 	return reviews, nil
 }
 
+func (m *mockStudySessionRepository) ListFormStats(ctx context.Context, groupID, wordID int64) ([]*models.FormStats, error) {
+	type key struct {
+		wordID int64
+		form   string
+	}
+	counts := make(map[key]*models.FormStats)
+	var stats []*models.FormStats
+	for sessionID, reviews := range m.reviews {
+		session := m.sessions[sessionID]
+		if groupID != 0 && (session == nil || session.GroupID != groupID) {
+			continue
+		}
+		for _, review := range reviews {
+			if review.Form == "" || (wordID != 0 && review.WordID != wordID) {
+				continue
+			}
+			k := key{review.WordID, review.Form}
+			if counts[k] == nil {
+				counts[k] = &models.FormStats{WordID: review.WordID, Form: review.Form}
+				stats = append(stats, counts[k])
+			}
+			if review.Correct {
+				counts[k].CorrectCount++
+			} else {
+				counts[k].WrongCount++
+			}
+		}
+	}
+	for _, stat := range stats {
+		stat.Accuracy = float64(stat.CorrectCount) / float64(stat.CorrectCount+stat.WrongCount) * 100
+	}
+	return stats, nil
+}
+
 func (m *mockStudySessionRepository) GetSessionStats(ctx context.Context, sessionID int64) (*models.StudySessionStats, error) {
 	reviews := m.reviews[sessionID]
 	stats := &models.StudySessionStats{
@@ -385,11 +683,281 @@ func (m *mockStudySessionRepository) ListByActivity(ctx context.Context, activit
 	return sessions, nil
 }
 
-func (m *mockStudySessionRepository) LoadSeedData(ctx context.Context, seedsDir string) error {
-	// For simplicity, you can just return nil or implement logic to load mock data
-	// Here, we will just simulate loading some mock data
-	m.sessions[1] = &models.StudySession{ID: 1, StudyActivityID: 1, GroupID: 1}
-	m.sessions[2] = &models.StudySession{ID: 2, StudyActivityID: 1, GroupID: 1}
+type mockScheduleRepository struct {
+	schedules  map[int64]*models.WordSchedule
+	history    []*models.WordReviewItem
+	lastGroups map[int64]int64
+}
+
+func NewMockScheduleRepository() *mockScheduleRepository {
+	return &mockScheduleRepository{
+		schedules:  make(map[int64]*models.WordSchedule),
+		lastGroups: make(map[int64]int64),
+	}
+}
+
+func (m *mockScheduleRepository) Get(ctx context.Context, wordID int64) (*models.WordSchedule, error) {
+	schedule, exists := m.schedules[wordID]
+	if !exists {
+		return nil, nil
+	}
+	return schedule, nil
+}
+
+func (m *mockScheduleRepository) Upsert(ctx context.Context, schedule *models.WordSchedule) error {
+	stored := *schedule
+	m.schedules[schedule.WordID] = &stored
+	return nil
+}
+
+func (m *mockScheduleRepository) ListDue(ctx context.Context, groupID int64, language string, now time.Time, limit int) ([]*models.DueWord, error) {
+	words := []*models.DueWord{}
+	for _, schedule := range m.schedules {
+		if len(words) == limit {
+			break
+		}
+		if !schedule.DueAt.After(now) {
+			word := &models.DueWord{Schedule: schedule}
+			word.ID = schedule.WordID
+			words = append(words, word)
+		}
+	}
+	return words, nil
+}
+
+func (m *mockScheduleRepository) ListHistory(ctx context.Context, groupID int64) ([]*models.WordReviewItem, error) {
+	return m.history, nil
+}
+
+func (m *mockScheduleRepository) ListWordHistory(ctx context.Context, wordID int64) ([]*models.WordReviewItem, error) {
+	var reviews []*models.WordReviewItem
+	for _, review := range m.history {
+		if review.WordID == wordID {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews, nil
+}
+
+func (m *mockScheduleRepository) ListLastReviewGroups(ctx context.Context) (map[int64]int64, error) {
+	return m.lastGroups, nil
+}
+
+type mockGroupSettingsRepository struct {
+	schedulers map[int64]string
+}
+
+func NewMockGroupSettingsRepository() *mockGroupSettingsRepository {
+	return &mockGroupSettingsRepository{
+		schedulers: make(map[int64]string),
+	}
+}
+
+func (m *mockGroupSettingsRepository) GetScheduler(ctx context.Context, groupID int64) (string, error) {
+	return m.schedulers[groupID], nil
+}
+
+func (m *mockGroupSettingsRepository) SetScheduler(ctx context.Context, groupID int64, scheduler string) error {
+	m.schedulers[groupID] = scheduler
+	return nil
+}
+
+type mockSentenceRepository struct {
+	sentences map[int64]*models.Sentence
+	nextID    int64
+}
+
+func NewMockSentenceRepository() *mockSentenceRepository {
+	return &mockSentenceRepository{
+		sentences: make(map[int64]*models.Sentence),
+	}
+}
+
+func (m *mockSentenceRepository) Create(ctx context.Context, sentence *models.Sentence) error {
+	m.nextID++
+	sentence.ID = m.nextID
+	sentence.CreatedAt = time.Now()
+	m.sentences[sentence.ID] = sentence
+	return nil
+}
+
+func (m *mockSentenceRepository) GetByID(ctx context.Context, id int64) (*models.Sentence, error) {
+	return m.sentences[id], nil
+}
+
+func (m *mockSentenceRepository) List(ctx context.Context, query string, page, pageSize int) ([]*models.Sentence, int, error) {
+	sentences := []*models.Sentence{}
+	for id := m.nextID; id > 0; id-- {
+		sentence := m.sentences[id]
+		if sentence != nil && (query == "" || strings.Contains(sentence.Japanese, query) || strings.Contains(sentence.English, query)) {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences, len(sentences), nil
+}
+
+func (m *mockSentenceRepository) ListByWord(ctx context.Context, wordID int64, page, pageSize int) ([]*models.Sentence, int, error) {
+	sentences := []*models.Sentence{}
+	for id := int64(1); id <= m.nextID; id++ {
+		sentence := m.sentences[id]
+		if sentence == nil {
+			continue
+		}
+		for _, linked := range sentence.WordIDs {
+			if linked == wordID {
+				sentences = append(sentences, sentence)
+				break
+			}
+		}
+	}
+	total := len(sentences)
+	if len(sentences) > pageSize {
+		sentences = sentences[:pageSize]
+	}
+	return sentences, total, nil
+}
+
+func (m *mockSentenceRepository) Update(ctx context.Context, sentence *models.Sentence) error {
+	if _, exists := m.sentences[sentence.ID]; !exists {
+		return fmt.Errorf("sentence not found")
+	}
+	m.sentences[sentence.ID] = sentence
+	return nil
+}
+
+func (m *mockSentenceRepository) Delete(ctx context.Context, id int64) error {
+	if _, exists := m.sentences[id]; !exists {
+		return fmt.Errorf("sentence not found")
+	}
+	delete(m.sentences, id)
+	return nil
+}
+
+type mockKanjiRepository struct {
+	kanji map[string]*models.Kanji
+}
+
+func NewMockKanjiRepository() *mockKanjiRepository {
+	return &mockKanjiRepository{
+		kanji: make(map[string]*models.Kanji),
+	}
+}
+
+func (m *mockKanjiRepository) Upsert(ctx context.Context, kanji *models.Kanji) error {
+	m.kanji[kanji.Character] = kanji
 	return nil
 }
 
+func (m *mockKanjiRepository) GetByCharacter(ctx context.Context, character string) (*models.Kanji, error) {
+	return m.kanji[character], nil
+}
+
+func (m *mockKanjiRepository) ListByCharacters(ctx context.Context, characters []string) (map[string]*models.Kanji, error) {
+	entries := make(map[string]*models.Kanji)
+	for _, character := range characters {
+		if kanji, ok := m.kanji[character]; ok {
+			entries[character] = kanji
+		}
+	}
+	return entries, nil
+}
+
+type mockDictionaryRepository struct {
+	entries map[int64]*models.DictionaryEntry
+}
+
+func NewMockDictionaryRepository() *mockDictionaryRepository {
+	return &mockDictionaryRepository{
+		entries: make(map[int64]*models.DictionaryEntry),
+	}
+}
+
+func (m *mockDictionaryRepository) Upsert(ctx context.Context, entry *models.DictionaryEntry) error {
+	m.entries[entry.ID] = entry
+	return nil
+}
+
+func (m *mockDictionaryRepository) GetByID(ctx context.Context, id int64) (*models.DictionaryEntry, error) {
+	return m.entries[id], nil
+}
+
+func (m *mockDictionaryRepository) Search(ctx context.Context, query models.DictionaryQuery, page, pageSize int) ([]*models.DictionaryEntry, int, error) {
+	entries := []*models.DictionaryEntry{}
+	for _, entry := range m.entries {
+		matches := query.Kanji != "" && slices.Contains(entry.Kanji, query.Kanji)
+		for _, sense := range entry.Senses {
+			matches = matches || (query.English != "" && slices.Contains(sense.Glosses, query.English))
+		}
+		if matches {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, len(entries), nil
+}
+
+// mockRevisionRepository holds revisions added by tests; the mock entity
+// repositories do not record any
+type mockRevisionRepository struct {
+	revisions map[models.RevisionKind]map[int64][]*models.Revision
+	nextID    int64
+}
+
+func NewMockRevisionRepository() *mockRevisionRepository {
+	return &mockRevisionRepository{
+		revisions: make(map[models.RevisionKind]map[int64][]*models.Revision),
+	}
+}
+
+// Add appends a revision to the history of an entity
+func (m *mockRevisionRepository) Add(kind models.RevisionKind, entityID int64, revision *models.Revision) {
+	if m.revisions[kind] == nil {
+		m.revisions[kind] = make(map[int64][]*models.Revision)
+	}
+	m.nextID++
+	revision.ID = m.nextID
+	m.revisions[kind][entityID] = append(m.revisions[kind][entityID], revision)
+}
+
+func (m *mockRevisionRepository) List(ctx context.Context, kind models.RevisionKind, entityID int64, page, pageSize int) ([]*models.Revision, int, error) {
+	history := m.revisions[kind][entityID]
+	revisions := make([]*models.Revision, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		revisions = append(revisions, history[i])
+	}
+	return revisions, len(revisions), nil
+}
+
+func (m *mockRevisionRepository) GetByID(ctx context.Context, kind models.RevisionKind, entityID, id int64) (*models.Revision, error) {
+	for _, revision := range m.revisions[kind][entityID] {
+		if revision.ID == id {
+			return revision, nil
+		}
+	}
+	return nil, nil
+}
+
+// mockTrashRepository lists the items given to it and remembers the cutoff
+// of the last purge
+type mockTrashRepository struct {
+	items  []*models.TrashItem
+	purged time.Time
+}
+
+func NewMockTrashRepository() *mockTrashRepository {
+	return &mockTrashRepository{}
+}
+
+func (m *mockTrashRepository) List(ctx context.Context, kind string, page, pageSize int) ([]*models.TrashItem, int, error) {
+	items := []*models.TrashItem{}
+	for _, item := range m.items {
+		if kind == "" || string(item.Type) == kind {
+			items = append(items, item)
+		}
+	}
+	return items, len(items), nil
+}
+
+func (m *mockTrashRepository) Purge(ctx context.Context, before time.Time) (*models.PurgeResult, error) {
+	m.purged = before
+	return &models.PurgeResult{}, nil
+}
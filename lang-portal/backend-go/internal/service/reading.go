@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/transliteration"
+)
+
+// fillReading settles the kana reading of a word before its romaji is
+// checked. A kana parts.reading is moved to the reading field; without one,
+// the reading is taken from the ruby, or from the kanji field of words
+// written in kana only.
+func fillReading(word *models.Word) error {
+	word.Reading = strings.TrimSpace(word.Reading)
+	if reading, ok := word.Parts["reading"].(string); ok && transliteration.IsKana(strings.TrimSpace(reading)) {
+		reading = strings.TrimSpace(reading)
+		if word.Reading == "" || word.Reading == reading {
+			word.Reading = reading
+			delete(word.Parts, "reading")
+		}
+	}
+
+	if word.Reading == "" && len(word.Ruby) > 0 {
+		var reading strings.Builder
+		for _, segment := range word.Ruby {
+			if segment.Ruby != "" {
+				reading.WriteString(segment.Ruby)
+			} else {
+				reading.WriteString(segment.Text)
+			}
+		}
+		word.Reading = reading.String()
+	}
+
+	if word.Reading == "" && transliteration.IsKana(word.Kanji) {
+		word.Reading = strings.TrimSpace(word.Kanji)
+	}
+
+	if word.Reading != "" && !transliteration.IsKana(word.Reading) {
+		return fmt.Errorf("reading must be written in kana")
+	}
+	return nil
+}
+
+// readingFromRomaji fills in the reading of a word from its romaji when the
+// romaji can only be spelled one way in kana and that spelling fits the kana
+// written in the kanji field
+func readingFromRomaji(word *models.Word) {
+	if word.Romaji == "" {
+		return
+	}
+	reading, err := transliteration.FromRomaji(word.Romaji)
+	if err != nil {
+		return
+	}
+	if _, ok := transliteration.Furigana(word.Kanji, reading); !ok {
+		return
+	}
+	word.Reading = reading
+}
+
+// fillRuby checks the ruby given with a word against its kanji and reading,
+// or lays it out from the reading when none was given
+func fillRuby(word *models.Word) error {
+	if len(word.Ruby) > 0 {
+		return checkRuby(word)
+	}
+	if word.Reading == "" {
+		return nil
+	}
+
+	segments, ok := transliteration.Furigana(word.Kanji, word.Reading)
+	if !ok {
+		return nil
+	}
+	word.Ruby = make([]models.RubySegment, len(segments))
+	for i, segment := range segments {
+		word.Ruby[i] = models.RubySegment{Text: segment.Text, Ruby: segment.Reading}
+	}
+	return nil
+}
+
+func checkRuby(word *models.Word) error {
+	var text, reading strings.Builder
+	for _, segment := range word.Ruby {
+		if segment.Text == "" {
+			return fmt.Errorf("ruby segments must have text")
+		}
+		if segment.Ruby != "" && !transliteration.IsKana(segment.Ruby) {
+			return fmt.Errorf("ruby must be written in kana")
+		}
+		text.WriteString(segment.Text)
+		if segment.Ruby != "" {
+			reading.WriteString(segment.Ruby)
+		} else {
+			reading.WriteString(segment.Text)
+		}
+	}
+
+	if text.String() != word.Kanji {
+		return fmt.Errorf("ruby does not spell the kanji")
+	}
+	if transliteration.ToHiragana(reading.String()) != transliteration.ToHiragana(word.Reading) {
+		return fmt.Errorf("ruby does not match the reading")
+	}
+	return nil
+}
+
+// BackfillReadings fills in the reading and ruby of words stored without a
+// reading, from parts.reading, kana-only kanji or unambiguous romaji. It
+// returns the number of words updated; the others are left as they are.
+func (s *WordService) BackfillReadings(ctx context.Context) (int, error) {
+	words, err := s.wordRepo.ListWithoutReading(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, word := range words {
+		if err := fillReading(word); err != nil {
+			continue
+		}
+		if word.Reading == "" {
+			readingFromRomaji(word)
+		}
+		if word.Reading == "" {
+			continue
+		}
+		if err := fillRuby(word); err != nil {
+			word.Ruby = nil
+		}
+
+		if err := s.wordRepo.Update(ctx, word); err != nil {
+			return updated, fmt.Errorf("error updating word %d: %v", word.ID, err)
+		}
+		updated++
+	}
+	return updated, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func TestWordService_CreateWordReading(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		word        *models.Word
+		wantReading string
+		wantRuby    []models.RubySegment
+		wantErr     bool
+	}{
+		{
+			name:        "reading laid out as furigana",
+			word:        &models.Word{Kanji: "飲み物", Reading: "のみもの", English: "drink", Parts: map[string]any{}},
+			wantReading: "のみもの",
+			wantRuby:    []models.RubySegment{{Text: "飲", Ruby: "の"}, {Text: "み"}, {Text: "物", Ruby: "もの"}},
+		},
+		{
+			name:        "parts.reading promoted",
+			word:        &models.Word{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{"reading": "たべる"}},
+			wantReading: "たべる",
+			wantRuby:    []models.RubySegment{{Text: "食", Ruby: "た"}, {Text: "べる"}},
+		},
+		{
+			name:        "reading from romaji",
+			word:        &models.Word{Kanji: "勉強", Romaji: "benkyou", English: "study", Parts: map[string]any{}},
+			wantReading: "べんきょう",
+			wantRuby:    []models.RubySegment{{Text: "勉強", Ruby: "べんきょう"}},
+		},
+		{
+			name: "ambiguous romaji leaves the reading empty",
+			word: &models.Word{Kanji: "勉強", Romaji: "benkyō", English: "study", Parts: map[string]any{}},
+		},
+		{
+			name: "romaji that does not fit the kana in the kanji leaves the reading empty",
+			word: &models.Word{Kanji: "食べる", Romaji: "nomu", English: "to eat", Parts: map[string]any{}},
+		},
+		{
+			name:        "kana word",
+			word:        &models.Word{Kanji: "コーヒー", English: "coffee", Parts: map[string]any{}},
+			wantReading: "コーヒー",
+		},
+		{
+			name:        "reading from ruby",
+			word:        &models.Word{Kanji: "飲み物", Romaji: "nomimono", English: "drink", Ruby: []models.RubySegment{{Text: "飲", Ruby: "の"}, {Text: "み物", Ruby: "みもの"}}, Parts: map[string]any{}},
+			wantReading: "のみもの",
+			wantRuby:    []models.RubySegment{{Text: "飲", Ruby: "の"}, {Text: "み物", Ruby: "みもの"}},
+		},
+		{
+			name:    "reading not in kana",
+			word:    &models.Word{Kanji: "食べる", Reading: "taberu", English: "to eat", Parts: map[string]any{}},
+			wantErr: true,
+		},
+		{
+			name:    "ruby not spelling the kanji",
+			word:    &models.Word{Kanji: "食べる", Reading: "たべる", English: "to eat", Ruby: []models.RubySegment{{Text: "食", Ruby: "た"}}, Parts: map[string]any{}},
+			wantErr: true,
+		},
+		{
+			name:    "ruby not matching the reading",
+			word:    &models.Word{Kanji: "食べる", Reading: "たべる", English: "to eat", Ruby: []models.RubySegment{{Text: "食", Ruby: "の"}, {Text: "べる"}}, Parts: map[string]any{}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewWordService(newMockWordRepository(), NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
+			_, err := service.CreateWord(ctx, tt.word)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateWord() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.word.Reading != tt.wantReading {
+				t.Errorf("CreateWord() reading = %q, want %q", tt.word.Reading, tt.wantReading)
+			}
+			if !reflect.DeepEqual(tt.word.Ruby, tt.wantRuby) {
+				t.Errorf("CreateWord() ruby = %v, want %v", tt.word.Ruby, tt.wantRuby)
+			}
+			if _, ok := tt.word.Parts["reading"]; ok {
+				t.Error("CreateWord() kept parts.reading")
+			}
+		})
+	}
+}
+
+func TestWordService_BackfillReadings(t *testing.T) {
+	repo := newMockWordRepository()
+	service := NewWordService(repo, NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
+	ctx := context.Background()
+
+	// Words stored before the reading column existed
+	words := []*models.Word{
+		{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{}},
+		{Kanji: "勉強", Romaji: "benkyō", English: "study", Parts: map[string]any{"reading": "べんきょう"}},
+		{Kanji: "ねこ", Romaji: "neko", English: "cat", Parts: map[string]any{}},
+		{Kanji: "今日", Romaji: "kyō", English: "today", Parts: map[string]any{}},
+	}
+	for _, word := range words {
+		if err := repo.Create(ctx, word); err != nil {
+			t.Fatalf("Failed to create test word: %v", err)
+		}
+	}
+
+	updated, err := service.BackfillReadings(ctx)
+	if err != nil {
+		t.Fatalf("BackfillReadings() error = %v", err)
+	}
+	if updated != 3 {
+		t.Errorf("BackfillReadings() updated %d words, want 3", updated)
+	}
+
+	want := []string{"たべる", "べんきょう", "ねこ", ""}
+	for i, word := range words {
+		if word.Reading != want[i] {
+			t.Errorf("%s reading = %q, want %q", word.Kanji, word.Reading, want[i])
+		}
+	}
+	if words[1].Romaji != "benkyō" {
+		t.Errorf("BackfillReadings() changed romaji to %q", words[1].Romaji)
+	}
+
+	// Words that could not be filled in are tried again, and left alone
+	if updated, _ := service.BackfillReadings(ctx); updated != 0 {
+		t.Errorf("Second BackfillReadings() updated %d words, want 0", updated)
+	}
+}
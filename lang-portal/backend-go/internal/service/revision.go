@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+var (
+	// ErrRevisionNotFound is returned for a revision that is not in the
+	// history of the entity it is looked up for
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrNothingToRevert is returned when reverting to a deletion, which left
+	// nothing to restore
+	ErrNothingToRevert = errors.New("revision has nothing to revert to")
+)
+
+type ListRevisionsResult struct {
+	Revisions   []*models.Revision
+	TotalItems  int
+	CurrentPage int
+	TotalPages  int
+}
+
+// listRevisions returns a page of the history of an entity, newest first
+func listRevisions(ctx context.Context, revisionRepo repository.RevisionRepository, kind models.RevisionKind, id int64, page, pageSize int) (*ListRevisionsResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	revisions, total, err := revisionRepo.List(ctx, kind, id, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error listing revisions: %v", err)
+	}
+
+	return &ListRevisionsResult{
+		Revisions:   revisions,
+		TotalItems:  total,
+		CurrentPage: page,
+		TotalPages:  (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// loadRevision decodes the state a revision of an entity left it in into v,
+// and returns a context whose writes are recorded as reverting to that
+// revision. The author of the revert is kept; its reason defaults to naming
+// the revision.
+func loadRevision(ctx context.Context, revisionRepo repository.RevisionRepository, kind models.RevisionKind, id, revisionID int64, v any) (context.Context, error) {
+	revision, err := revisionRepo.GetByID(ctx, kind, id, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting revision: %v", err)
+	}
+	if revision == nil {
+		return nil, ErrRevisionNotFound
+	}
+	if revision.After == nil {
+		return nil, fmt.Errorf("%w: revision %d is a deletion", ErrNothingToRevert, revisionID)
+	}
+	if err := json.Unmarshal(revision.After, v); err != nil {
+		return nil, fmt.Errorf("error reading revision %d: %v", revisionID, err)
+	}
+
+	change := models.ChangeFromContext(ctx)
+	change.RevertTo = revisionID
+	if change.Reason == "" {
+		change.Reason = fmt.Sprintf("revert to revision %d", revisionID)
+	}
+	return models.WithChange(ctx, change), nil
+}
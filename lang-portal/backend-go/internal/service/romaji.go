@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/transliteration"
+)
+
+// ErrRomajiMismatch is returned when romaji does not spell the kana reading
+// of a word and romaji validation rejects mismatches
+var ErrRomajiMismatch = errors.New("romaji does not match the reading")
+
+// RomajiValidation decides what happens to a word whose romaji does not
+// match its kana reading
+type RomajiValidation string
+
+const (
+	RomajiValidationOff    RomajiValidation = "off"
+	RomajiValidationWarn   RomajiValidation = "warn"
+	RomajiValidationReject RomajiValidation = "reject"
+)
+
+// ParseRomajiValidation returns the validation mode with the given name
+func ParseRomajiValidation(name string) (RomajiValidation, error) {
+	switch mode := RomajiValidation(strings.ToLower(strings.TrimSpace(name))); mode {
+	case RomajiValidationOff, RomajiValidationWarn, RomajiValidationReject:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown romaji validation mode %q", name)
+	}
+}
+
+// RomajiOptions controls how romaji is derived from and checked against the
+// kana reading of a word
+type RomajiOptions struct {
+	Validation RomajiValidation
+	// System is used to fill in missing romaji
+	System transliteration.System
+}
+
+// DefaultRomajiOptions warns about mismatches and fills in romaji in the
+// wāpuro spelling used by the seed data (benkyou, not benkyō)
+func DefaultRomajiOptions() RomajiOptions {
+	return RomajiOptions{Validation: RomajiValidationWarn, System: transliteration.Wapuro}
+}
+
+// checkReading fills in missing romaji from the kana reading of a word, or
+// the reading from romaji when the word has none, and checks supplied romaji
+// against the reading
+func (o RomajiOptions) checkReading(word *models.Word) (string, error) {
+	reading := word.Reading
+	if reading == "" {
+		readingFromRomaji(word)
+		return "", nil
+	}
+
+	word.Romaji = strings.TrimSpace(word.Romaji)
+	if word.Romaji == "" {
+		romaji, err := transliteration.Romanize(reading, o.System)
+		if err != nil {
+			return "", fmt.Errorf("error romanizing %s: %v", reading, err)
+		}
+		word.Romaji = romaji
+		return "", nil
+	}
+
+	if o.Validation == RomajiValidationOff || transliteration.Matches(word.Romaji, reading) {
+		return "", nil
+	}
+	mismatch := fmt.Errorf("%w: %q is not a romanization of %s", ErrRomajiMismatch, word.Romaji, reading)
+	if o.Validation == RomajiValidationReject {
+		return "", mismatch
+	}
+	return mismatch.Error(), nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/transliteration"
+)
+
+func TestWordService_CreateWordRomaji(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		options     RomajiOptions
+		word        *models.Word
+		wantRomaji  string
+		wantWarning bool
+		wantErr     error
+	}{
+		{
+			name:       "romaji filled in from kana",
+			options:    DefaultRomajiOptions(),
+			word:       &models.Word{Kanji: "べんきょう", English: "study", Parts: map[string]any{}},
+			wantRomaji: "benkyou",
+		},
+		{
+			name:       "romaji filled in from parts.reading in hepburn",
+			options:    RomajiOptions{Validation: RomajiValidationWarn, System: transliteration.Hepburn},
+			word:       &models.Word{Kanji: "勉強", English: "study", Parts: map[string]any{"reading": "べんきょう"}},
+			wantRomaji: "benkyō",
+		},
+		{
+			name:       "other spellings of the reading match",
+			options:    RomajiOptions{Validation: RomajiValidationReject, System: transliteration.Wapuro},
+			word:       &models.Word{Kanji: "勉強", Romaji: "Benkyō", English: "study", Parts: map[string]any{"reading": "べんきょう"}},
+			wantRomaji: "Benkyō",
+		},
+		{
+			name:        "mismatch is a warning",
+			options:     DefaultRomajiOptions(),
+			word:        &models.Word{Kanji: "たべる", Romaji: "nomu", English: "to eat", Parts: map[string]any{}},
+			wantRomaji:  "nomu",
+			wantWarning: true,
+		},
+		{
+			name:    "mismatch is rejected",
+			options: RomajiOptions{Validation: RomajiValidationReject, System: transliteration.Wapuro},
+			word:    &models.Word{Kanji: "たべる", Romaji: "nomu", English: "to eat", Parts: map[string]any{}},
+			wantErr: ErrRomajiMismatch,
+		},
+		{
+			name:       "mismatch is ignored when validation is off",
+			options:    RomajiOptions{Validation: RomajiValidationOff, System: transliteration.Wapuro},
+			word:       &models.Word{Kanji: "たべる", Romaji: "nomu", English: "to eat", Parts: map[string]any{}},
+			wantRomaji: "nomu",
+		},
+		{
+			name:       "kanji without a reading is not checked",
+			options:    RomajiOptions{Validation: RomajiValidationReject, System: transliteration.Wapuro},
+			word:       &models.Word{Kanji: "食べる", Romaji: "nomu", English: "to eat", Parts: map[string]any{}},
+			wantRomaji: "nomu",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewWordService(newMockWordRepository(), NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), NewLanguagePacks(NewJapanesePack(tt.options)))
+			warnings, err := service.CreateWord(ctx, tt.word)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateWord() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.word.Romaji != tt.wantRomaji {
+				t.Errorf("CreateWord() romaji = %q, want %q", tt.word.Romaji, tt.wantRomaji)
+			}
+			if (len(warnings) > 0) != tt.wantWarning {
+				t.Errorf("CreateWord() warnings = %v, want warning %v", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestWordService_CreateWordWithoutRomajiOrReading(t *testing.T) {
+	service := NewWordService(newMockWordRepository(), NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
+	_, err := service.CreateWord(context.Background(), &models.Word{Kanji: "食べる", English: "to eat", Parts: map[string]any{}})
+	if err == nil {
+		t.Error("CreateWord() accepted a kanji word without romaji or reading")
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"time"
+
+	"backend-go/internal/domain/models"
+)
+
+// Grade is how well a word was recalled during a review, on the four-point
+// scale shared by all schedulers.
+type Grade int
+
+const (
+	GradeAgain Grade = iota + 1
+	GradeHard
+	GradeGood
+	GradeEasy
+)
+
+// GradeFromCorrect maps the boolean result stored in word_review_items onto
+// a grade. Reviews only record correct/wrong, so a correct answer counts as
+// Good and a wrong one as Again.
+func GradeFromCorrect(correct bool) Grade {
+	if correct {
+		return GradeGood
+	}
+	return GradeAgain
+}
+
+// Scheduler is a spaced-repetition algorithm. Next receives the prior state
+// of a word (a zero WordSchedule with only WordID set for a word that has
+// never been reviewed), the grade of the review and when it happened, and
+// returns the state after that review.
+type Scheduler interface {
+	Name() string
+	Next(prev models.WordSchedule, grade Grade, now time.Time) models.WordSchedule
+}
+
+// DefaultScheduler is used for groups that have not chosen an algorithm
+const DefaultScheduler = "sm2"
+
+// isNewCard reports whether a schedule belongs to a word that has never
+// been reviewed
+func isNewCard(s models.WordSchedule) bool {
+	return s.LastReviewedAt.IsZero()
+}
+
+// nextState copies the fields common to every scheduler from prev
+func nextState(prev models.WordSchedule, algorithm string, now time.Time) models.WordSchedule {
+	return models.WordSchedule{
+		WordID:         prev.WordID,
+		Algorithm:      algorithm,
+		Repetitions:    prev.Repetitions,
+		Lapses:         prev.Lapses,
+		LastReviewedAt: now,
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"backend-go/internal/domain/models"
+)
+
+// fsrsWeights are the default FSRS-4.5 model parameters
+var fsrsWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+const (
+	fsrsDecay            = -0.5
+	fsrsFactor           = 19.0 / 81.0 // 0.9^(1/decay) - 1
+	fsrsDesiredRetention = 0.9
+	fsrsMaxIntervalDays  = 36500
+)
+
+// FSRSScheduler implements the Free Spaced Repetition Scheduler (FSRS-4.5),
+// which models each word's memory stability and difficulty and schedules the
+// next review for when recall probability drops to the desired retention.
+type FSRSScheduler struct{}
+
+func (FSRSScheduler) Name() string { return "fsrs" }
+
+func (FSRSScheduler) Next(prev models.WordSchedule, grade Grade, now time.Time) models.WordSchedule {
+	w := fsrsWeights
+	next := nextState(prev, "fsrs", now)
+
+	if isNewCard(prev) || prev.Stability == 0 {
+		next.Stability = w[grade-1]
+		next.Difficulty = fsrsInitialDifficulty(grade)
+	} else {
+		elapsedDays := now.Sub(prev.LastReviewedAt).Hours() / 24
+		if elapsedDays < 0 {
+			elapsedDays = 0
+		}
+		retrievability := math.Pow(1+fsrsFactor*elapsedDays/prev.Stability, fsrsDecay)
+
+		next.Difficulty = fsrsNextDifficulty(prev.Difficulty, grade)
+		if grade == GradeAgain {
+			next.Stability = w[11] *
+				math.Pow(prev.Difficulty, -w[12]) *
+				(math.Pow(prev.Stability+1, w[13]) - 1) *
+				math.Exp(w[14]*(1-retrievability))
+			if next.Stability > prev.Stability {
+				next.Stability = prev.Stability
+			}
+		} else {
+			hardPenalty, easyBonus := 1.0, 1.0
+			if grade == GradeHard {
+				hardPenalty = w[15]
+			}
+			if grade == GradeEasy {
+				easyBonus = w[16]
+			}
+			next.Stability = prev.Stability * (1 + math.Exp(w[8])*
+				(11-prev.Difficulty)*
+				math.Pow(prev.Stability, -w[9])*
+				(math.Exp(w[10]*(1-retrievability))-1)*
+				hardPenalty*easyBonus)
+		}
+	}
+
+	if grade == GradeAgain {
+		if next.Repetitions > 0 {
+			next.Lapses++
+		}
+		next.Repetitions = 0
+	} else {
+		next.Repetitions++
+	}
+
+	interval := next.Stability / fsrsFactor * (math.Pow(fsrsDesiredRetention, 1/fsrsDecay) - 1)
+	next.IntervalDays = int(math.Round(interval))
+	if next.IntervalDays < 1 {
+		next.IntervalDays = 1
+	}
+	if next.IntervalDays > fsrsMaxIntervalDays {
+		next.IntervalDays = fsrsMaxIntervalDays
+	}
+	next.DueAt = now.AddDate(0, 0, next.IntervalDays)
+
+	return next
+}
+
+func fsrsInitialDifficulty(grade Grade) float64 {
+	return clampDifficulty(fsrsWeights[4] - float64(grade-3)*fsrsWeights[5])
+}
+
+// fsrsNextDifficulty moves difficulty by the grade and reverts it towards
+// the initial difficulty of a Good answer
+func fsrsNextDifficulty(difficulty float64, grade Grade) float64 {
+	next := difficulty - fsrsWeights[6]*float64(grade-3)
+	next = fsrsWeights[7]*fsrsInitialDifficulty(GradeGood) + (1-fsrsWeights[7])*next
+	return clampDifficulty(next)
+}
+
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, 1), 10)
+}
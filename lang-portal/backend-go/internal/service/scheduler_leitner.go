@@ -0,0 +1,49 @@
+package service
+
+import (
+	"time"
+
+	"backend-go/internal/domain/models"
+)
+
+// leitnerIntervals holds the review interval in days of each box
+var leitnerIntervals = []int{1, 2, 4, 8, 16}
+
+// LeitnerScheduler implements the Leitner box system: a correct answer
+// promotes a word to the next box, a wrong one sends it back to the first.
+type LeitnerScheduler struct{}
+
+func (LeitnerScheduler) Name() string { return "leitner" }
+
+func (LeitnerScheduler) Next(prev models.WordSchedule, grade Grade, now time.Time) models.WordSchedule {
+	next := nextState(prev, "leitner", now)
+	next.Box = prev.Box
+	if next.Box < 1 {
+		next.Box = 1
+	}
+
+	switch grade {
+	case GradeAgain:
+		if next.Repetitions > 0 {
+			next.Lapses++
+		}
+		next.Repetitions = 0
+		next.Box = 1
+	case GradeHard:
+		next.Repetitions++
+	case GradeGood:
+		next.Repetitions++
+		next.Box++
+	case GradeEasy:
+		next.Repetitions++
+		next.Box += 2
+	}
+	if next.Box > len(leitnerIntervals) {
+		next.Box = len(leitnerIntervals)
+	}
+
+	next.IntervalDays = leitnerIntervals[next.Box-1]
+	next.DueAt = now.AddDate(0, 0, next.IntervalDays)
+
+	return next
+}
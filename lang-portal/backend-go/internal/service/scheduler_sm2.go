@@ -0,0 +1,70 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"backend-go/internal/domain/models"
+)
+
+const (
+	sm2InitialEaseFactor = 2.5
+	sm2MinEaseFactor     = 1.3
+)
+
+// SM2Scheduler implements the SuperMemo SM-2 algorithm
+type SM2Scheduler struct{}
+
+func (SM2Scheduler) Name() string { return "sm2" }
+
+func (SM2Scheduler) Next(prev models.WordSchedule, grade Grade, now time.Time) models.WordSchedule {
+	next := nextState(prev, "sm2", now)
+	next.EaseFactor = prev.EaseFactor
+	next.IntervalDays = prev.IntervalDays
+	if next.EaseFactor == 0 {
+		next.EaseFactor = sm2InitialEaseFactor
+	}
+
+	quality := sm2Quality(grade)
+	if quality >= 3 {
+		switch next.Repetitions {
+		case 0:
+			next.IntervalDays = 1
+		case 1:
+			next.IntervalDays = 6
+		default:
+			next.IntervalDays = int(math.Round(float64(next.IntervalDays) * next.EaseFactor))
+		}
+		next.Repetitions++
+	} else {
+		if next.Repetitions > 0 {
+			next.Lapses++
+		}
+		next.Repetitions = 0
+		next.IntervalDays = 1
+	}
+
+	q := float64(5 - quality)
+	next.EaseFactor += 0.1 - q*(0.08+q*0.02)
+	if next.EaseFactor < sm2MinEaseFactor {
+		next.EaseFactor = sm2MinEaseFactor
+	}
+
+	next.DueAt = now.AddDate(0, 0, next.IntervalDays)
+
+	return next
+}
+
+// sm2Quality maps a grade onto the SM-2 0-5 quality scale
+func sm2Quality(grade Grade) int {
+	switch grade {
+	case GradeAgain:
+		return 1
+	case GradeHard:
+		return 3
+	case GradeEasy:
+		return 5
+	default:
+		return 4
+	}
+}
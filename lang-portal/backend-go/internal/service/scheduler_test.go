@@ -0,0 +1,106 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"backend-go/internal/domain/models"
+)
+
+func review(s Scheduler, prev models.WordSchedule, grade Grade) models.WordSchedule {
+	now := prev.DueAt
+	if now.IsZero() {
+		now = time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	}
+	return s.Next(prev, grade, now)
+}
+
+func TestSM2Scheduler_Progression(t *testing.T) {
+	s := SM2Scheduler{}
+
+	first := review(s, models.WordSchedule{WordID: 1}, GradeGood)
+	if first.IntervalDays != 1 || first.Repetitions != 1 {
+		t.Fatalf("first review got interval %d reps %d, want 1 and 1", first.IntervalDays, first.Repetitions)
+	}
+	if !first.DueAt.Equal(first.LastReviewedAt.AddDate(0, 0, 1)) {
+		t.Errorf("first review due at %v, want one day after %v", first.DueAt, first.LastReviewedAt)
+	}
+
+	second := review(s, first, GradeGood)
+	if second.IntervalDays != 6 {
+		t.Errorf("second review got interval %d, want 6", second.IntervalDays)
+	}
+
+	third := review(s, second, GradeGood)
+	if third.IntervalDays != 15 {
+		t.Errorf("third review got interval %d, want 15", third.IntervalDays)
+	}
+}
+
+func TestSM2Scheduler_Lapse(t *testing.T) {
+	s := SM2Scheduler{}
+
+	state := review(s, models.WordSchedule{WordID: 1}, GradeGood)
+	state = review(s, state, GradeGood)
+	lapsed := review(s, state, GradeAgain)
+
+	if lapsed.Repetitions != 0 || lapsed.IntervalDays != 1 || lapsed.Lapses != 1 {
+		t.Errorf("lapse got interval %d reps %d lapses %d, want 1, 0 and 1", lapsed.IntervalDays, lapsed.Repetitions, lapsed.Lapses)
+	}
+	if lapsed.EaseFactor >= state.EaseFactor {
+		t.Errorf("lapse should lower ease factor, got %v from %v", lapsed.EaseFactor, state.EaseFactor)
+	}
+
+	for i := 0; i < 10; i++ {
+		lapsed = review(s, lapsed, GradeAgain)
+	}
+	if lapsed.EaseFactor != sm2MinEaseFactor {
+		t.Errorf("ease factor got %v, want floor %v", lapsed.EaseFactor, sm2MinEaseFactor)
+	}
+}
+
+func TestLeitnerScheduler_Boxes(t *testing.T) {
+	s := LeitnerScheduler{}
+
+	state := models.WordSchedule{WordID: 1}
+	for _, want := range []int{2, 3, 4, 5, 5} {
+		state = review(s, state, GradeGood)
+		if state.Box != want {
+			t.Fatalf("got box %d, want %d", state.Box, want)
+		}
+	}
+	if state.IntervalDays != leitnerIntervals[len(leitnerIntervals)-1] {
+		t.Errorf("top box got interval %d, want %d", state.IntervalDays, leitnerIntervals[len(leitnerIntervals)-1])
+	}
+
+	state = review(s, state, GradeAgain)
+	if state.Box != 1 || state.IntervalDays != 1 {
+		t.Errorf("wrong answer got box %d interval %d, want 1 and 1", state.Box, state.IntervalDays)
+	}
+}
+
+func TestFSRSScheduler_StabilityGrowsWithRecall(t *testing.T) {
+	s := FSRSScheduler{}
+
+	state := review(s, models.WordSchedule{WordID: 1}, GradeGood)
+	if state.Stability != fsrsWeights[2] {
+		t.Fatalf("first Good review got stability %v, want %v", state.Stability, fsrsWeights[2])
+	}
+	if state.IntervalDays != 4 {
+		t.Errorf("first Good review got interval %d, want 4", state.IntervalDays)
+	}
+
+	recalled := review(s, state, GradeGood)
+	if recalled.Stability <= state.Stability || recalled.IntervalDays <= state.IntervalDays {
+		t.Errorf("recall should grow stability and interval, got %v/%d from %v/%d",
+			recalled.Stability, recalled.IntervalDays, state.Stability, state.IntervalDays)
+	}
+
+	forgotten := review(s, recalled, GradeAgain)
+	if forgotten.Stability >= recalled.Stability || forgotten.Lapses != 1 {
+		t.Errorf("lapse should shrink stability and count a lapse, got %v lapses %d", forgotten.Stability, forgotten.Lapses)
+	}
+	if forgotten.Difficulty <= recalled.Difficulty {
+		t.Errorf("lapse should raise difficulty, got %v from %v", forgotten.Difficulty, recalled.Difficulty)
+	}
+}
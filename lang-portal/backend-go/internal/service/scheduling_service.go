@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+// ErrUnknownScheduler is returned when a scheduler name is not registered
+var ErrUnknownScheduler = errors.New("unknown scheduler")
+
+const (
+	defaultReviewQueueLimit = 20
+	maxReviewQueueLimit     = 100
+)
+
+// SchedulingService keeps the spaced-repetition schedule of every word up to
+// date using the scheduler configured for the group being studied
+type SchedulingService struct {
+	tx           repository.Transactor
+	scheduleRepo repository.ScheduleRepository
+	settingsRepo repository.GroupSettingsRepository
+	groupRepo    repository.GroupRepository
+	schedulers   map[string]Scheduler
+}
+
+func NewSchedulingService(
+	tx repository.Transactor,
+	scheduleRepo repository.ScheduleRepository,
+	settingsRepo repository.GroupSettingsRepository,
+	groupRepo repository.GroupRepository,
+) *SchedulingService {
+	s := &SchedulingService{
+		tx:           tx,
+		scheduleRepo: scheduleRepo,
+		settingsRepo: settingsRepo,
+		groupRepo:    groupRepo,
+		schedulers:   make(map[string]Scheduler),
+	}
+	for _, scheduler := range []Scheduler{SM2Scheduler{}, LeitnerScheduler{}, FSRSScheduler{}} {
+		s.schedulers[scheduler.Name()] = scheduler
+	}
+	return s
+}
+
+// Schedulers returns the names of the available scheduling algorithms
+func (s *SchedulingService) Schedulers() []string {
+	names := make([]string, 0, len(s.schedulers))
+	for name := range s.schedulers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetGroupScheduler returns the name of the scheduler used by a group
+func (s *SchedulingService) GetGroupScheduler(ctx context.Context, groupID int64) (string, error) {
+	if err := s.verifyGroup(ctx, groupID); err != nil {
+		return "", err
+	}
+	scheduler, err := s.schedulerFor(ctx, groupID)
+	if err != nil {
+		return "", err
+	}
+	return scheduler.Name(), nil
+}
+
+type ReplayResult struct {
+	Scheduler        string `json:"scheduler,omitempty"`
+	ReviewsReplayed  int    `json:"reviews_replayed"`
+	WordsRescheduled int    `json:"words_rescheduled"`
+}
+
+// SetGroupScheduler switches the algorithm used by a group and, when it
+// changes, rebuilds the schedule of the group's words from their review
+// history so no progress is lost. A failed replay keeps the old algorithm.
+func (s *SchedulingService) SetGroupScheduler(ctx context.Context, groupID int64, name string) (*ReplayResult, error) {
+	if _, ok := s.schedulers[name]; !ok {
+		return nil, ErrUnknownScheduler
+	}
+
+	result := &ReplayResult{Scheduler: name}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.GetGroupScheduler(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.settingsRepo.SetScheduler(ctx, groupID, name); err != nil {
+			return fmt.Errorf("error saving group scheduler: %v", err)
+		}
+
+		if current == name {
+			return nil
+		}
+		result, err = s.ReplayGroup(ctx, groupID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReplayGroup recomputes the schedule of the words in a group by feeding
+// their full review history through the group's scheduler. As in
+// RecordReview, words last reviewed in another group are left to that
+// group's scheduler.
+func (s *SchedulingService) ReplayGroup(ctx context.Context, groupID int64) (*ReplayResult, error) {
+	var result *ReplayResult
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.verifyGroup(ctx, groupID); err != nil {
+			return err
+		}
+
+		scheduler, err := s.schedulerFor(ctx, groupID)
+		if err != nil {
+			return err
+		}
+
+		history, err := s.scheduleRepo.ListHistory(ctx, groupID)
+		if err != nil {
+			return fmt.Errorf("error loading review history: %v", err)
+		}
+		lastGroups, err := s.scheduleRepo.ListLastReviewGroups(ctx)
+		if err != nil {
+			return fmt.Errorf("error loading reviewed groups: %v", err)
+		}
+
+		owned := history[:0:0]
+		for _, review := range history {
+			if lastGroups[review.WordID] == groupID {
+				owned = append(owned, review)
+			}
+		}
+
+		result, err = s.replay(ctx, scheduler, owned)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReplayAll recomputes the schedule of every reviewed word from its full
+// history. As in RecordReview, a word that belongs to several groups is
+// scheduled by the group it was last reviewed in.
+func (s *SchedulingService) ReplayAll(ctx context.Context) (*ReplayResult, error) {
+	total := &ReplayResult{}
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		history, err := s.scheduleRepo.ListHistory(ctx, 0)
+		if err != nil {
+			return fmt.Errorf("error loading review history: %v", err)
+		}
+		lastGroups, err := s.scheduleRepo.ListLastReviewGroups(ctx)
+		if err != nil {
+			return fmt.Errorf("error loading reviewed groups: %v", err)
+		}
+
+		// Split the history by the scheduler each word is replayed with,
+		// keeping every word's reviews together and in order
+		schedulers := make(map[int64]Scheduler)
+		histories := make(map[string][]*models.WordReviewItem)
+		for _, review := range history {
+			groupID := lastGroups[review.WordID]
+			scheduler, ok := schedulers[groupID]
+			if !ok {
+				if scheduler, err = s.schedulerFor(ctx, groupID); err != nil {
+					return err
+				}
+				schedulers[groupID] = scheduler
+			}
+			histories[scheduler.Name()] = append(histories[scheduler.Name()], review)
+		}
+
+		for _, name := range s.Schedulers() {
+			if len(histories[name]) == 0 {
+				continue
+			}
+			result, err := s.replay(ctx, s.schedulers[name], histories[name])
+			if err != nil {
+				return err
+			}
+			total.ReviewsReplayed += result.ReviewsReplayed
+			total.WordsRescheduled += result.WordsRescheduled
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return total, nil
+}
+
+// RecordReview advances the schedule of the reviewed word using the
+// scheduler of the group the review was made in
+func (s *SchedulingService) RecordReview(ctx context.Context, groupID int64, review *models.WordReviewItem) error {
+	scheduler, err := s.schedulerFor(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	prev, err := s.scheduleRepo.Get(ctx, review.WordID)
+	if err != nil {
+		return fmt.Errorf("error getting word schedule: %v", err)
+	}
+
+	// The word was last scheduled by a different algorithm: rebuild its
+	// state from history, which already includes this review.
+	if prev != nil && prev.Algorithm != scheduler.Name() {
+		history, err := s.scheduleRepo.ListWordHistory(ctx, review.WordID)
+		if err != nil {
+			return fmt.Errorf("error loading review history: %v", err)
+		}
+		_, err = s.replay(ctx, scheduler, history)
+		return err
+	}
+
+	state := models.WordSchedule{WordID: review.WordID}
+	if prev != nil {
+		state = *prev
+	}
+
+	reviewedAt := review.CreatedAt
+	if reviewedAt.IsZero() {
+		reviewedAt = time.Now()
+	}
+
+	next := scheduler.Next(state, GradeFromCorrect(review.Correct), reviewedAt.UTC())
+	if err := s.scheduleRepo.Upsert(ctx, &next); err != nil {
+		return fmt.Errorf("error updating word schedule: %v", err)
+	}
+
+	return nil
+}
+
+// GetReviewQueue returns the words due for review, most urgent first.
+// A groupID of 0 draws from all words and an empty language from every
+// language.
+func (s *SchedulingService) GetReviewQueue(ctx context.Context, groupID int64, language string, limit int) ([]*models.DueWord, error) {
+	if limit < 1 {
+		limit = defaultReviewQueueLimit
+	}
+	if limit > maxReviewQueueLimit {
+		limit = maxReviewQueueLimit
+	}
+
+	if groupID != 0 {
+		if err := s.verifyGroup(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	words, err := s.scheduleRepo.ListDue(ctx, groupID, language, time.Now().UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error listing review queue: %v", err)
+	}
+
+	return words, nil
+}
+
+// replay folds a chronologically ordered review history through a
+// scheduler and stores the resulting state of every word
+func (s *SchedulingService) replay(ctx context.Context, scheduler Scheduler, history []*models.WordReviewItem) (*ReplayResult, error) {
+	states := make(map[int64]models.WordSchedule)
+	for _, review := range history {
+		state, ok := states[review.WordID]
+		if !ok {
+			state = models.WordSchedule{WordID: review.WordID}
+		}
+		states[review.WordID] = scheduler.Next(state, GradeFromCorrect(review.Correct), review.CreatedAt.UTC())
+	}
+
+	for _, state := range states {
+		if err := s.scheduleRepo.Upsert(ctx, &state); err != nil {
+			return nil, fmt.Errorf("error updating word schedule: %v", err)
+		}
+	}
+
+	return &ReplayResult{
+		Scheduler:        scheduler.Name(),
+		ReviewsReplayed:  len(history),
+		WordsRescheduled: len(states),
+	}, nil
+}
+
+func (s *SchedulingService) schedulerFor(ctx context.Context, groupID int64) (Scheduler, error) {
+	name, err := s.settingsRepo.GetScheduler(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting group scheduler: %v", err)
+	}
+	if name == "" {
+		name = DefaultScheduler
+	}
+
+	scheduler, ok := s.schedulers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScheduler, name)
+	}
+	return scheduler, nil
+}
+
+func (s *SchedulingService) verifyGroup(ctx context.Context, groupID int64) error {
+	group, err := s.groupRepo.GetByID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("error verifying group: %v", err)
+	}
+	if group == nil {
+		return ErrGroupNotFound
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend-go/internal/domain/models"
+)
+
+func newTestSchedulingService(t *testing.T) (*SchedulingService, *mockScheduleRepository, int64) {
+	groupRepo := NewMockGroupRepository()
+	scheduleRepo := NewMockScheduleRepository()
+	service := NewSchedulingService(NewMockTransactor(), scheduleRepo, NewMockGroupSettingsRepository(), groupRepo)
+
+	group := &models.Group{Name: "Basic Verbs"}
+	if err := groupRepo.Create(context.Background(), group); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i, correct := range []bool{true, true, false, true} {
+		scheduleRepo.history = append(scheduleRepo.history, &models.WordReviewItem{
+			ID:        int64(i + 1),
+			WordID:    1,
+			Correct:   correct,
+			CreatedAt: start.AddDate(0, 0, i*3),
+		})
+	}
+	scheduleRepo.lastGroups[1] = group.ID
+
+	return service, scheduleRepo, group.ID
+}
+
+func TestSchedulingService_SetGroupSchedulerReplaysHistory(t *testing.T) {
+	service, scheduleRepo, groupID := newTestSchedulingService(t)
+	ctx := context.Background()
+
+	result, err := service.SetGroupScheduler(ctx, groupID, "leitner")
+	if err != nil {
+		t.Fatalf("SetGroupScheduler() error = %v", err)
+	}
+	if result.ReviewsReplayed != 4 || result.WordsRescheduled != 1 {
+		t.Errorf("SetGroupScheduler() replayed %d reviews for %d words, want 4 and 1", result.ReviewsReplayed, result.WordsRescheduled)
+	}
+
+	schedule := scheduleRepo.schedules[1]
+	if schedule.Algorithm != "leitner" || schedule.Box != 2 || schedule.Lapses != 1 {
+		t.Errorf("SetGroupScheduler() got %+v, want leitner box 2 with 1 lapse", schedule)
+	}
+
+	name, err := service.GetGroupScheduler(ctx, groupID)
+	if err != nil || name != "leitner" {
+		t.Errorf("GetGroupScheduler() = %q, %v, want leitner", name, err)
+	}
+}
+
+func TestSchedulingService_SetGroupSchedulerErrors(t *testing.T) {
+	service, _, groupID := newTestSchedulingService(t)
+	ctx := context.Background()
+
+	if _, err := service.SetGroupScheduler(ctx, groupID, "anki"); !errors.Is(err, ErrUnknownScheduler) {
+		t.Errorf("SetGroupScheduler() error = %v, want %v", err, ErrUnknownScheduler)
+	}
+	if _, err := service.SetGroupScheduler(ctx, 42, "fsrs"); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("SetGroupScheduler() error = %v, want %v", err, ErrGroupNotFound)
+	}
+}
+
+func TestSchedulingService_GetReviewQueueUnknownGroup(t *testing.T) {
+	service, _, _ := newTestSchedulingService(t)
+
+	if _, err := service.GetReviewQueue(context.Background(), 42, "", 10); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("GetReviewQueue() error = %v, want %v", err, ErrGroupNotFound)
+	}
+}
@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+// ErrUnknownSeedProfile is returned when no seed pack has the requested name
+var ErrUnknownSeedProfile = errors.New("unknown seed profile")
+
+// DefaultSeedProfile is loaded when no profile is requested
+const DefaultSeedProfile = "core-jlpt5"
+
+// seedReviewSpacing separates the reviews of a seeded session
+const seedReviewSpacing = 15 * time.Second
+
+// SeedPack is one JSON file in the seeds directory. Its file name without the
+// .json extension is the profile name; packs listed in Include are loaded first.
+type SeedPack struct {
+	Description string         `json:"description"`
+	Include     []string       `json:"include"`
+	Activities  []SeedActivity `json:"activities"`
+	Words       []SeedWord     `json:"words"`
+	Groups      []SeedGroup    `json:"groups"`
+	Sessions    []SeedSession  `json:"sessions"`
+}
+
+type SeedActivity struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type SeedWord struct {
+	Kanji   string         `json:"kanji"`
+	Romaji  string         `json:"romaji"`
+	English string         `json:"english"`
+	Parts   map[string]any `json:"parts"`
+}
+
+// SeedWordRef points at a word by its kanji and romaji
+type SeedWordRef struct {
+	Kanji  string `json:"kanji"`
+	Romaji string `json:"romaji"`
+}
+
+type SeedGroup struct {
+	Name  string        `json:"name"`
+	Words []SeedWordRef `json:"words"`
+}
+
+// SeedSession is a past study session. StartedAt identifies the session, so
+// loading a pack twice does not duplicate its history.
+type SeedSession struct {
+	Group     string       `json:"group"`
+	Activity  string       `json:"activity"`
+	StartedAt time.Time    `json:"started_at"`
+	Reviews   []SeedReview `json:"reviews"`
+}
+
+type SeedReview struct {
+	SeedWordRef
+	Correct bool `json:"correct"`
+}
+
+// SeedCounts tells how many records of one kind a load created, changed or
+// found already in place
+type SeedCounts struct {
+	Inserted       int `json:"inserted"`
+	Updated        int `json:"updated"`
+	AlreadyPresent int `json:"already_present"`
+}
+
+// SeedReport summarizes a profile load
+type SeedReport struct {
+	Profile    string     `json:"profile"`
+	Packs      []string   `json:"packs"`
+	Activities SeedCounts `json:"study_activities"`
+	Words      SeedCounts `json:"words"`
+	Groups     SeedCounts `json:"groups"`
+	GroupWords SeedCounts `json:"group_words"`
+	Sessions   SeedCounts `json:"study_sessions"`
+	Reviews    SeedCounts `json:"word_review_items"`
+}
+
+// SeedService loads seed packs through the repositories. Every record is
+// matched on its natural key first, so loading a profile again only fills in
+// what is missing or changed.
+type SeedService struct {
+	seeds        fs.FS
+	wordRepo     repository.WordRepository
+	groupRepo    repository.GroupRepository
+	activityRepo repository.StudyActivityRepository
+	sessionRepo  repository.StudySessionRepository
+	scheduling   *SchedulingService
+	packs        *LanguagePacks
+}
+
+func NewSeedService(
+	seeds fs.FS,
+	wordRepo repository.WordRepository,
+	groupRepo repository.GroupRepository,
+	activityRepo repository.StudyActivityRepository,
+	sessionRepo repository.StudySessionRepository,
+	scheduling *SchedulingService,
+	packs *LanguagePacks,
+) *SeedService {
+	return &SeedService{
+		seeds:        seeds,
+		wordRepo:     wordRepo,
+		groupRepo:    groupRepo,
+		activityRepo: activityRepo,
+		sessionRepo:  sessionRepo,
+		scheduling:   scheduling,
+		packs:        packs,
+	}
+}
+
+// Profiles lists the names of the available seed packs
+func (s *SeedService) Profiles() ([]string, error) {
+	matches, err := fs.Glob(s.seeds, "*.json")
+	if err != nil {
+		return nil, fmt.Errorf("error listing seed packs: %v", err)
+	}
+
+	profiles := make([]string, 0, len(matches))
+	for _, match := range matches {
+		profiles = append(profiles, strings.TrimSuffix(match, path.Ext(match)))
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// LoadProfile loads the named pack and the packs it includes. An empty name
+// loads DefaultSeedProfile.
+func (s *SeedService) LoadProfile(ctx context.Context, profile string) (*SeedReport, error) {
+	if profile == "" {
+		profile = DefaultSeedProfile
+	}
+
+	var names []string
+	packs := make(map[string]*SeedPack)
+	if err := s.resolvePack(profile, nil, packs, &names); err != nil {
+		return nil, err
+	}
+
+	report := &SeedReport{Profile: profile, Packs: names}
+	for _, name := range names {
+		if err := s.loadPack(ctx, packs[name], report); err != nil {
+			return report, fmt.Errorf("error loading seed pack %s: %v", name, err)
+		}
+	}
+
+	// Seeded reviews bypass the scheduler, so rebuild schedules from history
+	if report.Reviews.Inserted > 0 && s.scheduling != nil {
+		if _, err := s.scheduling.ReplayAll(ctx); err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// resolvePack reads name and, depth first, the packs it includes, appending
+// each to order after its includes
+func (s *SeedService) resolvePack(name string, stack []string, packs map[string]*SeedPack, order *[]string) error {
+	for _, parent := range stack {
+		if parent == name {
+			return fmt.Errorf("seed pack %s includes itself through %s", name, strings.Join(stack, " -> "))
+		}
+	}
+	if _, done := packs[name]; done {
+		return nil
+	}
+
+	content, err := fs.ReadFile(s.seeds, name+".json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrUnknownSeedProfile, name)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading seed pack %s: %v", name, err)
+	}
+
+	pack := &SeedPack{}
+	if err := json.Unmarshal(content, pack); err != nil {
+		return fmt.Errorf("error parsing seed pack %s: %v", name, err)
+	}
+
+	for _, include := range pack.Include {
+		if err := s.resolvePack(include, append(stack, name), packs, order); err != nil {
+			return err
+		}
+	}
+
+	packs[name] = pack
+	*order = append(*order, name)
+	return nil
+}
+
+func (s *SeedService) loadPack(ctx context.Context, pack *SeedPack, report *SeedReport) error {
+	for _, seed := range pack.Activities {
+		if err := s.upsertActivity(ctx, seed, &report.Activities); err != nil {
+			return err
+		}
+	}
+	for _, seed := range pack.Words {
+		if err := s.upsertWord(ctx, seed, &report.Words); err != nil {
+			return err
+		}
+	}
+	for _, seed := range pack.Groups {
+		if err := s.upsertGroup(ctx, seed, report); err != nil {
+			return err
+		}
+	}
+	for _, seed := range pack.Sessions {
+		if err := s.insertSession(ctx, seed, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SeedService) upsertActivity(ctx context.Context, seed SeedActivity, counts *SeedCounts) error {
+	activity := &models.StudyActivity{Name: seed.Name, URL: seed.URL}
+	if err := validateActivity(activity); err != nil {
+		return fmt.Errorf("invalid study activity %q: %v", seed.Name, err)
+	}
+
+	existing, err := s.activityRepo.GetByName(ctx, seed.Name)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case existing == nil:
+		if err := s.activityRepo.Create(ctx, activity); err != nil {
+			return err
+		}
+		counts.Inserted++
+	case existing.URL != seed.URL:
+		activity.ID = existing.ID
+		if err := s.activityRepo.Update(ctx, activity); err != nil {
+			return err
+		}
+		counts.Updated++
+	default:
+		counts.AlreadyPresent++
+	}
+	return nil
+}
+
+// upsertWord creates a seeded word, or updates the english and parts of the
+// word with the same kanji and romaji, keeping the rest of it. Words are
+// prepared by the Japanese language pack either way.
+func (s *SeedService) upsertWord(ctx context.Context, seed SeedWord, counts *SeedCounts) error {
+	word := &models.Word{
+		Language: models.DefaultLanguage,
+		Kanji:    seed.Kanji,
+		Romaji:   seed.Romaji,
+		English:  seed.English,
+		Parts:    maps.Clone(seed.Parts),
+	}
+	if word.Parts == nil {
+		word.Parts = map[string]any{}
+	}
+	if _, err := s.packs.PrepareWord(word); err != nil {
+		return fmt.Errorf("invalid word %s (%s): %v", seed.Kanji, seed.Romaji, err)
+	}
+
+	existing, err := s.wordRepo.GetByKanjiRomaji(ctx, word.Kanji, word.Romaji, word.Language)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		if err := s.wordRepo.Create(ctx, word); err != nil {
+			return err
+		}
+		counts.Inserted++
+		return nil
+	}
+
+	updated := *existing
+	updated.English = seed.English
+	updated.Parts = maps.Clone(seed.Parts)
+	if updated.Parts == nil {
+		updated.Parts = map[string]any{}
+	}
+	if _, err := s.packs.PrepareWord(&updated); err != nil {
+		return fmt.Errorf("invalid word %s (%s): %v", seed.Kanji, seed.Romaji, err)
+	}
+	if updated.English == existing.English && updated.Reading == existing.Reading &&
+		reflect.DeepEqual(updated.Parts, existing.Parts) && reflect.DeepEqual(updated.Ruby, existing.Ruby) {
+		counts.AlreadyPresent++
+		return nil
+	}
+	if err := s.wordRepo.Update(ctx, &updated); err != nil {
+		return err
+	}
+	counts.Updated++
+	return nil
+}
+
+func (s *SeedService) upsertGroup(ctx context.Context, seed SeedGroup, report *SeedReport) error {
+	group := &models.Group{Name: seed.Name}
+	if err := validateGroup(group); err != nil {
+		return fmt.Errorf("invalid group: %v", err)
+	}
+
+	existing, err := s.groupRepo.GetByName(ctx, seed.Name)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		if err := s.groupRepo.Create(ctx, group); err != nil {
+			return err
+		}
+		report.Groups.Inserted++
+	} else {
+		if err := checkNotSmart(existing); err != nil {
+			return fmt.Errorf("group %q: %w", seed.Name, err)
+		}
+		group = existing
+		report.Groups.AlreadyPresent++
+	}
+
+	for _, ref := range seed.Words {
+		word, err := s.findWord(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("group %q: %v", seed.Name, err)
+		}
+
+		member, err := s.groupRepo.HasWord(ctx, group.ID, word.ID)
+		if err != nil {
+			return err
+		}
+		if member {
+			report.GroupWords.AlreadyPresent++
+			continue
+		}
+		if err := s.groupRepo.AddWord(ctx, group.ID, word.ID); err != nil {
+			return err
+		}
+		report.GroupWords.Inserted++
+	}
+	return nil
+}
+
+func (s *SeedService) insertSession(ctx context.Context, seed SeedSession, report *SeedReport) error {
+	if seed.StartedAt.IsZero() {
+		return fmt.Errorf("study session for group %q has no started_at", seed.Group)
+	}
+	startedAt := seed.StartedAt.UTC().Truncate(time.Second)
+
+	group, err := s.groupRepo.GetByName(ctx, seed.Group)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		return fmt.Errorf("study session references unknown group %q", seed.Group)
+	}
+	activity, err := s.activityRepo.GetByName(ctx, seed.Activity)
+	if err != nil {
+		return err
+	}
+	if activity == nil {
+		return fmt.Errorf("study session references unknown study activity %q", seed.Activity)
+	}
+
+	existing, err := s.sessionRepo.FindSession(ctx, group.ID, activity.ID, startedAt)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		report.Sessions.AlreadyPresent++
+		report.Reviews.AlreadyPresent += len(seed.Reviews)
+		return nil
+	}
+
+	// Resolve every word before writing so a bad reference leaves no partial session
+	words := make([]*models.Word, len(seed.Reviews))
+	for i, review := range seed.Reviews {
+		if words[i], err = s.findWord(ctx, review.SeedWordRef); err != nil {
+			return fmt.Errorf("study session at %s: %v", startedAt.Format(time.RFC3339), err)
+		}
+	}
+
+	session := &models.StudySession{
+		GroupID:         group.ID,
+		StudyActivityID: activity.ID,
+		CreatedAt:       startedAt,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return err
+	}
+	report.Sessions.Inserted++
+
+	for i, review := range seed.Reviews {
+		item := &models.WordReviewItem{
+			WordID:         words[i].ID,
+			StudySessionID: session.ID,
+			Correct:        review.Correct,
+			CreatedAt:      startedAt.Add(time.Duration(i+1) * seedReviewSpacing),
+		}
+		if err := s.sessionRepo.AddReview(ctx, item); err != nil {
+			return err
+		}
+		report.Reviews.Inserted++
+	}
+	return nil
+}
+
+func (s *SeedService) findWord(ctx context.Context, ref SeedWordRef) (*models.Word, error) {
+	word, err := s.wordRepo.GetByKanjiRomaji(ctx, ref.Kanji, ref.Romaji, models.DefaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+	if word == nil {
+		return nil, fmt.Errorf("unknown word %s (%s)", ref.Kanji, ref.Romaji)
+	}
+	return word, nil
+}
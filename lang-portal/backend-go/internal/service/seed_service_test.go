@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+const testBasePack = `{
+	"activities": [{"name": "Flashcards", "url": "http://localhost:8081"}],
+	"words": [
+		{"kanji": "食べる", "romaji": "taberu", "english": "to eat", "parts": {"verb_type": "ichidan"}},
+		{"kanji": "飲む", "romaji": "nomu", "english": "to drink", "parts": {"verb_type": "godan"}}
+	],
+	"groups": [{"name": "Verbs", "words": [{"kanji": "食べる", "romaji": "taberu"}, {"kanji": "飲む", "romaji": "nomu"}]}]
+}`
+
+const testHistoryPack = `{
+	"include": ["base"],
+	"sessions": [{
+		"group": "Verbs",
+		"activity": "Flashcards",
+		"started_at": "2025-01-06T09:00:00Z",
+		"reviews": [{"kanji": "食べる", "romaji": "taberu", "correct": true}, {"kanji": "飲む", "romaji": "nomu", "correct": false}]
+	}]
+}`
+
+func newTestSeedService(files fstest.MapFS) (*SeedService, *mockWordRepository) {
+	wordRepo := NewMockWordRepository()
+	groupRepo := NewMockGroupRepository()
+	scheduling := NewSchedulingService(NewMockTransactor(), NewMockScheduleRepository(), NewMockGroupSettingsRepository(), groupRepo)
+	service := NewSeedService(files, wordRepo, groupRepo, NewMockStudyActivityRepository(), NewMockStudySessionRepository(), scheduling, DefaultLanguagePacks())
+	return service, wordRepo
+}
+
+func TestSeedService_LoadProfileIsIdempotent(t *testing.T) {
+	service, _ := newTestSeedService(fstest.MapFS{
+		"base.json":    {Data: []byte(testBasePack)},
+		"history.json": {Data: []byte(testHistoryPack)},
+	})
+	ctx := context.Background()
+
+	report, err := service.LoadProfile(ctx, "history")
+	if err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	if len(report.Packs) != 2 || report.Packs[0] != "base" || report.Packs[1] != "history" {
+		t.Errorf("Expected packs [base history], got %v", report.Packs)
+	}
+	if report.Words.Inserted != 2 || report.GroupWords.Inserted != 2 || report.Sessions.Inserted != 1 || report.Reviews.Inserted != 2 {
+		t.Errorf("Unexpected counts on first load: %+v", report)
+	}
+
+	report, err = service.LoadProfile(ctx, "history")
+	if err != nil {
+		t.Fatalf("Failed to reload profile: %v", err)
+	}
+	if report.Words.Inserted != 0 || report.Words.AlreadyPresent != 2 {
+		t.Errorf("Expected words to be already present, got %+v", report.Words)
+	}
+	if report.GroupWords.AlreadyPresent != 2 || report.Sessions.AlreadyPresent != 1 || report.Reviews.AlreadyPresent != 2 {
+		t.Errorf("Unexpected counts on second load: %+v", report)
+	}
+}
+
+func TestSeedService_LoadProfileUpdatesChangedWords(t *testing.T) {
+	files := fstest.MapFS{"base.json": {Data: []byte(testBasePack)}}
+	service, wordRepo := newTestSeedService(files)
+	ctx := context.Background()
+
+	if _, err := service.LoadProfile(ctx, "base"); err != nil {
+		t.Fatalf("Failed to load profile: %v", err)
+	}
+	word, _ := wordRepo.GetByKanjiRomaji(ctx, "食べる", "taberu", "")
+	if word.Reading != "たべる" || word.SortKey != "たべる" || len(word.Ruby) == 0 {
+		t.Errorf("Expected the reading, sort key and ruby of a seeded word, got %+v", word)
+	}
+	ruby := word.Ruby
+
+	files["base.json"] = &fstest.MapFile{Data: []byte(`{"words": [{"kanji": "食べる", "romaji": "taberu", "english": "to eat, to consume", "parts": {"verb_type": "ichidan"}}]}`)}
+	report, err := service.LoadProfile(ctx, "base")
+	if err != nil {
+		t.Fatalf("Failed to reload profile: %v", err)
+	}
+	if report.Words.Updated != 1 {
+		t.Errorf("Expected 1 updated word, got %+v", report.Words)
+	}
+
+	word, _ = wordRepo.GetByKanjiRomaji(ctx, "食べる", "taberu", "")
+	if word.English != "to eat, to consume" {
+		t.Errorf("Expected updated english, got %q", word.English)
+	}
+	if word.Reading != "たべる" || !reflect.DeepEqual(word.Ruby, ruby) {
+		t.Errorf("Expected the update to keep the reading and ruby, got %q, %v", word.Reading, word.Ruby)
+	}
+}
+
+func TestSeedService_LoadProfileErrors(t *testing.T) {
+	service, _ := newTestSeedService(fstest.MapFS{
+		"a.json":       {Data: []byte(`{"include": ["b"]}`)},
+		"b.json":       {Data: []byte(`{"include": ["a"]}`)},
+		"badref.json":  {Data: []byte(`{"groups": [{"name": "Verbs", "words": [{"kanji": "来る", "romaji": "kuru"}]}]}`)},
+		"invalid.json": {Data: []byte(`{"words": [{"kanji": "来る", "romaji": "kuru"}]}`)},
+	})
+	ctx := context.Background()
+
+	if _, err := service.LoadProfile(ctx, "missing"); !errors.Is(err, ErrUnknownSeedProfile) {
+		t.Errorf("Expected ErrUnknownSeedProfile, got %v", err)
+	}
+	for _, profile := range []string{"a", "badref", "invalid"} {
+		if _, err := service.LoadProfile(ctx, profile); err == nil {
+			t.Errorf("Expected error loading profile %s", profile)
+		}
+	}
+}
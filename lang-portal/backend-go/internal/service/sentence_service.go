@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+var (
+	// ErrSentenceNotFound is returned when a referenced sentence does not exist
+	ErrSentenceNotFound = errors.New("sentence not found")
+	// ErrInvalidSentence is returned for a sentence missing its text or
+	// linked to words that do not exist
+	ErrInvalidSentence = errors.New("invalid sentence")
+)
+
+type SentenceService struct {
+	sentenceRepo repository.SentenceRepository
+	wordRepo     repository.WordRepository
+}
+
+func NewSentenceService(sentenceRepo repository.SentenceRepository, wordRepo repository.WordRepository) *SentenceService {
+	return &SentenceService{
+		sentenceRepo: sentenceRepo,
+		wordRepo:     wordRepo,
+	}
+}
+
+type ListSentencesParams struct {
+	// Query keeps the sentences whose Japanese or English contains it
+	Query    string
+	Page     int
+	PageSize int
+}
+
+type ListSentencesResult struct {
+	Sentences   []*models.Sentence
+	TotalItems  int
+	CurrentPage int
+	TotalPages  int
+}
+
+func (s *SentenceService) ListSentences(ctx context.Context, params ListSentencesParams) (*ListSentencesResult, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+
+	sentences, total, err := s.sentenceRepo.List(ctx, params.Query, params.Page, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sentences: %v", err)
+	}
+
+	return &ListSentencesResult{
+		Sentences:   sentences,
+		TotalItems:  total,
+		CurrentPage: params.Page,
+		TotalPages:  (total + params.PageSize - 1) / params.PageSize,
+	}, nil
+}
+
+// ListWordSentences returns the sentences using a word, shortest first
+func (s *SentenceService) ListWordSentences(ctx context.Context, wordID int64, page, pageSize int) (*ListSentencesResult, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	word, err := s.wordRepo.GetByID(ctx, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting word: %v", err)
+	}
+	if word == nil {
+		return nil, ErrWordNotFound
+	}
+
+	sentences, total, err := s.sentenceRepo.ListByWord(ctx, wordID, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error listing word sentences: %v", err)
+	}
+
+	return &ListSentencesResult{
+		Sentences:   sentences,
+		TotalItems:  total,
+		CurrentPage: page,
+		TotalPages:  (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+func (s *SentenceService) GetSentence(ctx context.Context, id int64) (*models.Sentence, error) {
+	sentence, err := s.sentenceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sentence: %v", err)
+	}
+	if sentence == nil {
+		return nil, ErrSentenceNotFound
+	}
+	return sentence, nil
+}
+
+func (s *SentenceService) CreateSentence(ctx context.Context, sentence *models.Sentence) error {
+	if err := s.validateSentence(ctx, sentence); err != nil {
+		return err
+	}
+
+	if err := s.sentenceRepo.Create(ctx, sentence); err != nil {
+		return fmt.Errorf("error creating sentence: %v", err)
+	}
+	return nil
+}
+
+func (s *SentenceService) UpdateSentence(ctx context.Context, sentence *models.Sentence) error {
+	existing, err := s.GetSentence(ctx, sentence.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.validateSentence(ctx, sentence); err != nil {
+		return err
+	}
+	sentence.CreatedAt = existing.CreatedAt
+
+	if err := s.sentenceRepo.Update(ctx, sentence); err != nil {
+		return fmt.Errorf("error updating sentence: %v", err)
+	}
+	return nil
+}
+
+func (s *SentenceService) DeleteSentence(ctx context.Context, id int64) error {
+	if _, err := s.GetSentence(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.sentenceRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("error deleting sentence: %v", err)
+	}
+	return nil
+}
+
+// validateSentence trims the text fields, requires the Japanese and English
+// and checks that every linked word exists
+func (s *SentenceService) validateSentence(ctx context.Context, sentence *models.Sentence) error {
+	sentence.Japanese = strings.TrimSpace(sentence.Japanese)
+	sentence.Reading = strings.TrimSpace(sentence.Reading)
+	sentence.English = strings.TrimSpace(sentence.English)
+	sentence.Source = strings.TrimSpace(sentence.Source)
+	if sentence.Japanese == "" {
+		return fmt.Errorf("%w: japanese is required", ErrInvalidSentence)
+	}
+	if sentence.English == "" {
+		return fmt.Errorf("%w: english is required", ErrInvalidSentence)
+	}
+
+	seen := make(map[int64]bool, len(sentence.WordIDs))
+	wordIDs := make([]int64, 0, len(sentence.WordIDs))
+	for _, wordID := range sentence.WordIDs {
+		if seen[wordID] {
+			continue
+		}
+		seen[wordID] = true
+
+		word, err := s.wordRepo.GetByID(ctx, wordID)
+		if err != nil {
+			return fmt.Errorf("error getting word: %v", err)
+		}
+		if word == nil {
+			return fmt.Errorf("%w: word %d does not exist", ErrInvalidSentence, wordID)
+		}
+		wordIDs = append(wordIDs, wordID)
+	}
+	sort.Slice(wordIDs, func(i, j int) bool { return wordIDs[i] < wordIDs[j] })
+	sentence.WordIDs = wordIDs
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func TestSentenceService_CreateSentence(t *testing.T) {
+	wordRepo := NewMockWordRepository()
+	sentenceRepo := NewMockSentenceRepository()
+	service := NewSentenceService(sentenceRepo, wordRepo)
+	ctx := context.Background()
+
+	word := &models.Word{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{}}
+	if err := wordRepo.Create(ctx, word); err != nil {
+		t.Fatalf("Failed to create test word: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		sentence models.Sentence
+		wantErr  error
+	}{
+		{"valid", models.Sentence{Japanese: " 魚を食べる。", English: "I eat fish.", WordIDs: []int64{1, 1}}, nil},
+		{"missing english", models.Sentence{Japanese: "魚を食べる。"}, ErrInvalidSentence},
+		{"unknown word", models.Sentence{Japanese: "魚を食べる。", English: "I eat fish.", WordIDs: []int64{42}}, ErrInvalidSentence},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentence := tt.sentence
+			err := service.CreateSentence(ctx, &sentence)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateSentence() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (sentence.Japanese != "魚を食べる。" || len(sentence.WordIDs) != 1) {
+				t.Errorf("CreateSentence() stored %+v", sentence)
+			}
+		})
+	}
+}
+
+func TestWordService_GetWordWithStatsExample(t *testing.T) {
+	wordRepo := NewMockWordRepository()
+	sentenceRepo := NewMockSentenceRepository()
+	service := NewWordService(wordRepo, sentenceRepo, NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
+	ctx := context.Background()
+
+	word := &models.Word{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{}}
+	if err := wordRepo.Create(ctx, word); err != nil {
+		t.Fatalf("Failed to create test word: %v", err)
+	}
+
+	result, err := service.GetWordWithStats(ctx, word.ID)
+	if err != nil {
+		t.Fatalf("GetWordWithStats() error = %v", err)
+	}
+	if result.Example != nil {
+		t.Errorf("GetWordWithStats() example = %+v, want none", result.Example)
+	}
+
+	sentence := &models.Sentence{Japanese: "魚を食べる。", English: "I eat fish.", WordIDs: []int64{word.ID}}
+	if err := sentenceRepo.Create(ctx, sentence); err != nil {
+		t.Fatalf("Failed to create test sentence: %v", err)
+	}
+	result, err = service.GetWordWithStats(ctx, word.ID)
+	if err != nil {
+		t.Fatalf("GetWordWithStats() error = %v", err)
+	}
+	if result.Example == nil || result.Example.ID != sentence.ID {
+		t.Errorf("GetWordWithStats() example = %+v, want sentence %d", result.Example, sentence.ID)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -9,15 +10,21 @@ import (
 	"backend-go/internal/repository"
 )
 
+// ErrActivityNotFound is returned when a referenced study activity does not
+// exist
+var ErrActivityNotFound = errors.New("study activity not found")
+
 type StudyActivityService struct {
 	activityRepo repository.StudyActivityRepository
 	sessionRepo  repository.StudySessionRepository
+	revisionRepo repository.RevisionRepository
 }
 
-func NewStudyActivityService(activityRepo repository.StudyActivityRepository, sessionRepo repository.StudySessionRepository) *StudyActivityService {
+func NewStudyActivityService(activityRepo repository.StudyActivityRepository, sessionRepo repository.StudySessionRepository, revisionRepo repository.RevisionRepository) *StudyActivityService {
 	return &StudyActivityService{
 		activityRepo: activityRepo,
 		sessionRepo:  sessionRepo,
+		revisionRepo: revisionRepo,
 	}
 }
 
@@ -35,7 +42,7 @@ func (s *StudyActivityService) GetActivity(ctx context.Context, id int64) (*mode
 		return nil, fmt.Errorf("error getting study activity: %v", err)
 	}
 	if activity == nil {
-		return nil, fmt.Errorf("study activity not found")
+		return nil, ErrActivityNotFound
 	}
 	return activity, nil
 }
@@ -71,6 +78,40 @@ func (s *StudyActivityService) DeleteActivity(ctx context.Context, id int64) err
 	return nil
 }
 
+// GetActivityHistory returns a page of the revisions of a study activity,
+// newest first. The history of a deleted activity remains readable.
+func (s *StudyActivityService) GetActivityHistory(ctx context.Context, id int64, page, pageSize int) (*ListRevisionsResult, error) {
+	result, err := listRevisions(ctx, s.revisionRepo, models.RevisionStudyActivity, id, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if result.TotalItems == 0 {
+		if _, err := s.GetActivity(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// RevertActivity restores a study activity to the state one of its
+// revisions left it in, recorded as a revert
+func (s *StudyActivityService) RevertActivity(ctx context.Context, id, revisionID int64) (*models.StudyActivity, error) {
+	if _, err := s.GetActivity(ctx, id); err != nil {
+		return nil, err
+	}
+	activity := &models.StudyActivity{}
+	ctx, err := loadRevision(ctx, s.revisionRepo, models.RevisionStudyActivity, id, revisionID, activity)
+	if err != nil {
+		return nil, err
+	}
+	activity.ID = id
+
+	if err := s.UpdateActivity(ctx, activity); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
 func validateActivity(activity *models.StudyActivity) error {
 	if activity.Name == "" {
 		return fmt.Errorf("activity name is required")
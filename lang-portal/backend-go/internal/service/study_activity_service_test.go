@@ -22,7 +22,7 @@ func newMockStudySessionRepository() *mockStudySessionRepository {
 func TestStudyActivityService_CreateActivity(t *testing.T) {
 	repo := newMockStudyActivityRepository()
 	sessionRepo := newMockStudySessionRepository()
-	service := NewStudyActivityService(repo, sessionRepo)
+	service := NewStudyActivityService(repo, sessionRepo, NewMockRevisionRepository())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -78,7 +78,7 @@ func TestStudyActivityService_CreateActivity(t *testing.T) {
 func TestStudyActivityService_GetActivity(t *testing.T) {
 	repo := newMockStudyActivityRepository()
 	sessionRepo := newMockStudySessionRepository()
-	service := NewStudyActivityService(repo, sessionRepo)
+	service := NewStudyActivityService(repo, sessionRepo, NewMockRevisionRepository())
 	ctx := context.Background()
 
 	// Create a test activity
@@ -114,7 +114,7 @@ func TestStudyActivityService_GetActivity(t *testing.T) {
 func TestStudyActivityService_ListActivities(t *testing.T) {
 	repo := newMockStudyActivityRepository()
 	sessionRepo := newMockStudySessionRepository()
-	service := NewStudyActivityService(repo, sessionRepo)
+	service := NewStudyActivityService(repo, sessionRepo, NewMockRevisionRepository())
 	ctx := context.Background()
 
 	// Create test activities
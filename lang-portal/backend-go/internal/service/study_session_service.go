@@ -9,23 +9,29 @@ import (
 )
 
 type StudySessionService struct {
+	tx          repository.Transactor
 	sessionRepo repository.StudySessionRepository
 	groupRepo   repository.GroupRepository
+	scheduling  *SchedulingService
 }
 
 func NewStudySessionService(
+	tx repository.Transactor,
 	sessionRepo repository.StudySessionRepository,
 	groupRepo repository.GroupRepository,
+	scheduling *SchedulingService,
 ) *StudySessionService {
 	return &StudySessionService{
+		tx:          tx,
 		sessionRepo: sessionRepo,
 		groupRepo:   groupRepo,
+		scheduling:  scheduling,
 	}
 }
 
 type CreateSessionParams struct {
-	GroupID         int64
-	StudyActivityID int64
+	GroupID         int64 `json:"group_id"`
+	StudyActivityID int64 `json:"study_activity_id"`
 }
 
 func (s *StudySessionService) CreateSession(ctx context.Context, params CreateSessionParams) (*models.StudySession, error) {
@@ -97,6 +103,8 @@ func (s *StudySessionService) ListSessions(ctx context.Context, params ListSessi
 	}, nil
 }
 
+// AddReview records a review in a session and advances the schedule of the
+// word, in a single transaction
 func (s *StudySessionService) AddReview(ctx context.Context, sessionID, wordID int64, correct bool) (*models.WordReviewItem, error) {
 	// Verify session exists
 	session, err := s.sessionRepo.GetByID(ctx, sessionID)
@@ -113,8 +121,14 @@ func (s *StudySessionService) AddReview(ctx context.Context, sessionID, wordID i
 		Correct:        correct,
 	}
 
-	if err := s.sessionRepo.AddReview(ctx, review); err != nil {
-		return nil, fmt.Errorf("error adding word review: %v", err)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.AddReview(ctx, review); err != nil {
+			return fmt.Errorf("error adding word review: %v", err)
+		}
+		return s.scheduling.RecordReview(ctx, session.GroupID, review)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return review, nil
@@ -172,12 +186,6 @@ func (s *StudySessionService) GetSessionWords(ctx context.Context, sessionID int
 	return s.sessionRepo.GetSessionWords(ctx, sessionID)
 }
 
-// LoadSeedData loads initial seed data from the seeds directory
-func (s *StudySessionService) LoadSeedData(ctx context.Context) error {
-	seedsDir := "seeds"  // relative to backend-go directory
-	return s.sessionRepo.LoadSeedData(ctx, seedsDir)
-}
-
 // FullReset deletes all study session related data
 func (s *StudySessionService) FullReset(ctx context.Context) error {
 	return s.sessionRepo.FullReset(ctx)
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func TestStudySessionService_AddReviewUpdatesSchedule(t *testing.T) {
+	sessionRepo := NewMockStudySessionRepository()
+	groupRepo := NewMockGroupRepository()
+	scheduleRepo := NewMockScheduleRepository()
+	scheduling := NewSchedulingService(NewMockTransactor(), scheduleRepo, NewMockGroupSettingsRepository(), groupRepo)
+	service := NewStudySessionService(NewMockTransactor(), sessionRepo, groupRepo, scheduling)
+	ctx := context.Background()
+
+	group := &models.Group{Name: "Basic Verbs"}
+	if err := groupRepo.Create(ctx, group); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+	session, err := service.CreateSession(ctx, CreateSessionParams{GroupID: group.ID, StudyActivityID: 1})
+	if err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+
+	if _, err := service.AddReview(ctx, session.ID, 7, true); err != nil {
+		t.Fatalf("AddReview() error = %v", err)
+	}
+	schedule := scheduleRepo.schedules[7]
+	if schedule == nil || schedule.Repetitions != 1 || schedule.Algorithm != DefaultScheduler {
+		t.Fatalf("AddReview() did not schedule word, got %+v", schedule)
+	}
+
+	if _, err := service.AddReview(ctx, session.ID, 7, false); err != nil {
+		t.Fatalf("AddReview() error = %v", err)
+	}
+	if schedule := scheduleRepo.schedules[7]; schedule.Repetitions != 0 {
+		t.Errorf("AddReview() wrong answer got repetitions %d, want 0", schedule.Repetitions)
+	}
+}
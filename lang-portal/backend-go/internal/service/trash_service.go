@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+)
+
+var (
+	// ErrInvalidTrashType is returned for a type of item the trash does not
+	// hold
+	ErrInvalidTrashType = errors.New("invalid trash type")
+	// ErrTrashItemNotFound is returned when restoring an item that is not in
+	// the trash
+	ErrTrashItemNotFound = errors.New("item not found in trash")
+)
+
+type TrashService struct {
+	trashRepo    repository.TrashRepository
+	wordRepo     repository.WordRepository
+	groupRepo    repository.GroupRepository
+	activityRepo repository.StudyActivityRepository
+}
+
+func NewTrashService(
+	trashRepo repository.TrashRepository,
+	wordRepo repository.WordRepository,
+	groupRepo repository.GroupRepository,
+	activityRepo repository.StudyActivityRepository,
+) *TrashService {
+	return &TrashService{
+		trashRepo:    trashRepo,
+		wordRepo:     wordRepo,
+		groupRepo:    groupRepo,
+		activityRepo: activityRepo,
+	}
+}
+
+type ListTrashResult struct {
+	Items       []*models.TrashItem
+	TotalItems  int
+	CurrentPage int
+	TotalPages  int
+}
+
+// checkTrashType rejects a type that is not word, group or study_activity
+func checkTrashType(kind string) error {
+	switch models.RevisionKind(kind) {
+	case models.RevisionWord, models.RevisionGroup, models.RevisionStudyActivity:
+		return nil
+	}
+	return fmt.Errorf("%w %q: want word, group or study_activity", ErrInvalidTrashType, kind)
+}
+
+// ListTrash returns a page of the deleted items of a type, or of every type
+// when kind is empty, most recently deleted first
+func (s *TrashService) ListTrash(ctx context.Context, kind string, page, pageSize int) (*ListTrashResult, error) {
+	if kind != "" {
+		if err := checkTrashType(kind); err != nil {
+			return nil, err
+		}
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	items, total, err := s.trashRepo.List(ctx, kind, page, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error listing trash: %v", err)
+	}
+
+	return &ListTrashResult{
+		Items:       items,
+		TotalItems:  total,
+		CurrentPage: page,
+		TotalPages:  (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// Restore takes an item out of the trash and returns it as it is read
+// through its own endpoint
+func (s *TrashService) Restore(ctx context.Context, kind string, id int64) (any, error) {
+	if err := checkTrashType(kind); err != nil {
+		return nil, err
+	}
+
+	var restore func(context.Context, int64) (bool, error)
+	var get func(context.Context, int64) (any, error)
+	switch models.RevisionKind(kind) {
+	case models.RevisionWord:
+		restore = s.wordRepo.Restore
+		get = func(ctx context.Context, id int64) (any, error) { return s.wordRepo.GetByID(ctx, id) }
+	case models.RevisionGroup:
+		restore = s.groupRepo.Restore
+		get = func(ctx context.Context, id int64) (any, error) { return s.groupRepo.GetByID(ctx, id) }
+	case models.RevisionStudyActivity:
+		restore = s.activityRepo.Restore
+		get = func(ctx context.Context, id int64) (any, error) { return s.activityRepo.GetByID(ctx, id) }
+	}
+
+	restored, err := restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error restoring %s: %v", kind, err)
+	}
+	if !restored {
+		return nil, fmt.Errorf("%w: %s %d", ErrTrashItemNotFound, kind, id)
+	}
+
+	item, err := get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting restored %s: %v", kind, err)
+	}
+	return item, nil
+}
+
+// Purge permanently removes the items that have been in the trash for
+// longer than retention
+func (s *TrashService) Purge(ctx context.Context, retention time.Duration) (*models.PurgeResult, error) {
+	result, err := s.trashRepo.Purge(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return nil, fmt.Errorf("error purging trash: %v", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend-go/internal/domain/models"
+)
+
+func TestTrashService_Restore(t *testing.T) {
+	wordRepo := NewMockWordRepository()
+	groupRepo := NewMockGroupRepository()
+	activityRepo := NewMockStudyActivityRepository()
+	service := NewTrashService(NewMockTrashRepository(), wordRepo, groupRepo, activityRepo)
+	ctx := context.Background()
+
+	word := &models.Word{Kanji: "猫", Romaji: "neko", English: "cat"}
+	wordRepo.Create(ctx, word)
+	wordRepo.Delete(ctx, word.ID)
+	group := &models.Group{Name: "Animals"}
+	groupRepo.Create(ctx, group)
+
+	tests := []struct {
+		name    string
+		kind    string
+		id      int64
+		wantErr error
+	}{
+		{"word in the trash", "word", word.ID, nil},
+		{"word restored already", "word", word.ID, ErrTrashItemNotFound},
+		{"group not deleted", "group", group.ID, ErrTrashItemNotFound},
+		{"unknown activity", "study_activity", 42, ErrTrashItemNotFound},
+		{"unknown type", "sentence", 1, ErrInvalidTrashType},
+		{"empty type", "", 1, ErrInvalidTrashType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := service.Restore(ctx, tt.kind, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Restore() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && item.(*models.Word).Kanji != "猫" {
+				t.Errorf("Restore() = %+v, want 猫", item)
+			}
+		})
+	}
+}
+
+func TestTrashService_ListAndPurge(t *testing.T) {
+	trashRepo := NewMockTrashRepository()
+	trashRepo.items = []*models.TrashItem{
+		{Type: models.RevisionWord, ID: 1, Name: "猫"},
+		{Type: models.RevisionGroup, ID: 1, Name: "Animals"},
+	}
+	service := NewTrashService(trashRepo, NewMockWordRepository(), NewMockGroupRepository(), NewMockStudyActivityRepository())
+	ctx := context.Background()
+
+	result, err := service.ListTrash(ctx, "group", 0, 0)
+	if err != nil || result.TotalItems != 1 || result.CurrentPage != 1 || result.TotalPages != 1 {
+		t.Errorf("ListTrash() = %+v, %v, want the group on one page", result, err)
+	}
+	if _, err := service.ListTrash(ctx, "sentence", 1, 10); !errors.Is(err, ErrInvalidTrashType) {
+		t.Errorf("ListTrash() of sentences error = %v, want ErrInvalidTrashType", err)
+	}
+
+	// Items deleted more than the retention ago are purged
+	if _, err := service.Purge(ctx, 30*24*time.Hour); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if age := time.Since(trashRepo.purged); age < 30*24*time.Hour || age > 30*24*time.Hour+time.Minute {
+		t.Errorf("Purge() cutoff = %v ago, want 30 days", age)
+	}
+}
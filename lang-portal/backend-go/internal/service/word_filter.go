@@ -0,0 +1,239 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"backend-go/internal/domain/models"
+)
+
+// ErrInvalidFilter is returned for a word filter naming an unknown field or
+// operator, or with a value the field cannot be compared with
+var ErrInvalidFilter = errors.New("invalid filter")
+
+type filterKind int
+
+const (
+	textField filterKind = iota
+	numberField
+	partsField
+)
+
+// filterFields are the word columns a filter may name besides parts keys
+var filterFields = map[string]filterKind{
+	"language":          textField,
+	"term":              textField,
+	"kanji":             textField,
+	"reading":           textField,
+	"transliteration":   textField,
+	"romaji":            textField,
+	"gloss":             textField,
+	"english":           textField,
+	"correct_count":     numberField,
+	"wrong_count":       numberField,
+	"accuracy":          numberField,
+	"days_since_review": numberField,
+	"days_since_wrong":  numberField,
+}
+
+// filterOps are the operators allowed per kind of field
+var filterOps = map[filterKind][]models.FilterOp{
+	textField: {
+		models.FilterEq, models.FilterNe, models.FilterLt, models.FilterLte,
+		models.FilterGt, models.FilterGte, models.FilterLike, models.FilterIn,
+	},
+	numberField: {
+		models.FilterEq, models.FilterNe, models.FilterLt, models.FilterLte,
+		models.FilterGt, models.FilterGte, models.FilterIn,
+	},
+	partsField: {
+		models.FilterEq, models.FilterNe, models.FilterLt, models.FilterLte,
+		models.FilterGt, models.FilterGte, models.FilterLike, models.FilterIn,
+		models.FilterExists,
+	},
+}
+
+// filterSuffixes are tried longest first so that _lte is not read as _lt
+var filterSuffixes = []models.FilterOp{
+	models.FilterExists, models.FilterLike, models.FilterLte, models.FilterGte,
+	models.FilterEq, models.FilterNe, models.FilterLt, models.FilterGt, models.FilterIn,
+}
+
+var partsKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ParseWordFilter reads a word filter from query parameters such as
+// parts.topic=food, parts.verb_type_ne=godan, accuracy_lt=60, language=es,
+// days_since_wrong_lte=7, group_id=3 and group_id_ne=4.
+// The operator is an optional suffix; without one the field must equal the
+// value. Parameters named in skip, like page and sort_by, are ignored and any
+// other unknown parameter is an error.
+func ParseWordFilter(query url.Values, skip ...string) (models.WordFilter, error) {
+	var filter models.WordFilter
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[name] = true
+	}
+
+	for name, values := range query {
+		if skipped[name] {
+			continue
+		}
+		if name == "group_id" {
+			groupID, err := strconv.ParseInt(values[0], 10, 64)
+			if err != nil || groupID < 1 {
+				return filter, fmt.Errorf("%w: group_id must be a positive integer", ErrInvalidFilter)
+			}
+			filter.GroupID = groupID
+			continue
+		}
+		if name == "group_id_ne" {
+			for _, value := range values {
+				groupID, err := strconv.ParseInt(value, 10, 64)
+				if err != nil || groupID < 1 {
+					return filter, fmt.Errorf("%w: group_id_ne must be a positive integer", ErrInvalidFilter)
+				}
+				filter.ExcludeGroupIDs = append(filter.ExcludeGroupIDs, groupID)
+			}
+			continue
+		}
+
+		for _, value := range values {
+			condition, err := parseWordCondition(name, value)
+			if err != nil {
+				return filter, err
+			}
+			filter.Conditions = append(filter.Conditions, condition)
+		}
+	}
+
+	return filter, nil
+}
+
+func parseWordCondition(name, value string) (models.WordCondition, error) {
+	field, op := splitFilterOp(name)
+	raw := []string{value}
+	if op == models.FilterIn {
+		raw = strings.Split(value, ",")
+	}
+	return newWordCondition(field, op, raw)
+}
+
+// newWordCondition checks that field can be compared with op and converts
+// the raw values to what the field is compared with
+func newWordCondition(field string, op models.FilterOp, raw []string) (models.WordCondition, error) {
+	kind, ok := filterFields[field]
+	if key, isParts := strings.CutPrefix(field, "parts."); isParts {
+		if !partsKeyPattern.MatchString(key) {
+			return models.WordCondition{}, fmt.Errorf("%w: invalid parts key %q", ErrInvalidFilter, key)
+		}
+		kind, ok = partsField, true
+	}
+	if !ok {
+		return models.WordCondition{}, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, field)
+	}
+	if !allowsOp(kind, op) {
+		return models.WordCondition{}, fmt.Errorf("%w: %s cannot be compared with %s", ErrInvalidFilter, field, op)
+	}
+
+	condition := models.WordCondition{Field: field, Op: op}
+	for _, v := range raw {
+		parsed, err := filterValue(kind, op, strings.TrimSpace(v))
+		if err != nil {
+			return models.WordCondition{}, fmt.Errorf("%w: %s: %v", ErrInvalidFilter, field, err)
+		}
+		condition.Values = append(condition.Values, parsed)
+	}
+	return condition, nil
+}
+
+// normalizeWordFilter checks a filter given as JSON, like the filter of a
+// smart group, as ParseWordFilter checks query parameters, and converts its
+// values to what their fields are compared with
+func normalizeWordFilter(filter models.WordFilter) (models.WordFilter, error) {
+	normalized := models.WordFilter{GroupID: filter.GroupID, ExcludeGroupIDs: filter.ExcludeGroupIDs}
+	if filter.GroupID < 0 {
+		return normalized, fmt.Errorf("%w: group_id must be a positive integer", ErrInvalidFilter)
+	}
+	for _, groupID := range filter.ExcludeGroupIDs {
+		if groupID < 1 {
+			return normalized, fmt.Errorf("%w: exclude_group_ids must be positive integers", ErrInvalidFilter)
+		}
+	}
+
+	for _, condition := range filter.Conditions {
+		if condition.Op == "" {
+			condition.Op = models.FilterEq
+		}
+		if len(condition.Values) == 0 || (condition.Op != models.FilterIn && len(condition.Values) != 1) {
+			return normalized, fmt.Errorf("%w: %s %s takes one value", ErrInvalidFilter, condition.Field, condition.Op)
+		}
+
+		raw := make([]string, len(condition.Values))
+		for i, value := range condition.Values {
+			switch v := value.(type) {
+			case string:
+				raw[i] = v
+			case float64:
+				raw[i] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				raw[i] = strconv.FormatBool(v)
+			default:
+				return normalized, fmt.Errorf("%w: %s: values must be strings, numbers or booleans", ErrInvalidFilter, condition.Field)
+			}
+		}
+
+		parsed, err := newWordCondition(condition.Field, condition.Op, raw)
+		if err != nil {
+			return normalized, err
+		}
+		normalized.Conditions = append(normalized.Conditions, parsed)
+	}
+
+	return normalized, nil
+}
+
+// splitFilterOp splits the operator suffix off a parameter name. Names
+// without one compare for equality; a parts key that happens to end in an
+// operator, like check_in, is written with an explicit _eq.
+func splitFilterOp(name string) (string, models.FilterOp) {
+	for _, op := range filterSuffixes {
+		field, ok := strings.CutSuffix(name, "_"+string(op))
+		if ok && field != "" && !strings.HasSuffix(field, ".") {
+			return field, op
+		}
+	}
+	return name, models.FilterEq
+}
+
+func allowsOp(kind filterKind, op models.FilterOp) bool {
+	for _, allowed := range filterOps[kind] {
+		if op == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// filterValue converts a query value to what the field is compared with.
+// Parts values that look like numbers compare as numbers, as parts such as
+// jlpt levels are stored as JSON numbers.
+func filterValue(kind filterKind, op models.FilterOp, value string) (any, error) {
+	if op == models.FilterExists {
+		return strconv.ParseBool(value)
+	}
+	switch kind {
+	case numberField:
+		return strconv.ParseFloat(value, 64)
+	case partsField:
+		if op != models.FilterLike {
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				return number, nil
+			}
+		}
+	}
+	return value, nil
+}
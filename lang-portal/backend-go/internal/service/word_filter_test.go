@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"backend-go/internal/domain/models"
+)
+
+func TestParseWordFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []models.WordCondition
+		groupID int64
+		exclude []int64
+		wantErr bool
+	}{
+		{
+			name:  "parts equality",
+			query: "parts.verb_type=godan",
+			want:  []models.WordCondition{{Field: "parts.verb_type", Op: models.FilterEq, Values: []any{"godan"}}},
+		},
+		{
+			name:  "numeric parts value",
+			query: "parts.jlpt_lte=4",
+			want:  []models.WordCondition{{Field: "parts.jlpt", Op: models.FilterLte, Values: []any{4.0}}},
+		},
+		{
+			name:  "parts key ending in an operator",
+			query: "parts.check_in_eq=yes",
+			want:  []models.WordCondition{{Field: "parts.check_in", Op: models.FilterEq, Values: []any{"yes"}}},
+		},
+		{
+			name:  "in list",
+			query: "parts.topic_in=food,%20drink",
+			want:  []models.WordCondition{{Field: "parts.topic", Op: models.FilterIn, Values: []any{"food", "drink"}}},
+		},
+		{
+			name:    "stat and group",
+			query:   "accuracy_lt=60&group_id=3&page=2",
+			want:    []models.WordCondition{{Field: "accuracy", Op: models.FilterLt, Values: []any{60.0}}},
+			groupID: 3,
+		},
+		{
+			name:    "wrong recently and not in groups",
+			query:   "days_since_wrong_lte=7&group_id_ne=3&group_id_ne=4",
+			want:    []models.WordCondition{{Field: "days_since_wrong", Op: models.FilterLte, Values: []any{7.0}}},
+			exclude: []int64{3, 4},
+		},
+		{
+			name:  "exists",
+			query: "parts.topic_exists=false",
+			want:  []models.WordCondition{{Field: "parts.topic", Op: models.FilterExists, Values: []any{false}}},
+		},
+		{name: "unknown field", query: "level=3", wantErr: true},
+		{name: "operator not allowed on field", query: "accuracy_like=5", wantErr: true},
+		{name: "non-numeric stat", query: "wrong_count_gt=many", wantErr: true},
+		{name: "unsafe parts key", query: "parts.a'b=1", wantErr: true},
+		{name: "invalid group", query: "group_id=x", wantErr: true},
+		{name: "invalid excluded group", query: "group_id_ne=0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("invalid test query: %v", err)
+			}
+			filter, err := ParseWordFilter(query, "page")
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidFilter) {
+					t.Errorf("ParseWordFilter() error = %v, want ErrInvalidFilter", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWordFilter() error = %v", err)
+			}
+			if !reflect.DeepEqual(filter.Conditions, tt.want) {
+				t.Errorf("ParseWordFilter() conditions = %+v, want %+v", filter.Conditions, tt.want)
+			}
+			if filter.GroupID != tt.groupID {
+				t.Errorf("ParseWordFilter() group = %d, want %d", filter.GroupID, tt.groupID)
+			}
+			if !reflect.DeepEqual(filter.ExcludeGroupIDs, tt.exclude) {
+				t.Errorf("ParseWordFilter() excluded groups = %v, want %v", filter.ExcludeGroupIDs, tt.exclude)
+			}
+		})
+	}
+}
+
+func TestNormalizeWordFilter(t *testing.T) {
+	filter, err := normalizeWordFilter(models.WordFilter{
+		ExcludeGroupIDs: []int64{3},
+		Conditions: []models.WordCondition{
+			{Field: "parts.topic", Values: []any{"food"}},
+			{Field: "accuracy", Op: models.FilterLt, Values: []any{"50"}},
+			{Field: "parts.jlpt", Op: models.FilterIn, Values: []any{4.0, "5"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("normalizeWordFilter() error = %v", err)
+	}
+	want := []models.WordCondition{
+		{Field: "parts.topic", Op: models.FilterEq, Values: []any{"food"}},
+		{Field: "accuracy", Op: models.FilterLt, Values: []any{50.0}},
+		{Field: "parts.jlpt", Op: models.FilterIn, Values: []any{4.0, 5.0}},
+	}
+	if !reflect.DeepEqual(filter.Conditions, want) {
+		t.Errorf("normalizeWordFilter() conditions = %+v, want %+v", filter.Conditions, want)
+	}
+
+	for _, invalid := range []models.WordCondition{
+		{Field: "level", Values: []any{3.0}},
+		{Field: "accuracy", Op: "below", Values: []any{50.0}},
+		{Field: "accuracy", Op: models.FilterLt},
+		{Field: "english", Values: []any{"a", "b"}},
+		{Field: "parts.topic", Values: []any{nil}},
+	} {
+		_, err := normalizeWordFilter(models.WordFilter{Conditions: []models.WordCondition{invalid}})
+		if !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("normalizeWordFilter(%+v) error = %v, want ErrInvalidFilter", invalid, err)
+		}
+	}
+}
@@ -3,18 +3,27 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"backend-go/internal/domain/models"
 	"backend-go/internal/repository"
 )
 
 type WordService struct {
-	wordRepo repository.WordRepository
+	wordRepo     repository.WordRepository
+	sentenceRepo repository.SentenceRepository
+	languageRepo repository.LanguageRepository
+	revisionRepo repository.RevisionRepository
+	packs        *LanguagePacks
 }
 
-func NewWordService(wordRepo repository.WordRepository) *WordService {
+func NewWordService(wordRepo repository.WordRepository, sentenceRepo repository.SentenceRepository, languageRepo repository.LanguageRepository, revisionRepo repository.RevisionRepository, packs *LanguagePacks) *WordService {
 	return &WordService{
-		wordRepo: wordRepo,
+		wordRepo:     wordRepo,
+		sentenceRepo: sentenceRepo,
+		languageRepo: languageRepo,
+		revisionRepo: revisionRepo,
+		packs:        packs,
 	}
 }
 
@@ -31,6 +40,7 @@ type ListWordsParams struct {
 	PageSize int
 	SortBy   string
 	Order    string
+	Filter   models.WordFilter
 }
 
 type ListWordsResult struct {
@@ -48,7 +58,7 @@ func (s *WordService) ListWords(ctx context.Context, params ListWordsParams) (*L
 		params.PageSize = 10
 	}
 
-	words, total, err := s.wordRepo.List(ctx, params.Page, params.PageSize, params.SortBy, params.Order)
+	words, total, err := s.wordRepo.List(ctx, params.Page, params.PageSize, params.SortBy, params.Order, params.Filter)
 	if err != nil {
 		return nil, fmt.Errorf("error listing words: %v", err)
 	}
@@ -63,6 +73,41 @@ func (s *WordService) ListWords(ctx context.Context, params ListWordsParams) (*L
 	}, nil
 }
 
+type SearchWordsParams struct {
+	Query string
+	// Language, when set, keeps the words of that language
+	Language string
+	Page     int
+	PageSize int
+}
+
+// SearchWords performs a relevance-ranked full-text search over words
+func (s *WordService) SearchWords(ctx context.Context, params SearchWordsParams) (*ListWordsResult, error) {
+	if strings.TrimSpace(params.Query) == "" {
+		return nil, fmt.Errorf("search query is required")
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+
+	words, total, err := s.wordRepo.Search(ctx, params.Query, params.Language, params.Page, params.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("error searching words: %v", err)
+	}
+
+	totalPages := (total + params.PageSize - 1) / params.PageSize
+
+	return &ListWordsResult{
+		Words:       words,
+		TotalItems:  total,
+		CurrentPage: params.Page,
+		TotalPages:  totalPages,
+	}, nil
+}
+
 func (s *WordService) GetWord(ctx context.Context, id int64) (*models.Word, error) {
 	word, err := s.wordRepo.GetByID(ctx, id)
 	if err != nil {
@@ -85,38 +130,149 @@ func (s *WordService) GetWordWithStats(ctx context.Context, id int64) (*models.W
 		return nil, fmt.Errorf("error getting word stats: %v", err)
 	}
 
-	return &models.WordWithStats{
-		ID:      word.ID,
-		Kanji:   word.Kanji,
-		Romaji:  word.Romaji,
-		English: word.English,
-		Parts:   word.Parts,
-		Stats:   *stats,
-	}, nil
+	// The shortest sentence using the word serves as its example
+	examples, _, err := s.sentenceRepo.ListByWord(ctx, id, 1, 1)
+	if err != nil {
+		return nil, fmt.Errorf("error getting word example: %v", err)
+	}
+
+	result := &models.WordWithStats{
+		ID:       word.ID,
+		Language: word.Language,
+		Kanji:    word.Kanji,
+		Reading:  word.Reading,
+		Ruby:     word.Ruby,
+		Romaji:   word.Romaji,
+		English:  word.English,
+		Parts:    word.Parts,
+		Stats:    *stats,
+	}
+	if len(examples) > 0 {
+		result.Example = examples[0]
+	}
+	return result, nil
 }
 
-func (s *WordService) CreateWord(ctx context.Context, word *models.Word) error {
-	if err := validateWord(word); err != nil {
-		return err
+// CreateWord validates and stores a word, Japanese unless it names another
+// language, with the language pack of its language. For Japanese, missing
+// romaji is filled in from the kana reading; the returned warnings report
+// romaji that does not match it.
+func (s *WordService) CreateWord(ctx context.Context, word *models.Word) ([]string, error) {
+	if err := checkLanguage(ctx, s.languageRepo, &word.Language); err != nil {
+		return nil, err
+	}
+	warning, err := s.packs.PrepareWord(word)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := s.wordRepo.Create(ctx, word); err != nil {
-		return fmt.Errorf("error creating word: %v", err)
+		return nil, fmt.Errorf("error creating word: %v", err)
 	}
 
-	return nil
+	return warnings(warning), nil
 }
 
-func (s *WordService) UpdateWord(ctx context.Context, word *models.Word) error {
-	if err := validateWord(word); err != nil {
-		return err
+// UpdateWord replaces a word. Its language cannot change, as the word may
+// belong to groups of that language.
+func (s *WordService) UpdateWord(ctx context.Context, word *models.Word) ([]string, error) {
+	existing, err := s.wordRepo.GetByID(ctx, word.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting word: %v", err)
+	}
+	if existing == nil {
+		return nil, ErrWordNotFound
+	}
+	if word.Language == "" {
+		word.Language = existing.Language
+	} else if word.Language != existing.Language {
+		return nil, fmt.Errorf("%w: a word cannot move from %s to %s", ErrInvalidLanguage, existing.Language, word.Language)
+	}
+
+	warning, err := s.packs.PrepareWord(word)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := s.wordRepo.Update(ctx, word); err != nil {
-		return fmt.Errorf("error updating word: %v", err)
+		return nil, fmt.Errorf("error updating word: %v", err)
 	}
 
-	return nil
+	return warnings(warning), nil
+}
+
+// GetWordHistory returns a page of the revisions of a word, newest first.
+// The history of a deleted word remains readable.
+func (s *WordService) GetWordHistory(ctx context.Context, id int64, page, pageSize int) (*ListRevisionsResult, error) {
+	result, err := listRevisions(ctx, s.revisionRepo, models.RevisionWord, id, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	if result.TotalItems == 0 {
+		word, err := s.wordRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error getting word: %v", err)
+		}
+		if word == nil {
+			return nil, ErrWordNotFound
+		}
+	}
+	return result, nil
+}
+
+// RevertWord restores a word to the state one of its revisions left it in.
+// The restored word is validated like any update and recorded as a revert.
+func (s *WordService) RevertWord(ctx context.Context, id, revisionID int64) (*models.Word, []string, error) {
+	word := &models.Word{}
+	ctx, err := loadRevision(ctx, s.revisionRepo, models.RevisionWord, id, revisionID, word)
+	if err != nil {
+		return nil, nil, err
+	}
+	word.ID = id
+
+	warnings, err := s.UpdateWord(ctx, word)
+	if err != nil {
+		return nil, nil, err
+	}
+	return word, warnings, nil
+}
+
+func warnings(warning string) []string {
+	if warning == "" {
+		return nil
+	}
+	return []string{warning}
+}
+
+// BackfillSortKeys sets the sort key of every word to the one its language
+// pack gives it and returns the number of words whose key changed
+func (s *WordService) BackfillSortKeys(ctx context.Context) (int, error) {
+	const pageSize = 100
+	updated := 0
+	for page := 1; ; page++ {
+		words, total, err := s.wordRepo.List(ctx, page, pageSize, "id", "asc", models.WordFilter{})
+		if err != nil {
+			return updated, fmt.Errorf("error listing words: %v", err)
+		}
+		for _, listed := range words {
+			pack, err := s.packs.Get(listed.Language)
+			if err != nil {
+				// Words of languages without a pack keep their key
+				continue
+			}
+			word := &models.Word{Language: listed.Language, Kanji: listed.Kanji, Reading: listed.Reading}
+			changed, err := s.wordRepo.SetSortKey(ctx, listed.ID, pack.SortKey(word))
+			if err != nil {
+				return updated, fmt.Errorf("error updating word %d: %v", listed.ID, err)
+			}
+			if changed {
+				updated++
+			}
+		}
+		if page*pageSize >= total {
+			return updated, nil
+		}
+	}
 }
 
 func (s *WordService) DeleteWord(ctx context.Context, id int64) error {
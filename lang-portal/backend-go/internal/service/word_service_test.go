@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"backend-go/internal/domain/models"
@@ -18,7 +19,7 @@ func newMockWordRepository() *mockWordRepository {
 
 func TestWordService_CreateWord(t *testing.T) {
 	repo := newMockWordRepository()
-	service := NewWordService(repo)
+	service := NewWordService(repo, NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
 	ctx := context.Background()
 
 	tests := []struct {
@@ -52,7 +53,7 @@ func TestWordService_CreateWord(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := service.CreateWord(ctx, tt.word)
+			_, err := service.CreateWord(ctx, tt.word)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CreateWord() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -65,7 +66,7 @@ func TestWordService_CreateWord(t *testing.T) {
 
 func TestWordService_GetWordWithStats(t *testing.T) {
 	repo := newMockWordRepository()
-	service := NewWordService(repo)
+	service := NewWordService(repo, NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
 	ctx := context.Background()
 
 	// Create a test word
@@ -78,7 +79,7 @@ func TestWordService_GetWordWithStats(t *testing.T) {
 			"topic":     "food",
 		},
 	}
-	err := service.CreateWord(ctx, word)
+	_, err := service.CreateWord(ctx, word)
 	if err != nil {
 		t.Fatalf("Failed to create test word: %v", err)
 	}
@@ -104,4 +105,99 @@ func TestWordService_GetWordWithStats(t *testing.T) {
 	if wordWithStats.Stats.CorrectCount != 5 {
 		t.Errorf("GetWordWithStats() got CorrectCount = %v, want %v", wordWithStats.Stats.CorrectCount, 5)
 	}
-} 
\ No newline at end of file
+}
+
+func TestWordService_SearchWords(t *testing.T) {
+	repo := newMockWordRepository()
+	service := NewWordService(repo, NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
+	ctx := context.Background()
+
+	words := []*models.Word{
+		{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{"topic": "food"}},
+		{Kanji: "飲む", Romaji: "nomu", English: "to drink", Parts: map[string]any{"topic": "food"}},
+	}
+	for _, word := range words {
+		if _, err := service.CreateWord(ctx, word); err != nil {
+			t.Fatalf("Failed to create test word: %v", err)
+		}
+	}
+
+	result, err := service.SearchWords(ctx, SearchWordsParams{Query: "drink"})
+	if err != nil {
+		t.Fatalf("SearchWords() error = %v", err)
+	}
+	if result.TotalItems != 1 || result.Words[0].Kanji != "飲む" {
+		t.Errorf("SearchWords() got %v results, want only 飲む", result.TotalItems)
+	}
+
+	if _, err := service.SearchWords(ctx, SearchWordsParams{Query: "  "}); err == nil {
+		t.Error("SearchWords() expected error for empty query")
+	}
+}
+
+func TestWordService_Languages(t *testing.T) {
+	repo := newMockWordRepository()
+	service := NewWordService(repo, NewMockSentenceRepository(), NewMockLanguageRepository(), NewMockRevisionRepository(), DefaultLanguagePacks())
+	ctx := context.Background()
+
+	// Words of other languages need no romaji and get no reading
+	spanish := &models.Word{Language: "es", Kanji: " hablar ", English: "to speak", Parts: map[string]any{}}
+	if _, err := service.CreateWord(ctx, spanish); err != nil {
+		t.Fatalf("CreateWord() of a Spanish word error = %v", err)
+	}
+	if spanish.Kanji != "hablar" || spanish.Romaji != "" || spanish.Reading != "" {
+		t.Errorf("CreateWord() = %+v, want the trimmed term without romaji or reading", spanish)
+	}
+
+	japanese := &models.Word{Kanji: "たべる", English: "to eat", Parts: map[string]any{}}
+	if _, err := service.CreateWord(ctx, japanese); err != nil || japanese.Language != "ja" {
+		t.Errorf("CreateWord() without a language = %q, %v, want ja", japanese.Language, err)
+	}
+
+	unknown := &models.Word{Language: "xx", Kanji: "x", English: "x", Parts: map[string]any{}}
+	if _, err := service.CreateWord(ctx, unknown); !errors.Is(err, ErrInvalidLanguage) {
+		t.Errorf("CreateWord() of an unknown language error = %v, want %v", err, ErrInvalidLanguage)
+	}
+
+	moved := &models.Word{ID: spanish.ID, Language: "ja", Kanji: "はなす", English: "to speak", Parts: map[string]any{}}
+	if _, err := service.UpdateWord(ctx, moved); !errors.Is(err, ErrInvalidLanguage) {
+		t.Errorf("UpdateWord() to another language error = %v, want %v", err, ErrInvalidLanguage)
+	}
+	kept := &models.Word{ID: spanish.ID, Kanji: "hablar", English: "to talk", Parts: map[string]any{}}
+	if _, err := service.UpdateWord(ctx, kept); err != nil || kept.Language != "es" {
+		t.Errorf("UpdateWord() without a language = %q, %v, want es", kept.Language, err)
+	}
+}
+
+func TestWordService_RevertWord(t *testing.T) {
+	repo := newMockWordRepository()
+	revisions := NewMockRevisionRepository()
+	service := NewWordService(repo, NewMockSentenceRepository(), NewMockLanguageRepository(), revisions, DefaultLanguagePacks())
+	ctx := context.Background()
+
+	word := &models.Word{Kanji: "食べる", Reading: "たべる", English: "to eat", Parts: map[string]any{"verb_type": "ichidan"}}
+	if _, err := service.CreateWord(ctx, word); err != nil {
+		t.Fatalf("CreateWord() error = %v", err)
+	}
+	revisions.Add(models.RevisionWord, word.ID, &models.Revision{
+		Action: models.RevisionUpdate,
+		After:  []byte(`{"kanji": "食べる", "reading": "たべる", "gloss": "to eat (a meal)", "parts": {"verb_type": "ichidan"}}`),
+	})
+	revisions.Add(models.RevisionWord, word.ID, &models.Revision{Action: models.RevisionDelete})
+
+	reverted, _, err := service.RevertWord(ctx, word.ID, 1)
+	if err != nil {
+		t.Fatalf("RevertWord() error = %v", err)
+	}
+	// The restored word is prepared again like any update
+	if reverted.English != "to eat (a meal)" || reverted.Romaji != "taberu" || repo.words[word.ID].English != "to eat (a meal)" {
+		t.Errorf("RevertWord() = %+v, want the gloss of revision 1 with its romaji filled in", reverted)
+	}
+
+	if _, _, err := service.RevertWord(ctx, word.ID, 2); !errors.Is(err, ErrNothingToRevert) {
+		t.Errorf("RevertWord() to a deletion error = %v, want %v", err, ErrNothingToRevert)
+	}
+	if _, _, err := service.RevertWord(ctx, word.ID, 3); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("RevertWord() to an unknown revision error = %v, want %v", err, ErrRevisionNotFound)
+	}
+}
@@ -0,0 +1,72 @@
+package transliteration
+
+// syllable is the romanization of one kana, or of a kana followed by a
+// small ya/yu/yo or small vowel, in each system
+type syllable struct {
+	hepburn, kunrei, wapuro string
+}
+
+func same(romaji string) syllable {
+	return syllable{romaji, romaji, romaji}
+}
+
+// syllables maps hiragana to their romanization. Katakana are converted to
+// hiragana before lookup; ヴ has no hiragana in common use and is listed as ゔ.
+var syllables = map[string]syllable{
+	"あ": same("a"), "い": same("i"), "う": same("u"), "え": same("e"), "お": same("o"),
+	"か": same("ka"), "き": same("ki"), "く": same("ku"), "け": same("ke"), "こ": same("ko"),
+	"が": same("ga"), "ぎ": same("gi"), "ぐ": same("gu"), "げ": same("ge"), "ご": same("go"),
+	"さ": same("sa"), "し": {"shi", "si", "shi"}, "す": same("su"), "せ": same("se"), "そ": same("so"),
+	"ざ": same("za"), "じ": {"ji", "zi", "ji"}, "ず": same("zu"), "ぜ": same("ze"), "ぞ": same("zo"),
+	"た": same("ta"), "ち": {"chi", "ti", "chi"}, "つ": {"tsu", "tu", "tsu"}, "て": same("te"), "と": same("to"),
+	"だ": same("da"), "ぢ": {"ji", "zi", "di"}, "づ": {"zu", "zu", "du"}, "で": same("de"), "ど": same("do"),
+	"な": same("na"), "に": same("ni"), "ぬ": same("nu"), "ね": same("ne"), "の": same("no"),
+	"は": same("ha"), "ひ": same("hi"), "ふ": {"fu", "hu", "fu"}, "へ": same("he"), "ほ": same("ho"),
+	"ば": same("ba"), "び": same("bi"), "ぶ": same("bu"), "べ": same("be"), "ぼ": same("bo"),
+	"ぱ": same("pa"), "ぴ": same("pi"), "ぷ": same("pu"), "ぺ": same("pe"), "ぽ": same("po"),
+	"ま": same("ma"), "み": same("mi"), "む": same("mu"), "め": same("me"), "も": same("mo"),
+	"や": same("ya"), "ゆ": same("yu"), "よ": same("yo"),
+	"ら": same("ra"), "り": same("ri"), "る": same("ru"), "れ": same("re"), "ろ": same("ro"),
+	"わ": same("wa"), "ゐ": {"i", "i", "wi"}, "ゑ": {"e", "e", "we"}, "を": {"o", "o", "wo"},
+	"ゔ": same("vu"),
+
+	// Small kana on their own
+	"ぁ": same("a"), "ぃ": same("i"), "ぅ": same("u"), "ぇ": same("e"), "ぉ": same("o"),
+	"ゃ": same("ya"), "ゅ": same("yu"), "ょ": same("yo"), "ゎ": same("wa"),
+	"ゕ": same("ka"), "ゖ": same("ke"),
+
+	// Yōon
+	"きゃ": same("kya"), "きゅ": same("kyu"), "きょ": same("kyo"),
+	"ぎゃ": same("gya"), "ぎゅ": same("gyu"), "ぎょ": same("gyo"),
+	"しゃ": {"sha", "sya", "sha"}, "しゅ": {"shu", "syu", "shu"}, "しょ": {"sho", "syo", "sho"},
+	"じゃ": {"ja", "zya", "ja"}, "じゅ": {"ju", "zyu", "ju"}, "じょ": {"jo", "zyo", "jo"},
+	"ちゃ": {"cha", "tya", "cha"}, "ちゅ": {"chu", "tyu", "chu"}, "ちょ": {"cho", "tyo", "cho"},
+	"ぢゃ": {"ja", "zya", "dya"}, "ぢゅ": {"ju", "zyu", "dyu"}, "ぢょ": {"jo", "zyo", "dyo"},
+	"にゃ": same("nya"), "にゅ": same("nyu"), "にょ": same("nyo"),
+	"ひゃ": same("hya"), "ひゅ": same("hyu"), "ひょ": same("hyo"),
+	"びゃ": same("bya"), "びゅ": same("byu"), "びょ": same("byo"),
+	"ぴゃ": same("pya"), "ぴゅ": same("pyu"), "ぴょ": same("pyo"),
+	"みゃ": same("mya"), "みゅ": same("myu"), "みょ": same("myo"),
+	"りゃ": same("rya"), "りゅ": same("ryu"), "りょ": same("ryo"),
+
+	// Extended combinations used in loanwords
+	"しぇ": {"she", "sye", "she"}, "じぇ": {"je", "zye", "je"}, "ちぇ": {"che", "tye", "che"},
+	"つぁ": same("tsa"), "つぃ": same("tsi"), "つぇ": same("tse"), "つぉ": same("tso"),
+	"てぃ": same("ti"), "でぃ": same("di"), "とぅ": same("tu"), "どぅ": same("du"),
+	"ふぁ": same("fa"), "ふぃ": same("fi"), "ふぇ": same("fe"), "ふぉ": same("fo"), "ふゅ": same("fyu"),
+	"うぃ": same("wi"), "うぇ": same("we"), "うぉ": same("wo"), "いぇ": same("ye"),
+	"ゔぁ": same("va"), "ゔぃ": same("vi"), "ゔぇ": same("ve"), "ゔぉ": same("vo"),
+	"くぁ": same("kwa"), "ぐぁ": same("gwa"),
+}
+
+const (
+	smallTsu  = 'っ'
+	syllabicN = 'ん'
+	longMark  = 'ー'
+)
+
+// macrons and circumflexes mark long vowels in Hepburn and Kunrei
+var (
+	macrons      = map[byte]string{'a': "ā", 'i': "ī", 'u': "ū", 'e': "ē", 'o': "ō"}
+	circumflexes = map[byte]string{'a': "â", 'i': "î", 'u': "û", 'e': "ê", 'o': "ô"}
+)
@@ -0,0 +1,205 @@
+package transliteration
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrNotRomaji is returned when text to convert to kana is not romaji
+	ErrNotRomaji = errors.New("not romaji")
+	// ErrAmbiguous is returned when romaji could be spelled with different
+	// kana, e.g. ō (おう or おお) or ti (ち or てぃ)
+	ErrAmbiguous = errors.New("ambiguous romaji")
+)
+
+// smallKana are only written after another kana and never read on their own
+const smallKana = "ぁぃぅぇぉゃゅょゎゕゖ"
+
+// preferredKana resolves spellings shared by several kana to the one that
+// is almost always meant: ji is じ rather than ぢ, o is お rather than を
+var preferredKana = map[string]string{
+	"ji": "じ", "zi": "じ", "zu": "ず", "o": "お", "wo": "を",
+	"ja": "じゃ", "ju": "じゅ", "jo": "じょ", "zya": "じゃ", "zyu": "じゅ", "zyo": "じょ",
+}
+
+// romajiKana maps every spelling in syllables back to hiragana. Spellings of
+// several kana that preferredKana does not resolve map to "".
+var romajiKana = func() map[string]string {
+	candidates := make(map[string]map[string]bool)
+	for kana, syl := range syllables {
+		if strings.Contains(smallKana, kana) || kana == "ゐ" || kana == "ゑ" {
+			continue
+		}
+		for _, romaji := range []string{syl.hepburn, syl.kunrei, syl.wapuro} {
+			if candidates[romaji] == nil {
+				candidates[romaji] = make(map[string]bool)
+			}
+			candidates[romaji][kana] = true
+		}
+	}
+
+	table := make(map[string]string, len(candidates))
+	for romaji, kanas := range candidates {
+		if kana, ok := preferredKana[romaji]; ok {
+			table[romaji] = kana
+			continue
+		}
+		if len(kanas) > 1 {
+			table[romaji] = ""
+			continue
+		}
+		for kana := range kanas {
+			table[romaji] = kana
+		}
+	}
+	return table
+}()
+
+// FromRomaji converts romaji in any of the supported systems to hiragana.
+// Long vowels must be spelled out (ou, uu): macrons, circumflexes and
+// hyphens do not say which kana lengthens the vowel and return ErrAmbiguous,
+// as do spellings shared by different kana such as ti. Spaces are dropped.
+func FromRomaji(romaji string) (string, error) {
+	text := strings.ToLower(strings.TrimSpace(romaji))
+	if strings.ContainsAny(text, "āīūēōâîûêô-ー") {
+		return "", fmt.Errorf("%w: %q", ErrAmbiguous, romaji)
+	}
+	if strings.ContainsFunc(text, func(r rune) bool { return r >= unicode.MaxASCII }) {
+		return "", fmt.Errorf("%w: %q", ErrNotRomaji, romaji)
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		if c == ' ' || c == '\'' {
+			i++
+			continue
+		}
+
+		var next, after byte
+		if i+1 < len(text) {
+			next = text[i+1]
+		}
+		if i+2 < len(text) {
+			after = text[i+2]
+		}
+
+		switch {
+		// ん: n', nn and n or m before anything but a vowel or y
+		case c == 'n' && next == '\'':
+			out.WriteRune(syllabicN)
+			i += 2
+			continue
+		case c == 'n' && next == 'n' && !isVowel(after) && after != 'y':
+			out.WriteRune(syllabicN)
+			i += 2
+			continue
+		case c == 'n' && !isVowel(next) && next != 'y',
+			c == 'm' && (next == 'b' || next == 'p' || next == 'm'):
+			out.WriteRune(syllabicN)
+			i++
+			continue
+		// っ: a doubled consonant, or tch and cch
+		case c == next && !isVowel(c) && c != 'n',
+			(c == 't' || c == 'c') && next == 'c' && after == 'h':
+			out.WriteRune(smallTsu)
+			i++
+			continue
+		}
+
+		matched := false
+		for length := 3; length >= 1 && !matched; length-- {
+			if i+length > len(text) {
+				continue
+			}
+			kana, ok := romajiKana[text[i:i+length]]
+			if !ok {
+				continue
+			}
+			if kana == "" {
+				return "", fmt.Errorf("%w: %q", ErrAmbiguous, text[i:i+length])
+			}
+			out.WriteString(kana)
+			i += length
+			matched = true
+		}
+		if !matched {
+			return "", fmt.Errorf("%w: %q", ErrNotRomaji, text[i:])
+		}
+	}
+	return out.String(), nil
+}
+
+// Segment is a piece of written text with the kana reading of its kanji.
+// Reading is empty for pieces written in kana.
+type Segment struct {
+	Text    string
+	Reading string
+}
+
+// Furigana splits text into runs of kanji and runs of kana and assigns each
+// kanji run its part of reading, using the kana in text as anchors: 食べる
+// read たべる gives 食 (た) and べる. Consecutive kanji stay together as
+// their reading cannot be split without a dictionary. It reports false when
+// text has no kanji, or the reading does not fit the text or fits it in more
+// than one way.
+func Furigana(text, reading string) ([]Segment, bool) {
+	runs := splitRuns(text)
+	var pattern strings.Builder
+	kanji := false
+	for _, run := range runs {
+		if run.kana {
+			pattern.WriteString(regexp.QuoteMeta(ToHiragana(run.text)))
+		} else {
+			pattern.WriteString("(.+?)")
+			kanji = true
+		}
+	}
+	if !kanji {
+		return nil, false
+	}
+
+	// The lazy and greedy matches agree only when there is a single way to
+	// place the kana anchors in the reading
+	hiragana := ToHiragana(reading)
+	lazy := regexp.MustCompile("^" + pattern.String() + "$").FindStringSubmatchIndex(hiragana)
+	greedy := regexp.MustCompile("^" + strings.ReplaceAll(pattern.String(), "(.+?)", "(.+)") + "$").FindStringSubmatchIndex(hiragana)
+	if lazy == nil || fmt.Sprint(lazy) != fmt.Sprint(greedy) {
+		return nil, false
+	}
+
+	// ToHiragana keeps byte offsets, so the indexes apply to reading as well
+	segments := make([]Segment, 0, len(runs))
+	group := 1
+	for _, run := range runs {
+		if run.kana {
+			segments = append(segments, Segment{Text: run.text})
+			continue
+		}
+		segments = append(segments, Segment{Text: run.text, Reading: reading[lazy[2*group]:lazy[2*group+1]]})
+		group++
+	}
+	return segments, true
+}
+
+type run struct {
+	text string
+	kana bool
+}
+
+func splitRuns(text string) []run {
+	var runs []run
+	for _, r := range text {
+		kana := IsKana(string(r)) || r == longMark
+		if n := len(runs); n > 0 && runs[n-1].kana == kana {
+			runs[n-1].text += string(r)
+			continue
+		}
+		runs = append(runs, run{text: string(r), kana: kana})
+	}
+	return runs
+}
@@ -0,0 +1,106 @@
+package transliteration
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFromRomaji(t *testing.T) {
+	tests := []struct {
+		romaji string
+		want   string
+	}{
+		{"taberu", "たべる"},
+		{"benkyou", "べんきょう"},
+		{"Benkyou", "べんきょう"},
+		{"shinbun", "しんぶん"},
+		{"shimbun", "しんぶん"},
+		{"sinbun", "しんぶん"},
+		{"kin'en", "きんえん"},
+		{"konnichiha", "こんにちは"},
+		{"hon'ya", "ほんや"},
+		{"gakkou", "がっこう"},
+		{"matcha", "まっちゃ"},
+		{"maccha", "まっちゃ"},
+		{"mattya", "まっちゃ"},
+		{"zasshi", "ざっし"},
+		{"fujisan", "ふじさん"},
+		{"huzisan", "ふじさん"},
+		{"ookii", "おおきい"},
+		{"arigatou gozaimasu", "ありがとうございます"},
+		{"fairu", "ふぁいる"},
+	}
+
+	for _, tt := range tests {
+		got, err := FromRomaji(tt.romaji)
+		if err != nil {
+			t.Errorf("FromRomaji(%q) error = %v", tt.romaji, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FromRomaji(%q) = %q, want %q", tt.romaji, got, tt.want)
+		}
+	}
+}
+
+func TestFromRomajiErrors(t *testing.T) {
+	tests := []struct {
+		romaji string
+		want   error
+	}{
+		{"benkyō", ErrAmbiguous},
+		{"ko-hi-", ErrAmbiguous},
+		{"pa-ti", ErrAmbiguous},
+		{"tiizu", ErrAmbiguous},
+		{"xyz", ErrNotRomaji},
+		{"tabe2", ErrNotRomaji},
+		{"食べる", ErrNotRomaji},
+	}
+
+	for _, tt := range tests {
+		if _, err := FromRomaji(tt.romaji); !errors.Is(err, tt.want) {
+			t.Errorf("FromRomaji(%q) error = %v, want %v", tt.romaji, err, tt.want)
+		}
+	}
+}
+
+func TestFurigana(t *testing.T) {
+	tests := []struct {
+		text, reading string
+		want          []Segment
+	}{
+		{"食べる", "たべる", []Segment{{"食", "た"}, {"べる", ""}}},
+		{"勉強", "べんきょう", []Segment{{"勉強", "べんきょう"}}},
+		{"お茶", "おちゃ", []Segment{{"お", ""}, {"茶", "ちゃ"}}},
+		{"飲み物", "のみもの", []Segment{{"飲", "の"}, {"み", ""}, {"物", "もの"}}},
+		{"食べる", "タベル", []Segment{{"食", "タ"}, {"べる", ""}}},
+	}
+
+	for _, tt := range tests {
+		got, ok := Furigana(tt.text, tt.reading)
+		if !ok {
+			t.Errorf("Furigana(%q, %q) failed", tt.text, tt.reading)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Furigana(%q, %q) = %v, want %v", tt.text, tt.reading, got, tt.want)
+		}
+	}
+}
+
+func TestFuriganaRejects(t *testing.T) {
+	tests := []struct {
+		name, text, reading string
+	}{
+		{"kana only", "たべる", "たべる"},
+		{"reading does not fit", "食べる", "のむ"},
+		{"anchor fits twice", "日の丸", "ひののまる"},
+	}
+
+	for _, tt := range tests {
+		if got, ok := Furigana(tt.text, tt.reading); ok {
+			t.Errorf("%s: Furigana(%q, %q) = %v, want failure", tt.name, tt.text, tt.reading, got)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+// Package transliteration converts Japanese kana to romaji in the Hepburn,
+// Kunrei-shiki and wāpuro systems, and checks romaji against a reading.
+package transliteration
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// System is a romanization system
+type System string
+
+const (
+	// Hepburn is modified Hepburn: し shi, つ tsu, long vowels with macrons (ō)
+	Hepburn System = "hepburn"
+	// Kunrei is Kunrei-shiki: し si, つ tu, long vowels with circumflexes (ô)
+	Kunrei System = "kunrei"
+	// Wapuro follows the kana as typed on a keyboard: long vowels are spelled
+	// out (ou, uu), を is wo and ー is a hyphen
+	Wapuro System = "wapuro"
+)
+
+// Systems lists the supported systems
+var Systems = []System{Hepburn, Kunrei, Wapuro}
+
+// ErrNotKana is returned when text to romanize contains anything but kana
+var ErrNotKana = errors.New("not kana")
+
+// ParseSystem returns the system with the given name
+func ParseSystem(name string) (System, error) {
+	switch System(strings.ToLower(strings.TrimSpace(name))) {
+	case Hepburn:
+		return Hepburn, nil
+	case Kunrei, "kunrei-shiki":
+		return Kunrei, nil
+	case Wapuro, "wāpuro":
+		return Wapuro, nil
+	default:
+		return "", fmt.Errorf("unknown romanization system %q", name)
+	}
+}
+
+// piece is the romanization of one syllable while a word is assembled
+type piece struct {
+	text string
+	// long is set once the vowel has been lengthened
+	long bool
+	// n marks the syllabic ん
+	n bool
+}
+
+// Romanize converts hiragana or katakana to romaji. Small tsu doubles the
+// following consonant, ん is written n' before a vowel or y, and long vowels
+// follow the conventions of the system. Spaces and ・ separate words.
+func Romanize(kana string, system System) (string, error) {
+	runes := []rune(ToHiragana(kana))
+	var pieces []piece
+	geminate := false
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '　' || r == '・':
+			pieces = append(pieces, piece{text: " "})
+			geminate = false
+			continue
+		case r == smallTsu:
+			geminate = true
+			continue
+		case r == longMark:
+			lengthen(pieces, system)
+			continue
+		case r == syllabicN:
+			pieces = append(pieces, piece{text: "n", n: true})
+			geminate = false
+			continue
+		}
+
+		var syl syllable
+		found := false
+		if i+1 < len(runes) {
+			if syl, found = syllables[string(runes[i:i+2])]; found {
+				i++
+			}
+		}
+		if !found {
+			if syl, found = syllables[string(r)]; !found {
+				return "", fmt.Errorf("%w: %q", ErrNotKana, r)
+			}
+		}
+
+		text := syl.wapuro
+		switch system {
+		case Hepburn:
+			text = syl.hepburn
+		case Kunrei:
+			text = syl.kunrei
+		}
+
+		if geminate && !isVowel(text[0]) {
+			switch {
+			case system == Hepburn && strings.HasPrefix(text, "ch"):
+				text = "t" + text
+			default:
+				text = text[:1] + text
+			}
+		}
+		geminate = false
+
+		if len(pieces) > 0 {
+			prev := &pieces[len(pieces)-1]
+			if prev.n && (isVowel(text[0]) || text[0] == 'y') {
+				prev.text = "n'"
+			}
+			// A plain vowel kana after a matching vowel lengthens it
+			if len(text) == 1 && isLargeVowel(runes[i]) && system != Wapuro && !prev.long && !prev.n && extendsVowel(prev.text, text[0]) {
+				lengthen(pieces, system)
+				continue
+			}
+		}
+
+		pieces = append(pieces, piece{text: text})
+	}
+
+	var out strings.Builder
+	for _, p := range pieces {
+		out.WriteString(p.text)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// lengthen marks the vowel of the last syllable as long
+func lengthen(pieces []piece, system System) {
+	if len(pieces) == 0 {
+		return
+	}
+	prev := &pieces[len(pieces)-1]
+	last := prev.text[len(prev.text)-1]
+	if !isVowel(last) && last != '-' {
+		return
+	}
+	if system == Wapuro {
+		prev.text += "-"
+		return
+	}
+	if prev.long {
+		return
+	}
+	marks := macrons
+	if system == Kunrei {
+		marks = circumflexes
+	}
+	prev.text = prev.text[:len(prev.text)-1] + marks[last]
+	prev.long = true
+}
+
+// extendsVowel reports whether vowel after a syllable ending in text is
+// written as a long vowel: aa, ee, oo, ou and uu. ii and ei are spelled out.
+func extendsVowel(text string, vowel byte) bool {
+	last := text[len(text)-1]
+	return (last == vowel && vowel != 'i') || (last == 'o' && vowel == 'u')
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aiueo", c) >= 0
+}
+
+func isLargeVowel(r rune) bool {
+	return strings.ContainsRune("あいうえお", r)
+}
+
+// ToHiragana converts katakana to hiragana, leaving everything else as is
+func ToHiragana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, text)
+}
+
+// IsKana reports whether text is made only of kana, the long vowel mark and
+// word separators, and contains at least one kana
+func IsKana(text string) bool {
+	kana := false
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana) && r != '・':
+			kana = true
+		case r == longMark || r == ' ' || r == '　' || r == '・':
+		default:
+			return false
+		}
+	}
+	return kana
+}
+
+// Normalize reduces romaji to a form in which spellings of the same reading
+// compare equal: lowercase, long vowels doubled whether written with macrons,
+// circumflexes, hyphens or ou, no apostrophes or spaces, tch for cch and n
+// for m before b and p
+func Normalize(romaji string) string {
+	romaji = strings.ToLower(strings.TrimSpace(romaji))
+	// Combining marks, for input that was decomposed
+	romaji = strings.NewReplacer("\u0304", "-", "\u0302", "-").Replace(romaji)
+
+	var out []byte
+	for _, r := range romaji {
+		switch {
+		case strings.ContainsRune("āâ", r):
+			out = append(out, 'a', 'a')
+		case strings.ContainsRune("īî", r):
+			out = append(out, 'i', 'i')
+		case strings.ContainsRune("ūû", r):
+			out = append(out, 'u', 'u')
+		case strings.ContainsRune("ēê", r):
+			out = append(out, 'e', 'e')
+		case strings.ContainsRune("ōô", r):
+			out = append(out, 'o', 'o')
+		case r == '-' || r == 'ー':
+			if n := len(out); n > 0 && isVowel(out[n-1]) {
+				out = append(out, out[n-1])
+			}
+		case r == '\'' || r == '’' || unicode.IsSpace(r):
+		case r < unicode.MaxASCII:
+			out = append(out, byte(r))
+		default:
+			out = append(out, string(r)...)
+		}
+	}
+
+	normalized := string(out)
+	normalized = strings.ReplaceAll(normalized, "ou", "oo")
+	normalized = strings.ReplaceAll(normalized, "cch", "tch")
+	normalized = strings.ReplaceAll(normalized, "mb", "nb")
+	normalized = strings.ReplaceAll(normalized, "mp", "np")
+	return normalized
+}
+
+// Matches reports whether romaji is a romanization of the kana reading in
+// any of the supported systems, ignoring how long vowels, ん and
+// capitalization are written
+func Matches(romaji, kana string) bool {
+	want := Normalize(romaji)
+	for _, system := range Systems {
+		candidate, err := Romanize(kana, system)
+		if err != nil {
+			return false
+		}
+		if Normalize(candidate) == want {
+			return true
+		}
+	}
+	return false
+}
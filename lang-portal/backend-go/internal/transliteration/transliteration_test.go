@@ -0,0 +1,95 @@
+package transliteration
+
+import "testing"
+
+func TestRomanize(t *testing.T) {
+	tests := []struct {
+		kana                    string
+		hepburn, kunrei, wapuro string
+	}{
+		{"たべる", "taberu", "taberu", "taberu"},
+		{"べんきょう", "benkyō", "benkyô", "benkyou"},
+		{"とうきょう", "tōkyō", "tôkyô", "toukyou"},
+		{"おおきい", "ōkii", "ôkii", "ookii"},
+		{"くうき", "kūki", "kûki", "kuuki"},
+		{"おかあさん", "okāsan", "okâsan", "okaasan"},
+		{"せんせい", "sensei", "sensei", "sensei"},
+		{"しんぶん", "shinbun", "sinbun", "shinbun"},
+		{"きんえん", "kin'en", "kin'en", "kin'en"},
+		{"ほんや", "hon'ya", "hon'ya", "hon'ya"},
+		{"がっこう", "gakkō", "gakkô", "gakkou"},
+		{"まっちゃ", "matcha", "mattya", "maccha"},
+		{"ざっし", "zasshi", "zassi", "zasshi"},
+		{"ちず", "chizu", "tizu", "chizu"},
+		{"ふじさん", "fujisan", "huzisan", "fujisan"},
+		{"はなぢ", "hanaji", "hanazi", "hanadi"},
+		{"コーヒー", "kōhī", "kôhî", "ko-hi-"},
+		{"パーティー", "pātī", "pâtî", "pa-ti-"},
+		{"ファイル", "fairu", "fairu", "fairu"},
+		{"を", "o", "o", "wo"},
+		{"ありがとう ございます", "arigatō gozaimasu", "arigatô gozaimasu", "arigatou gozaimasu"},
+	}
+
+	for _, tt := range tests {
+		for system, want := range map[System]string{Hepburn: tt.hepburn, Kunrei: tt.kunrei, Wapuro: tt.wapuro} {
+			got, err := Romanize(tt.kana, system)
+			if err != nil {
+				t.Errorf("Romanize(%q, %s) error = %v", tt.kana, system, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("Romanize(%q, %s) = %q, want %q", tt.kana, system, got, want)
+			}
+		}
+	}
+}
+
+func TestRomanizeRejectsKanji(t *testing.T) {
+	if _, err := Romanize("食べる", Hepburn); err == nil {
+		t.Error("Expected an error for text containing kanji")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		romaji string
+		kana   string
+		want   bool
+	}{
+		{"benkyou", "べんきょう", true},
+		{"benkyō", "べんきょう", true},
+		{"benkyoo", "べんきょう", true},
+		{"Benkyô", "べんきょう", true},
+		{"kin'en", "きんえん", true},
+		{"kinen", "きんえん", true},
+		{"shimbun", "しんぶん", true},
+		{"sinbun", "しんぶん", true},
+		{"maccha", "まっちゃ", true},
+		{"koohii", "コーヒー", true},
+		{"kōhī", "コーヒー", true},
+		{"tabero", "たべる", false},
+		{"benkyo", "べんきょう", false},
+		{"taberu", "食べる", false},
+	}
+
+	for _, tt := range tests {
+		if got := Matches(tt.romaji, tt.kana); got != tt.want {
+			t.Errorf("Matches(%q, %q) = %v, want %v", tt.romaji, tt.kana, got, tt.want)
+		}
+	}
+}
+
+func TestIsKana(t *testing.T) {
+	for text, want := range map[string]bool{
+		"たべる":    true,
+		"コーヒー":   true,
+		"食べる":    false,
+		"taberu": false,
+		"":       false,
+		"ー":      false,
+	} {
+		if got := IsKana(text); got != want {
+			t.Errorf("IsKana(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
@@ -9,20 +9,28 @@ import (
 	"github.com/magefile/mage/sh"
 )
 
+// buildTags enables the SQLite extensions the schema relies on (FTS5 for word search)
+const buildTags = "sqlite_fts5"
+
 // Build builds the application with CGO enabled
 func Build() error {
 	os.Setenv("CGO_ENABLED", "1")
-	return sh.Run("go", "build", "-o", "bin/api", "./cmd/api")
+	return sh.Run("go", "build", "-tags", buildTags, "-o", "bin/api", "./cmd/api")
 }
 
 // Run runs the application
 func Run() error {
-	return sh.Run("go", "run", "./cmd/api/main.go")
+	return sh.Run("go", "run", "-tags", buildTags, "./cmd/api", "-migrations", "migrations", "-seeds", "seeds")
 }
 
 // Test runs the test suite
 func Test() error {
-	return sh.Run("go", "test", "./test/...")
+	return sh.Run("go", "test", "-tags", buildTags, "./test/...")
+}
+
+// Migrate applies pending database migrations
+func Migrate() error {
+	return sh.Run("go", "run", "-tags", buildTags, "./cmd/api", "migrate", "up")
 }
 
 // InitDB initializes the SQLite database
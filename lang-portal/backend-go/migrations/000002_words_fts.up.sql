@@ -0,0 +1,43 @@
+-- Full-text index over words. The parts column holds the space-separated
+-- values of the words.parts JSON object so that e.g. "food" or "ru-verb"
+-- match without indexing the JSON keys themselves.
+CREATE VIRTUAL TABLE IF NOT EXISTS words_fts USING fts5(
+    kanji,
+    romaji,
+    english,
+    parts,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+-- Keep words_fts in sync with words
+CREATE TRIGGER IF NOT EXISTS words_fts_after_insert AFTER INSERT ON words
+BEGIN
+    INSERT INTO words_fts (rowid, kanji, romaji, english, parts)
+    VALUES (
+        new.id, new.kanji, new.romaji, new.english,
+        (SELECT COALESCE(group_concat(value, ' '), '') FROM json_tree(new.parts) WHERE atom IS NOT NULL)
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS words_fts_after_update AFTER UPDATE ON words
+BEGIN
+    DELETE FROM words_fts WHERE rowid = old.id;
+    INSERT INTO words_fts (rowid, kanji, romaji, english, parts)
+    VALUES (
+        new.id, new.kanji, new.romaji, new.english,
+        (SELECT COALESCE(group_concat(value, ' '), '') FROM json_tree(new.parts) WHERE atom IS NOT NULL)
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS words_fts_after_delete AFTER DELETE ON words
+BEGIN
+    DELETE FROM words_fts WHERE rowid = old.id;
+END;
+
+-- Backfill words created before the index existed
+INSERT INTO words_fts (rowid, kanji, romaji, english, parts)
+SELECT
+    w.id, w.kanji, w.romaji, w.english,
+    (SELECT COALESCE(group_concat(value, ' '), '') FROM json_tree(w.parts) WHERE atom IS NOT NULL)
+FROM words w
+WHERE w.id NOT IN (SELECT rowid FROM words_fts);
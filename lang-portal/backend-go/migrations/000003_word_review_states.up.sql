@@ -0,0 +1,13 @@
+-- Create word_review_states table holding the spaced-repetition (SM-2)
+-- scheduling state of every word that has been reviewed at least once
+CREATE TABLE IF NOT EXISTS word_review_states (
+    word_id INTEGER PRIMARY KEY,
+    ease_factor REAL NOT NULL DEFAULT 2.5,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    due_at DATETIME NOT NULL,
+    last_reviewed_at DATETIME NOT NULL,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_review_states_due_at ON word_review_states(due_at);
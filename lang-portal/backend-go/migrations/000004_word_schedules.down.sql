@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS group_settings;
+
+-- Restore word_review_states with the SM-2 state that word_schedules holds;
+-- states produced by other algorithms cannot be represented and are dropped
+CREATE TABLE IF NOT EXISTS word_review_states (
+    word_id INTEGER PRIMARY KEY,
+    ease_factor REAL NOT NULL DEFAULT 2.5,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    due_at DATETIME NOT NULL,
+    last_reviewed_at DATETIME NOT NULL,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_review_states_due_at ON word_review_states(due_at);
+
+INSERT OR IGNORE INTO word_review_states (word_id, ease_factor, interval_days, repetitions, due_at, last_reviewed_at)
+SELECT word_id, ease_factor, interval_days, repetitions, due_at, last_reviewed_at
+FROM word_schedules
+WHERE algorithm = 'sm2';
+
+DROP TABLE IF EXISTS word_schedules;
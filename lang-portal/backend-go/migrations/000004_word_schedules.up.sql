@@ -0,0 +1,33 @@
+-- word_schedules supersedes word_review_states: each state now records the
+-- scheduling algorithm that produced it along with the Leitner box and FSRS
+-- memory parameters. Existing SM-2 state is carried over.
+CREATE TABLE IF NOT EXISTS word_schedules (
+    word_id INTEGER PRIMARY KEY,
+    algorithm TEXT NOT NULL DEFAULT 'sm2',
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    ease_factor REAL NOT NULL DEFAULT 0,
+    box INTEGER NOT NULL DEFAULT 0,
+    stability REAL NOT NULL DEFAULT 0,
+    difficulty REAL NOT NULL DEFAULT 0,
+    due_at DATETIME NOT NULL,
+    last_reviewed_at DATETIME NOT NULL,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_schedules_due_at ON word_schedules(due_at);
+
+INSERT OR IGNORE INTO word_schedules (word_id, algorithm, repetitions, interval_days, ease_factor, due_at, last_reviewed_at)
+SELECT word_id, 'sm2', repetitions, interval_days, ease_factor, due_at, last_reviewed_at
+FROM word_review_states;
+
+DROP TABLE IF EXISTS word_review_states;
+
+-- Create group_settings table holding per-group preferences such as the
+-- spaced-repetition algorithm used when studying the group
+CREATE TABLE IF NOT EXISTS group_settings (
+    group_id INTEGER PRIMARY KEY,
+    scheduler TEXT NOT NULL DEFAULT 'sm2',
+    FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+);
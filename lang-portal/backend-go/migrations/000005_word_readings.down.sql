@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_words_reading;
+
+ALTER TABLE words DROP COLUMN ruby;
+ALTER TABLE words DROP COLUMN reading;
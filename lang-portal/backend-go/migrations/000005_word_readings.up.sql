@@ -0,0 +1,7 @@
+-- Kana reading of each word and its furigana: a JSON array of
+-- {"text", "ruby"} segments covering the kanji field. Both are filled in by
+-- the application, which also backfills existing words on startup.
+ALTER TABLE words ADD COLUMN reading TEXT NOT NULL DEFAULT '';
+ALTER TABLE words ADD COLUMN ruby TEXT CHECK (ruby IS NULL OR json_valid(ruby));
+
+CREATE INDEX IF NOT EXISTS idx_words_reading ON words(reading);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_word_review_items_form;
+
+-- Drill reviews cannot be told apart from word reviews without the form
+DELETE FROM word_review_items WHERE form IS NOT NULL;
+ALTER TABLE word_review_items DROP COLUMN form;
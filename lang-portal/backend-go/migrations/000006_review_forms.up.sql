@@ -0,0 +1,5 @@
+-- Reviews from conjugation drills record the form that was asked for, e.g.
+-- "te" or "polite_past". Plain word reviews leave it NULL.
+ALTER TABLE word_review_items ADD COLUMN form TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_word_review_items_form ON word_review_items(form);
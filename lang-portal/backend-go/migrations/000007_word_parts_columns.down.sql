@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_words_parts_adjective_type;
+DROP INDEX IF EXISTS idx_words_parts_verb_type;
+DROP INDEX IF EXISTS idx_words_parts_topic;
+
+ALTER TABLE words DROP COLUMN parts_adjective_type;
+ALTER TABLE words DROP COLUMN parts_verb_type;
+ALTER TABLE words DROP COLUMN parts_topic;
@@ -0,0 +1,11 @@
+-- The most common parts keys as generated columns, so filtering on them
+-- (/api/words?parts.topic=food) uses an index instead of parsing the parts
+-- JSON of every word. The columns are untyped so values keep the JSON type
+-- json_extract gives them. Other keys are filtered with json_extract.
+ALTER TABLE words ADD COLUMN parts_topic GENERATED ALWAYS AS (json_extract(parts, '$.topic')) VIRTUAL;
+ALTER TABLE words ADD COLUMN parts_verb_type GENERATED ALWAYS AS (json_extract(parts, '$.verb_type')) VIRTUAL;
+ALTER TABLE words ADD COLUMN parts_adjective_type GENERATED ALWAYS AS (json_extract(parts, '$.adjective_type')) VIRTUAL;
+
+CREATE INDEX IF NOT EXISTS idx_words_parts_topic ON words(parts_topic);
+CREATE INDEX IF NOT EXISTS idx_words_parts_verb_type ON words(parts_verb_type);
+CREATE INDEX IF NOT EXISTS idx_words_parts_adjective_type ON words(parts_adjective_type);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_word_sentences_sentence_id;
+DROP TABLE IF EXISTS word_sentences;
+DROP TABLE IF EXISTS sentences;
@@ -0,0 +1,19 @@
+-- Example sentences and the words they illustrate
+CREATE TABLE IF NOT EXISTS sentences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    japanese TEXT NOT NULL,
+    reading TEXT NOT NULL DEFAULT '',
+    english TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS word_sentences (
+    word_id INTEGER NOT NULL,
+    sentence_id INTEGER NOT NULL,
+    PRIMARY KEY (word_id, sentence_id),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    FOREIGN KEY (sentence_id) REFERENCES sentences(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_sentences_sentence_id ON word_sentences(sentence_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_word_kanji_character;
+DROP TABLE IF EXISTS word_kanji;
+DROP TABLE IF EXISTS kanji;
@@ -0,0 +1,43 @@
+-- Kanji dictionary, filled from KANJIDIC2 by the kanjidic-import command.
+-- Readings, nanori and meanings are JSON arrays of strings.
+CREATE TABLE IF NOT EXISTS kanji (
+    character TEXT PRIMARY KEY,
+    grade INTEGER,
+    stroke_count INTEGER NOT NULL,
+    frequency INTEGER,
+    jlpt INTEGER,
+    on_readings JSON NOT NULL DEFAULT '[]',
+    kun_readings JSON NOT NULL DEFAULT '[]',
+    nanori JSON NOT NULL DEFAULT '[]',
+    meanings JSON NOT NULL DEFAULT '[]'
+);
+
+-- The kanji each word is written with, in order of first appearance. Links
+-- are kept for every kanji of a word whether or not it is in the dictionary
+-- yet, so importing KANJIDIC2 needs no relinking.
+CREATE TABLE IF NOT EXISTS word_kanji (
+    word_id INTEGER NOT NULL,
+    character TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (word_id, character),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_kanji_character ON word_kanji(character);
+
+-- Link the existing words, using the same ranges as kanjidic.IsKanji
+WITH RECURSIVE word_characters(word_id, position, character, rest) AS (
+    SELECT id, 0, '', kanji FROM words
+    UNION ALL
+    SELECT word_id, position + 1, substr(rest, 1, 1), substr(rest, 2)
+    FROM word_characters
+    WHERE rest <> ''
+)
+INSERT OR IGNORE INTO word_kanji (word_id, character, position)
+SELECT word_id, character, position
+FROM word_characters
+WHERE unicode(character) BETWEEN 0x3400 AND 0x4DBF
+   OR unicode(character) BETWEEN 0x4E00 AND 0x9FFF
+   OR unicode(character) BETWEEN 0xF900 AND 0xFAFF
+   OR unicode(character) BETWEEN 0x20000 AND 0x3134F
+ORDER BY word_id, position;
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS dictionary_sense_parts_of_speech;
+DROP TABLE IF EXISTS dictionary_parts_of_speech;
+DROP INDEX IF EXISTS idx_dictionary_glosses_text;
+DROP TABLE IF EXISTS dictionary_glosses;
+DROP INDEX IF EXISTS idx_dictionary_senses_entry_id;
+DROP TABLE IF EXISTS dictionary_senses;
+DROP INDEX IF EXISTS idx_dictionary_readings_romaji;
+DROP TABLE IF EXISTS dictionary_readings;
+DROP INDEX IF EXISTS idx_dictionary_kanji_text;
+DROP TABLE IF EXISTS dictionary_kanji;
+DROP TABLE IF EXISTS dictionary_entries;
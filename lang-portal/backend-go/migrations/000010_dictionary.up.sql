@@ -0,0 +1,64 @@
+-- JMdict dictionary, filled by the jmdict-import command. Entry IDs are the
+-- JMdict entry numbers (ent_seq), which stay stable across releases.
+CREATE TABLE IF NOT EXISTS dictionary_entries (
+    id INTEGER PRIMARY KEY,
+    common BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS dictionary_kanji (
+    entry_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    PRIMARY KEY (entry_id, position),
+    FOREIGN KEY (entry_id) REFERENCES dictionary_entries(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dictionary_kanji_text ON dictionary_kanji(text);
+
+-- romaji is the reading romanized and normalized like transliteration.Normalize
+-- (koohii for コーヒー), so readings are found from kana and any romaji spelling
+CREATE TABLE IF NOT EXISTS dictionary_readings (
+    entry_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    romaji TEXT NOT NULL,
+    PRIMARY KEY (entry_id, position),
+    FOREIGN KEY (entry_id) REFERENCES dictionary_entries(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dictionary_readings_romaji ON dictionary_readings(romaji);
+
+-- misc is a JSON array of JMdict usage codes, e.g. ["uk"]
+CREATE TABLE IF NOT EXISTS dictionary_senses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entry_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    misc JSON NOT NULL DEFAULT '[]',
+    FOREIGN KEY (entry_id) REFERENCES dictionary_entries(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dictionary_senses_entry_id ON dictionary_senses(entry_id);
+
+CREATE TABLE IF NOT EXISTS dictionary_glosses (
+    sense_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL COLLATE NOCASE,
+    PRIMARY KEY (sense_id, position),
+    FOREIGN KEY (sense_id) REFERENCES dictionary_senses(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dictionary_glosses_text ON dictionary_glosses(text);
+
+CREATE TABLE IF NOT EXISTS dictionary_parts_of_speech (
+    code TEXT PRIMARY KEY,
+    description TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS dictionary_sense_parts_of_speech (
+    sense_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    code TEXT NOT NULL,
+    PRIMARY KEY (sense_id, position),
+    FOREIGN KEY (sense_id) REFERENCES dictionary_senses(id) ON DELETE CASCADE,
+    FOREIGN KEY (code) REFERENCES dictionary_parts_of_speech(code)
+);
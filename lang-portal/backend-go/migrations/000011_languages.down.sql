@@ -0,0 +1,20 @@
+DROP VIEW IF EXISTS words;
+
+-- Only Japanese words and groups fit the former schema
+DELETE FROM word_groups
+WHERE word_id IN (SELECT id FROM terms WHERE language <> 'ja')
+   OR group_id IN (SELECT id FROM groups WHERE language <> 'ja');
+DELETE FROM terms WHERE language <> 'ja';
+DELETE FROM groups WHERE language <> 'ja';
+
+DROP INDEX IF EXISTS idx_groups_language;
+ALTER TABLE groups DROP COLUMN language;
+
+DROP INDEX IF EXISTS idx_terms_language;
+ALTER TABLE terms DROP COLUMN language;
+ALTER TABLE terms RENAME COLUMN gloss TO english;
+ALTER TABLE terms RENAME COLUMN transliteration TO romaji;
+ALTER TABLE terms RENAME COLUMN term TO kanji;
+ALTER TABLE terms RENAME TO words;
+
+DROP TABLE IF EXISTS languages;
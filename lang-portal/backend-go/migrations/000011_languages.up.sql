@@ -0,0 +1,37 @@
+-- Languages the vocabulary can be in. script is the ISO 15924 code of the
+-- writing system terms are written in, and transliteration names the
+-- romanization stored with each term (empty for languages written in the
+-- Latin script).
+CREATE TABLE IF NOT EXISTS languages (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    script TEXT NOT NULL,
+    transliteration TEXT NOT NULL DEFAULT ''
+);
+
+INSERT OR IGNORE INTO languages (code, name, script, transliteration) VALUES
+    ('ja', 'Japanese', 'Jpan', 'romaji'),
+    ('es', 'Spanish', 'Latn', '');
+
+-- Words become language-agnostic terms. Renaming keeps the ids, the indexes,
+-- the generated parts columns and the full-text triggers; every existing
+-- word is Japanese.
+ALTER TABLE words RENAME TO terms;
+ALTER TABLE terms RENAME COLUMN kanji TO term;
+ALTER TABLE terms RENAME COLUMN romaji TO transliteration;
+ALTER TABLE terms RENAME COLUMN english TO gloss;
+ALTER TABLE terms ADD COLUMN language TEXT NOT NULL DEFAULT 'ja';
+
+CREATE INDEX IF NOT EXISTS idx_terms_language ON terms(language);
+
+-- A group holds the words of one language
+ALTER TABLE groups ADD COLUMN language TEXT NOT NULL DEFAULT 'ja';
+
+CREATE INDEX IF NOT EXISTS idx_groups_language ON groups(language);
+
+-- The Japanese words under their former table and column names, for tools
+-- that read the database directly
+CREATE VIEW IF NOT EXISTS words AS
+SELECT id, term AS kanji, reading, ruby, transliteration AS romaji, gloss AS english, parts
+FROM terms
+WHERE language = 'ja';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_terms_language_sort_key;
+
+ALTER TABLE terms DROP COLUMN sort_key;
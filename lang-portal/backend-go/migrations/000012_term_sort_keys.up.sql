@@ -0,0 +1,8 @@
+-- The key terms sort by within their language, set by its language pack
+-- (the reading in hiragana for Japanese, accents folded for Spanish). Keys
+-- start out as the term and are recomputed when the server starts.
+ALTER TABLE terms ADD COLUMN sort_key TEXT NOT NULL DEFAULT '';
+
+UPDATE terms SET sort_key = term;
+
+CREATE INDEX IF NOT EXISTS idx_terms_language_sort_key ON terms(language, sort_key);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS study_activity_revisions;
+DROP TABLE IF EXISTS group_revisions;
+DROP TABLE IF EXISTS word_revisions;
@@ -0,0 +1,75 @@
+-- Append-only histories of words, groups and study activities. Each row
+-- holds the entity as the API returned it before and after a change (before
+-- is NULL for a creation, after for a deletion), who made the change and
+-- why. Rows outlive the entities they describe and cannot be changed.
+CREATE TABLE IF NOT EXISTS word_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'revert', 'delete')),
+    before TEXT,
+    after TEXT,
+    author TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    reverted_to INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_revisions_word_id ON word_revisions(word_id);
+
+CREATE TRIGGER IF NOT EXISTS word_revisions_no_update BEFORE UPDATE ON word_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'word revisions are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS word_revisions_no_delete BEFORE DELETE ON word_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'word revisions are append-only');
+END;
+
+CREATE TABLE IF NOT EXISTS group_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'revert', 'delete')),
+    before TEXT,
+    after TEXT,
+    author TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    reverted_to INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_revisions_group_id ON group_revisions(group_id);
+
+CREATE TRIGGER IF NOT EXISTS group_revisions_no_update BEFORE UPDATE ON group_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'group revisions are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS group_revisions_no_delete BEFORE DELETE ON group_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'group revisions are append-only');
+END;
+
+CREATE TABLE IF NOT EXISTS study_activity_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    study_activity_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'revert', 'delete')),
+    before TEXT,
+    after TEXT,
+    author TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    reverted_to INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_study_activity_revisions_study_activity_id ON study_activity_revisions(study_activity_id);
+
+CREATE TRIGGER IF NOT EXISTS study_activity_revisions_no_update BEFORE UPDATE ON study_activity_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'study activity revisions are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS study_activity_revisions_no_delete BEFORE DELETE ON study_activity_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'study activity revisions are append-only');
+END;
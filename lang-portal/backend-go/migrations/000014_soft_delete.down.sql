@@ -0,0 +1,114 @@
+-- Restores cannot be recorded once the revision tables are rebuilt without
+-- them
+CREATE TABLE word_revisions_rebuilt (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    word_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'revert', 'delete')),
+    before TEXT,
+    after TEXT,
+    author TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    reverted_to INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO word_revisions_rebuilt (id, word_id, action, before, after, author, reason, reverted_to, created_at)
+SELECT id, word_id, action, before, after, author, reason, reverted_to, created_at FROM word_revisions WHERE action <> 'restore';
+
+DROP TABLE word_revisions;
+ALTER TABLE word_revisions_rebuilt RENAME TO word_revisions;
+
+CREATE INDEX IF NOT EXISTS idx_word_revisions_word_id ON word_revisions(word_id);
+
+CREATE TRIGGER IF NOT EXISTS word_revisions_no_update BEFORE UPDATE ON word_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'word revisions are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS word_revisions_no_delete BEFORE DELETE ON word_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'word revisions are append-only');
+END;
+
+CREATE TABLE group_revisions_rebuilt (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    group_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'revert', 'delete')),
+    before TEXT,
+    after TEXT,
+    author TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    reverted_to INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO group_revisions_rebuilt (id, group_id, action, before, after, author, reason, reverted_to, created_at)
+SELECT id, group_id, action, before, after, author, reason, reverted_to, created_at FROM group_revisions WHERE action <> 'restore';
+
+DROP TABLE group_revisions;
+ALTER TABLE group_revisions_rebuilt RENAME TO group_revisions;
+
+CREATE INDEX IF NOT EXISTS idx_group_revisions_group_id ON group_revisions(group_id);
+
+CREATE TRIGGER IF NOT EXISTS group_revisions_no_update BEFORE UPDATE ON group_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'group revisions are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS group_revisions_no_delete BEFORE DELETE ON group_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'group revisions are append-only');
+END;
+
+CREATE TABLE study_activity_revisions_rebuilt (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    study_activity_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'revert', 'delete')),
+    before TEXT,
+    after TEXT,
+    author TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    reverted_to INTEGER,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO study_activity_revisions_rebuilt (id, study_activity_id, action, before, after, author, reason, reverted_to, created_at)
+SELECT id, study_activity_id, action, before, after, author, reason, reverted_to, created_at FROM study_activity_revisions WHERE action <> 'restore';
+
+DROP TABLE study_activity_revisions;
+ALTER TABLE study_activity_revisions_rebuilt RENAME TO study_activity_revisions;
+
+CREATE INDEX IF NOT EXISTS idx_study_activity_revisions_study_activity_id ON study_activity_revisions(study_activity_id);
+
+CREATE TRIGGER IF NOT EXISTS study_activity_revisions_no_update BEFORE UPDATE ON study_activity_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'study activity revisions are append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS study_activity_revisions_no_delete BEFORE DELETE ON study_activity_revisions
+BEGIN
+    SELECT RAISE(ABORT, 'study activity revisions are append-only');
+END;
+
+DROP VIEW IF EXISTS words;
+CREATE VIEW IF NOT EXISTS words AS
+SELECT id, term AS kanji, reading, ruby, transliteration AS romaji, gloss AS english, parts
+FROM terms
+WHERE language = 'ja';
+
+-- Items in the trash were deleted for good before it existed
+DELETE FROM word_groups
+WHERE word_id IN (SELECT id FROM terms WHERE deleted_at IS NOT NULL)
+   OR group_id IN (SELECT id FROM groups WHERE deleted_at IS NOT NULL);
+DELETE FROM terms WHERE deleted_at IS NOT NULL;
+DELETE FROM groups WHERE deleted_at IS NOT NULL;
+DELETE FROM study_activities WHERE deleted_at IS NOT NULL;
+UPDATE groups SET words_count = (SELECT COUNT(*) FROM word_groups WHERE group_id = groups.id);
+
+DROP INDEX IF EXISTS idx_study_activities_deleted_at;
+DROP INDEX IF EXISTS idx_groups_deleted_at;
+DROP INDEX IF EXISTS idx_terms_deleted_at;
+
+ALTER TABLE study_activities DROP COLUMN deleted_at;
+ALTER TABLE groups DROP COLUMN deleted_at;
+ALTER TABLE terms DROP COLUMN deleted_at;
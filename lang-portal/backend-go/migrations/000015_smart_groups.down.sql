@@ -0,0 +1,12 @@
+-- Smart groups have no words to fall back to; they are dropped along with
+-- their sessions and settings
+DELETE FROM word_review_items
+WHERE study_session_id IN (
+    SELECT id FROM study_sessions
+    WHERE group_id IN (SELECT id FROM groups WHERE filter IS NOT NULL)
+);
+DELETE FROM study_sessions WHERE group_id IN (SELECT id FROM groups WHERE filter IS NOT NULL);
+DELETE FROM group_settings WHERE group_id IN (SELECT id FROM groups WHERE filter IS NOT NULL);
+DELETE FROM groups WHERE filter IS NOT NULL;
+
+ALTER TABLE groups DROP COLUMN filter;
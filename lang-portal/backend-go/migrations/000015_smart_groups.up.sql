@@ -0,0 +1,4 @@
+-- A smart group keeps no word_groups rows: its members are the words of its
+-- language matching a saved word filter, stored as JSON. Groups without a
+-- filter list their words as before.
+ALTER TABLE groups ADD COLUMN filter TEXT;
@@ -0,0 +1,5 @@
+-- Every group goes back to the top; their words and sessions are kept
+DROP INDEX IF EXISTS idx_groups_parent_id;
+
+ALTER TABLE groups DROP COLUMN position;
+ALTER TABLE groups DROP COLUMN parent_id;
@@ -0,0 +1,9 @@
+-- Groups nest into a tree, such as course, unit and lesson. A group without
+-- a parent is at the top; position orders a group among its siblings. The
+-- groups there are now keep their order of creation at the top.
+ALTER TABLE groups ADD COLUMN parent_id INTEGER;
+ALTER TABLE groups ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE groups SET position = (SELECT COUNT(*) FROM groups g WHERE g.id < groups.id);
+
+CREATE INDEX IF NOT EXISTS idx_groups_parent_id ON groups(parent_id, position);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_word_groups_position;
+ALTER TABLE word_groups DROP COLUMN position;
@@ -0,0 +1,8 @@
+-- position orders the words of a group, such as the vocabulary of a lesson in
+-- teaching order. The words there are now keep the order of their ids.
+ALTER TABLE word_groups ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+UPDATE word_groups SET position = (
+    SELECT COUNT(*) FROM word_groups wg
+    WHERE wg.group_id = word_groups.group_id AND wg.word_id < word_groups.word_id
+);
+CREATE INDEX IF NOT EXISTS idx_word_groups_position ON word_groups(group_id, position);
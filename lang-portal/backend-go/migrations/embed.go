@@ -0,0 +1,10 @@
+// Package migrations embeds the versioned SQL schema migrations so the
+// binary can upgrade a database without the files next to it.
+package migrations
+
+import "embed"
+
+// FS holds the NNNNNN_name.up.sql and NNNNNN_name.down.sql files
+//
+//go:embed *.sql
+var FS embed.FS
@@ -9,6 +9,17 @@ type Config struct {
 	DBPath      string
 	ServerPort  string
 	Environment string
+	// MigrationsDir and SeedsDir load those files from disk instead of the
+	// copies embedded in the binary when set
+	MigrationsDir string
+	SeedsDir      string
+	// RomajiValidation is off, warn or reject; RomajiSystem is the
+	// romanization (hepburn, kunrei or wapuro) used to fill in missing romaji
+	RomajiValidation string
+	RomajiSystem     string
+	// TrashRetentionDays is how long deleted words, groups and study
+	// activities stay restorable before the purge removes them; 0 keeps them
+	TrashRetentionDays string
 }
 
 func New() *Config {
@@ -16,6 +27,14 @@ func New() *Config {
 		DBPath:      getEnvOrDefault("DB_PATH", filepath.Join(".", "words.db")),
 		ServerPort:  getEnvOrDefault("SERVER_PORT", "8080"),
 		Environment: getEnvOrDefault("ENV", "development"),
+
+		MigrationsDir: os.Getenv("MIGRATIONS_DIR"),
+		SeedsDir:      os.Getenv("SEEDS_DIR"),
+
+		RomajiValidation: getEnvOrDefault("ROMAJI_VALIDATION", "warn"),
+		RomajiSystem:     getEnvOrDefault("ROMAJI_SYSTEM", "wapuro"),
+
+		TrashRetentionDays: getEnvOrDefault("TRASH_RETENTION_DAYS", "30"),
 	}
 }
 
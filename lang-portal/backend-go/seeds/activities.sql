@@ -1,5 +0,0 @@
-INSERT INTO study_activities (name, description) 
-VALUES 
-    ('Flashcards', 'Practice with flashcards'),
-    ('Quiz', 'Test your knowledge')
-ON CONFLICT DO NOTHING; 
\ No newline at end of file
@@ -0,0 +1,9 @@
+// Package seeds embeds the JSON seed packs, one file per seed profile.
+package seeds
+
+import "embed"
+
+// FS holds the JSON seed packs
+//
+//go:embed *.json
+var FS embed.FS
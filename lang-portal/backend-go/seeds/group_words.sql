@@ -1,5 +0,0 @@
-INSERT INTO group_words (group_id, word_id)
-SELECT g.id, w.id
-FROM groups g, words w
-WHERE g.name = 'Beginner Words' AND w.term IN ('Hello', 'Goodbye')
-ON CONFLICT DO NOTHING; 
\ No newline at end of file
@@ -1,5 +0,0 @@
-INSERT INTO groups (name, description)
-VALUES 
-    ('Beginner Words', 'Basic vocabulary for beginners'),
-    ('Common Phrases', 'Everyday useful phrases')
-ON CONFLICT DO NOTHING; 
\ No newline at end of file
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository"
+	"backend-go/internal/service"
+)
+
+func setupAnkiTest(t *testing.T) (*gin.Engine, repository.GroupRepository) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	groupRepo := service.NewMockGroupRepository()
+	if err := groupRepo.Create(context.Background(), &models.Group{Name: "Vocab"}); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+	groupRepo.AddWord(context.Background(), 1, 1)
+	scheduleRepo := service.NewMockScheduleRepository()
+	scheduling := service.NewSchedulingService(service.NewMockTransactor(), scheduleRepo, service.NewMockGroupSettingsRepository(), groupRepo)
+
+	ankiService := service.NewAnkiService(service.NewMockTransactor(), service.NewMockWordRepository(), groupRepo,
+		service.NewMockStudyActivityRepository(), service.NewMockStudySessionRepository(), scheduleRepo, scheduling, service.DefaultLanguagePacks())
+	handler := handlers.NewAnkiHandler(ankiService)
+
+	r.POST("/api/groups/import.apkg", handler.ImportAnki)
+	r.GET("/api/group/:id/export.apkg", handler.ExportGroupAnki)
+
+	return r, groupRepo
+}
+
+func TestAnkiHandler_ExportGroupAnki(t *testing.T) {
+	r, _ := setupAnkiTest(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"export", "/api/group/1/export.apkg?parts=topic&include_history=true", http.StatusOK},
+		{"unknown group", "/api/group/42/export.apkg", http.StatusNotFound},
+		{"parts field clashing with a word field", "/api/group/1/export.apkg?parts=kanji", http.StatusBadRequest},
+		{"invalid include_history", "/api/group/1/export.apkg?include_history=maybe", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="group-1.apkg"`, w.Header().Get("Content-Disposition"))
+				// Packages are zip archives
+				assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("PK")))
+			}
+		})
+	}
+}
+
+func TestAnkiHandler_ImportAnki(t *testing.T) {
+	r, groupRepo := setupAnkiTest(t)
+
+	// An exported group is a valid package to import
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/group/1/export.apkg", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	apkg := w.Body.Bytes()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "vocab.apkg")
+	part.Write(apkg)
+	form.Close()
+	upload := body.Bytes()
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/groups/import.apkg", bytes.NewReader(upload))
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data service.AnkiImportResult `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Data.Decks, 1) {
+		assert.Equal(t, "Vocab", response.Data.Decks[0].Deck)
+		assert.Equal(t, int64(1), response.Data.Decks[0].GroupID)
+		assert.False(t, response.Data.Decks[0].GroupCreated)
+		assert.Equal(t, 1, response.Data.Decks[0].Notes)
+	}
+
+	// Words are not added to a smart group of the deck's name
+	group, _ := groupRepo.GetByID(context.Background(), 1)
+	group.Filter = &models.WordFilter{}
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/groups/import.apkg", bytes.NewReader(upload))
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/api/groups/import.apkg", bytes.NewReader([]byte("not a package")))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
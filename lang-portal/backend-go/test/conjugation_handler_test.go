@@ -0,0 +1,129 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/service"
+)
+
+func setupConjugationTest(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ctx := context.Background()
+
+	wordRepo := service.NewMockWordRepository()
+	groupRepo := service.NewMockGroupRepository()
+	sessionRepo := service.NewMockStudySessionRepository()
+	words := []*models.Word{
+		{Kanji: "飲む", Reading: "のむ", Romaji: "nomu", English: "to drink", Parts: map[string]any{"verb_type": "godan"}},
+		{Kanji: "本", Reading: "ほん", Romaji: "hon", English: "book", Parts: map[string]any{"type": "noun"}},
+	}
+	for _, word := range words {
+		if err := wordRepo.Create(ctx, word); err != nil {
+			t.Fatalf("Failed to create test word: %v", err)
+		}
+	}
+	if err := groupRepo.Create(ctx, &models.Group{Name: "Verbs"}); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+	groupRepo.AddWord(ctx, 1, 1)
+	if err := sessionRepo.Create(ctx, &models.StudySession{GroupID: 1, StudyActivityID: 1}); err != nil {
+		t.Fatalf("Failed to create test session: %v", err)
+	}
+
+	conjugationService := service.NewConjugationService(wordRepo, groupRepo, sessionRepo, service.DefaultLanguagePacks())
+	handler := handlers.NewConjugationHandler(conjugationService)
+
+	r.GET("/api/words/:id/conjugations", handler.GetWordConjugations)
+	r.GET("/api/group/:id/conjugation_drill", handler.GetConjugationDrill)
+	r.GET("/api/group/:id/conjugation_stats", handler.GetConjugationStats)
+	r.POST("/api/study_sessions/:id/conjugation_review", handler.AddConjugationReview)
+
+	return r
+}
+
+func TestConjugationHandler_GetWordConjugations(t *testing.T) {
+	r := setupConjugationTest(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"verb", "/api/words/1/conjugations", http.StatusOK},
+		{"noun", "/api/words/2/conjugations", http.StatusBadRequest},
+		{"unknown word", "/api/words/42/conjugations", http.StatusNotFound},
+		{"invalid id", "/api/words/abc/conjugations", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestConjugationHandler_DrillAndReview(t *testing.T) {
+	r := setupConjugationTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/group/1/conjugation_drill?count=3&forms=te,polite", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var drill struct {
+		Data struct {
+			Exercises []models.ConjugationExercise `json:"exercises"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &drill))
+	assert.Len(t, drill.Data.Exercises, 2)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"answer", `{"word_id": 1, "form": "te", "answer": "飲んで"}`, http.StatusCreated},
+		{"self graded", `{"word_id": 1, "form": "polite", "correct": false}`, http.StatusCreated},
+		{"no result", `{"word_id": 1, "form": "te"}`, http.StatusBadRequest},
+		{"unknown form", `{"word_id": 1, "form": "imperative", "correct": true}`, http.StatusBadRequest},
+		{"unknown word", `{"word_id": 42, "form": "te", "correct": true}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/api/study_sessions/1/conjugation_review", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/group/1/conjugation_stats", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var stats struct {
+		Data struct {
+			Forms []models.FormStats `json:"forms"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Len(t, stats.Data.Forms, 2)
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/service"
+)
+
+func setupDictionaryTest(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	dictionaryService := service.NewDictionaryService(service.NewMockTransactor(), service.NewMockDictionaryRepository(),
+		service.NewMockWordRepository(), service.DefaultLanguagePacks())
+	jmdict := `<!DOCTYPE JMdict [<!ENTITY v1 "Ichidan verb">]>
+<JMdict><entry><ent_seq>1358280</ent_seq><k_ele><keb>食べる</keb></k_ele><r_ele><reb>たべる</reb></r_ele>
+<sense><pos>&v1;</pos><gloss>to eat</gloss></sense></entry></JMdict>`
+	if _, err := dictionaryService.ImportJMdict(context.Background(), strings.NewReader(jmdict)); err != nil {
+		t.Fatalf("Failed to import test dictionary: %v", err)
+	}
+
+	handler := handlers.NewDictionaryHandler(dictionaryService)
+	r.GET("/api/dictionary", handler.LookupDictionary)
+	r.POST("/api/words/from_dictionary/:entry_id", handler.CreateWordFromDictionary)
+
+	return r
+}
+
+func TestDictionaryHandler(t *testing.T) {
+	r := setupDictionaryTest(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"lookup", http.MethodGet, "/api/dictionary?q=to+eat", "", http.StatusOK},
+		{"lookup without query", http.MethodGet, "/api/dictionary", "", http.StatusBadRequest},
+		{"create", http.MethodPost, "/api/words/from_dictionary/1358280", "", http.StatusCreated},
+		{"create again", http.MethodPost, "/api/words/from_dictionary/1358280", "", http.StatusConflict},
+		{"unknown sense", http.MethodPost, "/api/words/from_dictionary/1358280", `{"senses": [3]}`, http.StatusBadRequest},
+		{"unknown entry", http.MethodPost, "/api/words/from_dictionary/42", "", http.StatusNotFound},
+		{"invalid entry id", http.MethodPost, "/api/words/from_dictionary/abc", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite/implementations"
+)
+
+func TestDictionaryRepository(t *testing.T) {
+	db, _, _ := newSQLiteFixture(t)
+	repo := implementations.NewDictionaryRepository(db)
+	ctx := context.Background()
+
+	verb := []models.PartOfSpeech{{Code: "v1", Description: "Ichidan verb"}}
+	noun := []models.PartOfSpeech{{Code: "n", Description: "noun (common) (futsuumeishi)"}}
+	entries := []*models.DictionaryEntry{
+		{ID: 1358280, Kanji: []string{"食べる", "喰べる"}, Readings: []string{"たべる"}, Common: true, Senses: []models.DictionarySense{
+			{PartsOfSpeech: verb, Misc: []string{}, Glosses: []string{"to eat"}},
+			{PartsOfSpeech: verb, Misc: []string{}, Glosses: []string{"to live on (e.g. a salary)", "to live off"}},
+		}},
+		{ID: 1358300, Kanji: []string{"食べ物"}, Readings: []string{"たべもの"}, Senses: []models.DictionarySense{
+			{PartsOfSpeech: noun, Misc: []string{}, Glosses: []string{"food"}},
+		}},
+		{ID: 1038430, Kanji: []string{}, Readings: []string{"コーヒー"}, Common: true, Senses: []models.DictionarySense{
+			{PartsOfSpeech: noun, Misc: []string{}, Glosses: []string{"coffee"}},
+		}},
+		{ID: 1270190, Kanji: []string{"食堂"}, Readings: []string{"しょくどう"}, Common: true, Senses: []models.DictionarySense{
+			{PartsOfSpeech: noun, Misc: []string{}, Glosses: []string{"eating place"}},
+		}},
+	}
+	for _, entry := range entries {
+		if err := repo.Upsert(ctx, entry); err != nil {
+			t.Fatalf("Upsert() error = %v", err)
+		}
+	}
+
+	entry, err := repo.GetByID(ctx, 1358280)
+	if assert.NoError(t, err) {
+		assert.Equal(t, entries[0], entry)
+	}
+	entry, err = repo.GetByID(ctx, 42)
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+
+	tests := []struct {
+		name  string
+		query models.DictionaryQuery
+		want  []int64
+	}{
+		{"kanji prefix, common first", models.DictionaryQuery{Kanji: "食"}, []int64{1270190, 1358280, 1358300}},
+		{"exact kanji first", models.DictionaryQuery{Kanji: "食べ物"}, []int64{1358300}},
+		{"romaji", models.DictionaryQuery{Romaji: "tabe"}, []int64{1358280, 1358300}},
+		{"romaji of katakana", models.DictionaryQuery{Romaji: "kōhī"}, []int64{1038430}},
+		{"english verb before prefix", models.DictionaryQuery{English: "eat"}, []int64{1358280, 1270190}},
+		{"english is case insensitive", models.DictionaryQuery{English: "Coffee"}, []int64{1038430}},
+		{"empty query", models.DictionaryQuery{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, total, err := repo.Search(ctx, tt.query, 1, 10)
+			assert.NoError(t, err)
+			var ids []int64
+			for _, entry := range found {
+				ids = append(ids, entry.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+			assert.Equal(t, len(tt.want), total)
+		})
+	}
+
+	// Importing an entry again replaces it
+	entries[1].Senses = entries[1].Senses[:0]
+	entries[1].Kanji = []string{"食物"}
+	if err := repo.Upsert(ctx, entries[1]); err != nil {
+		t.Fatalf("Upsert() again error = %v", err)
+	}
+	entry, err = repo.GetByID(ctx, 1358300)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"食物"}, entry.Kanji)
+		assert.Empty(t, entry.Senses)
+	}
+	if found, _, _ := repo.Search(ctx, models.DictionaryQuery{English: "food"}, 1, 10); len(found) != 0 {
+		t.Errorf("Search() found the replaced gloss in %d entries", len(found))
+	}
+}
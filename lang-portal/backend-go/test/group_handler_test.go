@@ -0,0 +1,189 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/internal/service"
+)
+
+// setupGroupTest serves the group routes over the SQLite fixture, with a
+// Spanish word (id 5) added to its words
+func setupGroupTest(t *testing.T) (*gin.Engine, *implementations.GroupRepository) {
+	t.Helper()
+	db, wordRepo, groupRepo := newSQLiteFixture(t)
+	spanish := &models.Word{Language: "es", Kanji: "comer", English: "to eat", Parts: map[string]any{}}
+	if err := wordRepo.Create(context.Background(), spanish); err != nil {
+		t.Fatalf("Failed to create test word: %v", err)
+	}
+
+	groupService := service.NewGroupService(db, groupRepo, wordRepo, implementations.NewLanguageRepository(db), implementations.NewRevisionRepository(db))
+	handler := handlers.NewGroupHandler(groupService)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/groups", handler.CreateGroup)
+	r.GET("/api/group/:id", handler.GetGroup)
+	r.PUT("/api/group/:id", handler.UpdateGroup)
+	r.DELETE("/api/group/:id", handler.DeleteGroup)
+	r.GET("/api/group/:id/tree", handler.GetGroupTree)
+	r.GET("/api/group/:id/study_sessions", handler.GetGroupStudySessions)
+	r.PUT("/api/group/:id/parent", handler.MoveGroup)
+	r.POST("/api/group/:id/words", handler.AddGroupWords)
+	r.DELETE("/api/group/:id/words", handler.RemoveGroupWords)
+	r.PUT("/api/group/:id/words/order", handler.ReorderGroupWords)
+	r.POST("/api/group/:id/words/move", handler.MoveGroupWords)
+	r.POST("/api/group/:id/words/copy", handler.CopyGroupWords)
+	return r, groupRepo
+}
+
+func TestGroupHandler_CRUD(t *testing.T) {
+	r, _ := setupGroupTest(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"create", http.MethodPost, "/api/groups", `{"name": "Adjectives"}`, http.StatusCreated},
+		{"create in Spanish", http.MethodPost, "/api/groups", `{"name": "Verbos", "language": "es"}`, http.StatusCreated},
+		{"create without name", http.MethodPost, "/api/groups", `{}`, http.StatusBadRequest},
+		{"create in unknown language", http.MethodPost, "/api/groups", `{"name": "Wörter", "language": "de"}`, http.StatusBadRequest},
+		{"create duplicate", http.MethodPost, "/api/groups", `{"name": "Verbs"}`, http.StatusConflict},
+		{"rename", http.MethodPut, "/api/group/2", `{"name": "Describing Words"}`, http.StatusOK},
+		{"rename to itself", http.MethodPut, "/api/group/2", `{"name": "Describing Words"}`, http.StatusOK},
+		{"rename to another group's name", http.MethodPut, "/api/group/2", `{"name": "Verbs"}`, http.StatusConflict},
+		{"rename unknown", http.MethodPut, "/api/group/42", `{"name": "Nouns"}`, http.StatusNotFound},
+		{"delete", http.MethodDelete, "/api/group/2", "", http.StatusNoContent},
+		{"delete again", http.MethodDelete, "/api/group/2", "", http.StatusNotFound},
+		{"get deleted", http.MethodGet, "/api/group/2", "", http.StatusNotFound},
+		{"study sessions", http.MethodGet, "/api/group/1/study_sessions", "", http.StatusOK},
+		{"study sessions of a deleted group", http.MethodGet, "/api/group/2/study_sessions", "", http.StatusNotFound},
+		{"study sessions of an unknown group", http.MethodGet, "/api/group/42/study_sessions", "", http.StatusNotFound},
+		{"reuse a deleted group's name", http.MethodPost, "/api/groups", `{"name": "Describing Words"}`, http.StatusCreated},
+		{"create smart", http.MethodPost, "/api/groups", `{"name": "Food", "filter": {"conditions": [{"field": "parts.topic", "op": "eq", "values": ["food"]}]}}`, http.StatusCreated},
+		{"create smart with an unknown field", http.MethodPost, "/api/groups", `{"name": "Levels", "filter": {"conditions": [{"field": "level", "values": [3]}]}}`, http.StatusBadRequest},
+		{"change a smart group's filter", http.MethodPut, "/api/group/5", `{"name": "Food", "filter": {"exclude_group_ids": [1]}}`, http.StatusOK},
+		{"add words to a smart group", http.MethodPost, "/api/group/5/words", `{"word_ids": [4]}`, http.StatusConflict},
+		{"give a group a filter", http.MethodPut, "/api/group/1", `{"name": "Verbs", "filter": {}}`, http.StatusBadRequest},
+		{"create under a group", http.MethodPost, "/api/groups", `{"name": "Lesson 1", "parent_id": 1}`, http.StatusCreated},
+		{"create under a group of another language", http.MethodPost, "/api/groups", `{"name": "Lección 1", "parent_id": 3}`, http.StatusConflict},
+		{"delete a group with groups under it", http.MethodDelete, "/api/group/1", "", http.StatusConflict},
+		{"get the tree", http.MethodGet, "/api/group/1/tree", "", http.StatusOK},
+		{"move under a group it holds", http.MethodPut, "/api/group/1/parent", `{"parent_id": 6}`, http.StatusBadRequest},
+		{"move to the top", http.MethodPut, "/api/group/6/parent", `{"parent_id": null, "position": 0}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestGroupHandler_Membership(t *testing.T) {
+	r, groupRepo := setupGroupTest(t)
+	ctx := context.Background()
+	adjectives := &models.Group{Name: "Adjectives"}
+	if err := groupRepo.Create(ctx, adjectives); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+
+	wordsCount := func(id int64) int {
+		t.Helper()
+		group, err := groupRepo.GetByID(ctx, id)
+		if err != nil || group == nil {
+			t.Fatalf("GetByID(%d) = %v, %v", id, group, err)
+		}
+		return group.WordsCount
+	}
+	members := func(id int64) []int64 {
+		t.Helper()
+		words, _, err := groupRepo.ListWords(ctx, id, 1, 10, models.WordFilter{})
+		if err != nil {
+			t.Fatalf("ListWords(%d) error = %v", id, err)
+		}
+		ids := []int64{}
+		for _, word := range words {
+			ids = append(ids, word.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		wantStatus  int
+		wantVerbs   []int64
+		wantAdjs    []int64
+		wantChanged map[string]int
+	}{
+		{"add", http.MethodPost, "/api/group/2/words", `{"word_ids": [4, 1]}`, http.StatusOK, []int64{1, 2, 3}, []int64{4, 1}, map[string]int{"added": 2}},
+		{"add members again", http.MethodPost, "/api/group/2/words", `{"word_ids": [4]}`, http.StatusOK, []int64{1, 2, 3}, []int64{4, 1}, map[string]int{"added": 0}},
+		{"add with an unknown word adds none", http.MethodPost, "/api/group/1/words", `{"word_ids": [4, 42]}`, http.StatusNotFound, []int64{1, 2, 3}, []int64{4, 1}, nil},
+		{"add a word of another language", http.MethodPost, "/api/group/1/words", `{"word_ids": [5]}`, http.StatusConflict, []int64{1, 2, 3}, []int64{4, 1}, nil},
+		{"add to unknown group", http.MethodPost, "/api/group/42/words", `{"word_ids": [1]}`, http.StatusNotFound, []int64{1, 2, 3}, []int64{4, 1}, nil},
+		{"add nothing", http.MethodPost, "/api/group/1/words", `{"word_ids": []}`, http.StatusBadRequest, []int64{1, 2, 3}, []int64{4, 1}, nil},
+		{"remove", http.MethodDelete, "/api/group/2/words", `{"word_ids": [1, 3]}`, http.StatusOK, []int64{1, 2, 3}, []int64{4}, map[string]int{"removed": 1}},
+		{"move", http.MethodPost, "/api/group/1/words/move", `{"target_group_id": 2, "word_ids": [2, 3]}`, http.StatusOK, []int64{1}, []int64{4, 2, 3}, map[string]int{"moved": 2}},
+		{"move a word not in the group", http.MethodPost, "/api/group/1/words/move", `{"target_group_id": 2, "word_ids": [1, 4]}`, http.StatusNotFound, []int64{1}, []int64{4, 2, 3}, nil},
+		{"move to the same group", http.MethodPost, "/api/group/1/words/move", `{"target_group_id": 1, "word_ids": [1]}`, http.StatusBadRequest, []int64{1}, []int64{4, 2, 3}, nil},
+		{"move to unknown group", http.MethodPost, "/api/group/1/words/move", `{"target_group_id": 42, "word_ids": [1]}`, http.StatusNotFound, []int64{1}, []int64{4, 2, 3}, nil},
+		{"copy", http.MethodPost, "/api/group/2/words/copy", `{"target_group_id": 1, "word_ids": [2, 4]}`, http.StatusOK, []int64{1, 2, 4}, []int64{4, 2, 3}, map[string]int{"copied": 2}},
+		{"insert at a position", http.MethodPost, "/api/group/2/words", `{"word_ids": [1], "position": 1}`, http.StatusOK, []int64{1, 2, 4}, []int64{4, 1, 2, 3}, map[string]int{"added": 1}},
+		{"insert at a negative position", http.MethodPost, "/api/group/2/words", `{"word_ids": [1], "position": -1}`, http.StatusBadRequest, []int64{1, 2, 4}, []int64{4, 1, 2, 3}, nil},
+		{"reorder", http.MethodPut, "/api/group/2/words/order", `{"word_ids": [3, 2]}`, http.StatusOK, []int64{1, 2, 4}, []int64{3, 2, 4, 1}, map[string]int{"reordered": 2}},
+		{"reorder a word not in the group", http.MethodPut, "/api/group/2/words/order", `{"word_ids": [1, 5]}`, http.StatusNotFound, []int64{1, 2, 4}, []int64{3, 2, 4, 1}, nil},
+		{"reorder a word twice", http.MethodPut, "/api/group/2/words/order", `{"word_ids": [1, 1]}`, http.StatusBadRequest, []int64{1, 2, 4}, []int64{3, 2, 4, 1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			if !assert.Equal(t, tt.wantStatus, w.Code, w.Body.String()) {
+				return
+			}
+
+			assert.Equal(t, tt.wantVerbs, members(1))
+			assert.Equal(t, tt.wantAdjs, members(2))
+			assert.Equal(t, len(tt.wantVerbs), wordsCount(1))
+			assert.Equal(t, len(tt.wantAdjs), wordsCount(2))
+
+			if tt.wantChanged != nil {
+				var response struct {
+					Data map[string]json.RawMessage `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				for key, want := range tt.wantChanged {
+					var got int
+					assert.NoError(t, json.Unmarshal(response.Data[key], &got))
+					assert.Equal(t, want, got, key)
+				}
+				var groups []models.Group
+				assert.NoError(t, json.Unmarshal(response.Data["groups"], &groups))
+				assert.NotEmpty(t, groups)
+			}
+		})
+	}
+}
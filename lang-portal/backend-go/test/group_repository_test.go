@@ -74,7 +74,7 @@ func TestGroupRepository_List(t *testing.T) {
 	}
 
 	// Test listing groups
-	retrievedGroups, total, err := repo.List(ctx, 1, 10, "name", "asc")
+	retrievedGroups, total, err := repo.List(ctx, 1, 10, "name", "asc", "")
 	if err != nil {
 		t.Errorf("error listing groups: %v", err)
 	}
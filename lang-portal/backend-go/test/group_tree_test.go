@@ -0,0 +1,242 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/internal/service"
+)
+
+func TestGroupTree(t *testing.T) {
+	db, wordRepo, groupRepo := newSQLiteFixture(t)
+	ctx := context.Background()
+	groupService := service.NewGroupService(db, groupRepo, wordRepo, implementations.NewLanguageRepository(db), implementations.NewRevisionRepository(db))
+	scheduleRepo := implementations.NewScheduleRepository(db)
+
+	// A course with a unit holding two lessons; 高い is in the first
+	create := func(name string, parentID *int64) *models.Group {
+		t.Helper()
+		group := &models.Group{Name: name, ParentID: parentID}
+		if err := groupService.CreateGroup(ctx, group); err != nil {
+			t.Fatalf("CreateGroup(%q) error = %v", name, err)
+		}
+		return group
+	}
+	course := create("Japanese 1", nil)
+	unit := create("Unit 1", &course.ID)
+	lesson1 := create("Lesson 1", &unit.ID)
+	lesson2 := create("Lesson 2", &unit.ID)
+	if lesson1.Position != 0 || lesson2.Position != 1 {
+		t.Errorf("lesson positions = %d, %d, want 0, 1", lesson1.Position, lesson2.Position)
+	}
+	if _, err := groupService.AddWordsToGroup(ctx, lesson1.ID, []int64{4}); err != nil {
+		t.Fatalf("AddWordsToGroup() error = %v", err)
+	}
+
+	// The fixture's verbs, studied once, go first in the unit
+	first, negative, unknown := 0, -1, int64(42)
+	if _, err := groupService.MoveGroup(ctx, 1, &unit.ID, &first); err != nil {
+		t.Fatalf("MoveGroup() error = %v", err)
+	}
+
+	members := func(groupID int64) []int64 {
+		t.Helper()
+		words, _, err := groupRepo.ListWords(ctx, groupID, 1, 10, models.WordFilter{})
+		if err != nil {
+			t.Fatalf("ListWords(%d) error = %v", groupID, err)
+		}
+		ids := []int64{}
+		for _, word := range words {
+			ids = append(ids, word.ID)
+		}
+		return ids
+	}
+	childNames := func(tree *models.GroupTree) []string {
+		names := []string{}
+		for _, child := range tree.Children {
+			names = append(names, child.Name)
+		}
+		return names
+	}
+
+	// The course holds the words, reviews and sessions of everything under it
+	if got := members(course.ID); !slices.Equal(got, []int64{1, 2, 3, 4}) {
+		t.Errorf("members of the course = %v, want [1 2 3 4]", got)
+	}
+	tree, err := groupService.GetGroupTree(ctx, course.ID)
+	if err != nil {
+		t.Fatalf("GetGroupTree() error = %v", err)
+	}
+	if tree.WordsCount != 4 || tree.LastStudiedAt == nil || tree.Stats.TotalReviews != 2 {
+		t.Errorf("course = %+v, want 4 words, 2 reviews and a last study time", tree.GroupWithStats)
+	}
+	if len(tree.Children) != 1 || tree.Children[0].WordsCount != 4 {
+		t.Fatalf("course children = %+v, want the unit with 4 words", tree.Children)
+	}
+	if got := childNames(tree.Children[0]); len(got) != 3 || got[0] != "Verbs" || got[1] != "Lesson 1" || got[2] != "Lesson 2" {
+		t.Errorf("unit children = %v, want [Verbs Lesson 1 Lesson 2]", got)
+	}
+	// A page of groups rolls up the groups under those on the page
+	groups, total, err := groupRepo.List(ctx, 4, 1, "name", "asc", "")
+	if err != nil || total != 5 || len(groups) != 1 || groups[0].ID != unit.ID || groups[0].LastStudiedAt == nil || groups[0].WordsCount != 4 {
+		t.Errorf("List() page 4 = %d groups (%d in total), %v, want the unit studied with 4 words", len(groups), total, err)
+	}
+
+	// Words come in the order of the groups, then of the words in each
+	if _, err := groupService.ReorderWords(ctx, 1, []int64{3}); err != nil {
+		t.Fatalf("ReorderWords() error = %v", err)
+	}
+	if got := members(course.ID); !slices.Equal(got, []int64{3, 1, 2, 4}) {
+		t.Errorf("members of the course after a reorder = %v, want [3 1 2 4]", got)
+	}
+	due, err := scheduleRepo.ListDue(ctx, course.ID, "", time.Now(), 10)
+	if err != nil || len(due) != 4 || due[0].ID != 3 || due[3].ID != 4 {
+		t.Errorf("ListDue() = %d words, %v, want 4 starting with 行く", len(due), err)
+	}
+	sessionService := service.NewStudySessionService(db, implementations.NewStudySessionRepository(db), groupRepo, nil)
+	if _, err := sessionService.CreateSession(ctx, service.CreateSessionParams{GroupID: course.ID, StudyActivityID: 1}); err != nil {
+		t.Errorf("CreateSession() error = %v", err)
+	}
+	if sessions, err := groupService.GetGroupStudySessions(ctx, unit.ID, 1, 10); err != nil || sessions.TotalItems != 1 {
+		t.Errorf("GetGroupStudySessions() of the unit = %+v, %v, want the verbs' session", sessions, err)
+	}
+	if sessions, err := groupService.GetGroupStudySessions(ctx, course.ID, 1, 10); err != nil || sessions.TotalItems != 2 {
+		t.Errorf("GetGroupStudySessions() of the course = %+v, %v, want two sessions", sessions, err)
+	}
+
+	tests := []struct {
+		name    string
+		run     func() error
+		wantErr error
+	}{
+		{"move under itself", func() error {
+			_, err := groupService.MoveGroup(ctx, unit.ID, &unit.ID, nil)
+			return err
+		}, service.ErrInvalidGroup},
+		{"move under a group it holds", func() error {
+			_, err := groupService.MoveGroup(ctx, course.ID, &lesson2.ID, nil)
+			return err
+		}, service.ErrInvalidGroup},
+		{"move under an unknown group", func() error {
+			_, err := groupService.MoveGroup(ctx, lesson2.ID, &unknown, nil)
+			return err
+		}, service.ErrGroupNotFound},
+		{"move to a negative position", func() error {
+			_, err := groupService.MoveGroup(ctx, lesson2.ID, &unit.ID, &negative)
+			return err
+		}, service.ErrInvalidGroup},
+		{"create under a group of another language", func() error {
+			return groupService.CreateGroup(ctx, &models.Group{Name: "Lección 1", Language: "es", ParentID: &unit.ID})
+		}, service.ErrGroupLanguageMismatch},
+		{"create a smart group naming a group above it", func() error {
+			return groupService.CreateGroup(ctx, &models.Group{Name: "Review", ParentID: &lesson2.ID, Filter: &models.WordFilter{GroupID: course.ID}})
+		}, service.ErrInvalidGroup},
+		{"delete a group with groups under it", func() error {
+			return groupService.DeleteGroup(ctx, unit.ID)
+		}, service.ErrGroupHasChildren},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Moving a lesson first and the verbs to the top reorders the unit
+	if _, err := groupService.MoveGroup(ctx, lesson2.ID, &unit.ID, &first); err != nil {
+		t.Fatalf("MoveGroup() error = %v", err)
+	}
+	if _, err := groupService.MoveGroup(ctx, 1, nil, nil); err != nil {
+		t.Fatalf("MoveGroup() to the top error = %v", err)
+	}
+	if tree, err = groupService.GetGroupTree(ctx, unit.ID); err != nil {
+		t.Fatalf("GetGroupTree() error = %v", err)
+	}
+	if got := childNames(tree); len(got) != 2 || got[0] != "Lesson 2" || got[1] != "Lesson 1" || tree.Children[1].Position != 1 {
+		t.Errorf("unit children = %v, want [Lesson 2 Lesson 1]", got)
+	}
+	if got := members(course.ID); len(got) != 1 || got[0] != 4 {
+		t.Errorf("members of the course = %v, want [4]", got)
+	}
+}
+
+func TestGroupTree_PositionsAcrossTrash(t *testing.T) {
+	db, wordRepo, groupRepo := newSQLiteFixture(t)
+	ctx := context.Background()
+	groupService := service.NewGroupService(db, groupRepo, wordRepo, implementations.NewLanguageRepository(db), implementations.NewRevisionRepository(db))
+
+	create := func(name string, parentID *int64) *models.Group {
+		t.Helper()
+		group := &models.Group{Name: name, ParentID: parentID}
+		if err := groupService.CreateGroup(ctx, group); err != nil {
+			t.Fatalf("CreateGroup(%q) error = %v", name, err)
+		}
+		return group
+	}
+	// siblings lists the names of the groups under a parent in order, and
+	// checks they are numbered from 0 without gaps
+	siblings := func(parentID *int64) []string {
+		t.Helper()
+		rows, err := db.Query(`SELECT name, position FROM groups WHERE parent_id IS ? AND deleted_at IS NULL ORDER BY position, id`, parentID)
+		if err != nil {
+			t.Fatalf("error listing groups: %v", err)
+		}
+		defer rows.Close()
+		names := []string{}
+		for rows.Next() {
+			var name string
+			var position int
+			if err := rows.Scan(&name, &position); err != nil {
+				t.Fatalf("error scanning group: %v", err)
+			}
+			if position != len(names) {
+				t.Errorf("%s is at position %d, want %d", name, position, len(names))
+			}
+			names = append(names, name)
+		}
+		return names
+	}
+
+	parent := create("P", nil)
+	a := create("A", &parent.ID)
+	create("B", &parent.ID)
+	create("C", &parent.ID)
+
+	// Trashing A closes its gap, so a new group moved in lands where asked
+	if err := groupService.DeleteGroup(ctx, a.ID); err != nil {
+		t.Fatalf("DeleteGroup() error = %v", err)
+	}
+	d := create("D", nil)
+	second := 1
+	if _, err := groupService.MoveGroup(ctx, d.ID, &parent.ID, &second); err != nil {
+		t.Fatalf("MoveGroup() error = %v", err)
+	}
+	if got, want := siblings(&parent.ID), []string{"B", "D", "C"}; !slices.Equal(got, want) {
+		t.Errorf("groups under P = %v, want %v", got, want)
+	}
+
+	// A comes back where it was
+	if _, err := groupRepo.Restore(ctx, a.ID); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if got, want := siblings(&parent.ID), []string{"A", "B", "D", "C"}; !slices.Equal(got, want) {
+		t.Errorf("groups under P after a restore = %v, want %v", got, want)
+	}
+
+	// Purging P moves its groups, in order, after the groups at the top
+	if err := groupRepo.Delete(ctx, parent.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := implementations.NewTrashRepository(db).Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if got, want := siblings(nil), []string{"Verbs", "A", "B", "D", "C"}; !slices.Equal(got, want) {
+		t.Errorf("groups at the top after a purge = %v, want %v", got, want)
+	}
+}
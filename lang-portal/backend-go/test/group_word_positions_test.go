@@ -0,0 +1,97 @@
+package test
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"backend-go/internal/repository/sqlite"
+)
+
+// wordPositions maps the words of a group to their positions
+func wordPositions(t *testing.T, db *sqlite.Database, groupID int64) map[int64]int {
+	t.Helper()
+	rows, err := db.Query(`SELECT word_id, position FROM word_groups WHERE group_id = ?`, groupID)
+	if err != nil {
+		t.Fatalf("error listing word positions: %v", err)
+	}
+	defer rows.Close()
+
+	positions := map[int64]int{}
+	for rows.Next() {
+		var wordID int64
+		var position int
+		if err := rows.Scan(&wordID, &position); err != nil {
+			t.Fatalf("error scanning word position: %v", err)
+		}
+		positions[wordID] = position
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("error iterating word positions: %v", err)
+	}
+	return positions
+}
+
+func TestGroupRepository_WordPositions(t *testing.T) {
+	db, _, groupRepo := newSQLiteFixture(t)
+	ctx := context.Background()
+
+	steps := []struct {
+		name      string
+		run       func() (int, error)
+		wantCount int
+		want      map[int64]int
+	}{
+		{"the fixture's words in order", func() (int, error) { return 0, nil }, 0, map[int64]int{1: 0, 2: 1, 3: 2}},
+		{"remove a word", func() (int, error) { return 1, groupRepo.RemoveWord(ctx, 1, 2) }, 1, map[int64]int{1: 0, 3: 1}},
+		{"add a word last", func() (int, error) { return 1, groupRepo.AddWord(ctx, 1, 4) }, 1, map[int64]int{1: 0, 3: 1, 4: 2}},
+		{"insert words", func() (int, error) { return groupRepo.AddWords(ctx, 1, []int64{2, 4}, 1) }, 1, map[int64]int{1: 0, 2: 1, 3: 2, 4: 3}},
+		{"insert past the last", func() (int, error) { return groupRepo.AddWords(ctx, 1, []int64{2}, 42) }, 0, map[int64]int{1: 0, 2: 1, 3: 2, 4: 3}},
+		{"remove words", func() (int, error) { return groupRepo.RemoveWords(ctx, 1, []int64{1, 3, 42}) }, 2, map[int64]int{2: 0, 4: 1}},
+		{"remove the last word", func() (int, error) { return 1, groupRepo.RemoveWord(ctx, 1, 4) }, 1, map[int64]int{2: 0}},
+	}
+
+	for _, step := range steps {
+		count, err := step.run()
+		if err != nil {
+			t.Fatalf("%s: error = %v", step.name, err)
+		}
+		if count != step.wantCount {
+			t.Errorf("%s: %d words changed, want %d", step.name, count, step.wantCount)
+		}
+		if got := wordPositions(t, db, 1); !maps.Equal(got, step.want) {
+			t.Errorf("%s: positions = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestMigrations_WordPositionsBackfill(t *testing.T) {
+	db, _, _ := newSQLiteFixture(t)
+	files := sqliteFixtureMigrations(t)
+
+	// Before 000017, a second group gets 高い before 飲む
+	if _, err := db.MigrateTo(files, 16); err != nil {
+		t.Fatalf("MigrateTo(16) error = %v", err)
+	}
+	for _, query := range []string{
+		`INSERT INTO groups (id, name, words_count) VALUES (2, 'Mixed', 2)`,
+		`INSERT INTO word_groups (word_id, group_id) VALUES (4, 2), (2, 2)`,
+	} {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("error adding group words: %v", err)
+		}
+	}
+
+	// The words of each group are numbered from 0 in the order of their ids
+	if _, err := db.MigrateUp(files); err != nil {
+		t.Fatalf("MigrateUp() error = %v", err)
+	}
+	for groupID, want := range map[int64]map[int64]int{
+		1: {1: 0, 2: 1, 3: 2},
+		2: {2: 0, 4: 1},
+	} {
+		if got := wordPositions(t, db, groupID); !maps.Equal(got, want) {
+			t.Errorf("positions in group %d = %v, want %v", groupID, got, want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/service"
+)
+
+func setupImportTest(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	groupRepo := service.NewMockGroupRepository()
+	if err := groupRepo.Create(context.Background(), &models.Group{Name: "Vocab"}); err != nil {
+		t.Fatalf("Failed to create test group: %v", err)
+	}
+
+	importService := service.NewImportService(service.NewMockTransactor(), service.NewMockWordRepository(), groupRepo, service.DefaultLanguagePacks())
+	handler := handlers.NewImportHandler(importService)
+
+	r.POST("/api/groups/:id/import", handler.ImportGroupWords)
+	r.POST("/api/words/import.csv", handler.ImportWordsCSV)
+	r.GET("/api/groups/:id/export.csv", handler.ExportGroupCSV)
+
+	return r
+}
+
+func TestImportHandler_ImportGroupWords(t *testing.T) {
+	r := setupImportTest(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "vocab-importer list",
+			path:       "/api/groups/1/import",
+			body:       `[{"kanji": "勉強", "romaji": "benkyou", "english": "study", "parts": [{"kanji": "勉", "romaji": "ben"}]}]`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown group",
+			path:       "/api/groups/42/import",
+			body:       `[{"kanji": "勉強", "romaji": "benkyou", "english": "study", "parts": []}]`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not an array",
+			path:       "/api/groups/1/import",
+			body:       `{"kanji": "勉強"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "empty array",
+			path:       "/api/groups/1/import",
+			body:       `[]`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestImportHandler_ReportsPerItemResults(t *testing.T) {
+	r := setupImportTest(t)
+
+	body := `[
+		{"kanji": "勉強", "romaji": "benkyou", "english": "study", "parts": []},
+		{"kanji": "食事", "romaji": "shokuji", "parts": []}
+	]`
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/groups/1/import", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data service.ImportWordsResult `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Data.Created)
+	assert.Equal(t, 1, response.Data.Invalid)
+	assert.Len(t, response.Data.Items, 2)
+	assert.Equal(t, service.ImportStatusInvalid, response.Data.Items[1].Status)
+}
+
+func TestImportHandler_ImportWordsCSV(t *testing.T) {
+	r := setupImportTest(t)
+
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{
+			name:        "csv with column mapping",
+			query:       "?kanji_column=Word&romaji_column=Reading&english_column=Meaning&group_id=1",
+			contentType: "text/csv",
+			body:        "Word,Reading,Meaning\n猫,neko,cat\n",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "tsv detected from content type",
+			query:       "?dry_run=true",
+			contentType: "text/tab-separated-values",
+			body:        "kanji\tromaji\tenglish\n犬\tinu\tdog\n",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "missing mapped column",
+			query:       "?kanji_column=Word",
+			contentType: "text/csv",
+			body:        "kanji,romaji,english\n猫,neko,cat\n",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "unknown group",
+			query:       "?group_id=42",
+			contentType: "text/csv",
+			body:        "kanji,romaji,english\n猫,neko,cat\n",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:        "unsupported delimiter",
+			query:       "?delimiter=pipe",
+			contentType: "text/csv",
+			body:        "kanji|romaji|english\n",
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/words/import.csv"+tt.query, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestImportHandler_ExportGroupCSV(t *testing.T) {
+	r := setupImportTest(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/groups/1/export.csv", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "kanji,romaji,english\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/groups/42/export.csv", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
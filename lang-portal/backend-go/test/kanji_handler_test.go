@@ -0,0 +1,82 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/service"
+)
+
+func setupKanjiTest(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ctx := context.Background()
+
+	wordRepo := service.NewMockWordRepository()
+	word := &models.Word{Kanji: "食べ物", Romaji: "tabemono", English: "food", Parts: map[string]any{}}
+	if err := wordRepo.Create(ctx, word); err != nil {
+		t.Fatalf("Failed to create test word: %v", err)
+	}
+
+	kanjiService := service.NewKanjiService(service.NewMockTransactor(), service.NewMockKanjiRepository(), wordRepo)
+	kanjidic := `<kanjidic2><character><literal>食</literal><misc><stroke_count>9</stroke_count></misc></character></kanjidic2>`
+	if _, err := kanjiService.ImportKanjidic(ctx, strings.NewReader(kanjidic)); err != nil {
+		t.Fatalf("Failed to import test kanji: %v", err)
+	}
+
+	handler := handlers.NewKanjiHandler(kanjiService)
+	r.GET("/api/kanji/:char", handler.GetKanji)
+	r.GET("/api/words/:id/kanji", handler.GetWordKanji)
+
+	return r
+}
+
+func TestKanjiHandler(t *testing.T) {
+	r := setupKanjiTest(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"kanji", "/api/kanji/" + url.PathEscape("食"), http.StatusOK},
+		{"missing kanji", "/api/kanji/" + url.PathEscape("物"), http.StatusNotFound},
+		{"kana", "/api/kanji/" + url.PathEscape("た"), http.StatusBadRequest},
+		{"word kanji", "/api/words/1/kanji", http.StatusOK},
+		{"unknown word", "/api/words/42/kanji", http.StatusNotFound},
+		{"invalid word id", "/api/words/abc/kanji", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/words/1/kanji", nil)
+	r.ServeHTTP(w, req)
+	var response struct {
+		Data struct {
+			Kanji []models.Kanji `json:"kanji"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Data.Kanji, 2) {
+		assert.Equal(t, 9, response.Data.Kanji[0].StrokeCount)
+		assert.Equal(t, "物", response.Data.Kanji[1].Character)
+	}
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite/implementations"
+)
+
+func TestKanjiRepository(t *testing.T) {
+	db, _, _ := newSQLiteFixture(t)
+	repo := implementations.NewKanjiRepository(db)
+	ctx := context.Background()
+
+	eat := &models.Kanji{Character: "食", Grade: 2, StrokeCount: 9, OnReadings: []string{"ショク"}, Meanings: []string{"eat", "food"}}
+	if err := repo.Upsert(ctx, eat); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	eat.JLPT = 4
+	if err := repo.Upsert(ctx, eat); err != nil {
+		t.Fatalf("Upsert() again error = %v", err)
+	}
+
+	kanji, err := repo.GetByCharacter(ctx, "食")
+	if err != nil || kanji == nil {
+		t.Fatalf("GetByCharacter() = %v, %v", kanji, err)
+	}
+	if kanji.JLPT != 4 || kanji.Frequency != 0 || len(kanji.Meanings) != 2 || kanji.KunReadings == nil {
+		t.Errorf("GetByCharacter() = %+v", kanji)
+	}
+	if kanji, err := repo.GetByCharacter(ctx, "飲"); err != nil || kanji != nil {
+		t.Errorf("GetByCharacter() of a missing kanji = %+v, %v", kanji, err)
+	}
+
+	entries, err := repo.ListByCharacters(ctx, []string{"食", "飲"})
+	if err != nil || len(entries) != 1 || entries["食"] == nil {
+		t.Errorf("ListByCharacters() = %v, %v", entries, err)
+	}
+}
+
+func TestWordKanjiLinks(t *testing.T) {
+	db, wordRepo, _ := newSQLiteFixture(t)
+	ctx := context.Background()
+
+	words, total, err := wordRepo.List(ctx, 1, 10, "id", "asc", models.WordFilter{Character: "食"})
+	if err != nil || total != 1 || words[0].Kanji != "食べる" {
+		t.Fatalf("List() of 食 = %d words, %v", total, err)
+	}
+
+	// Renaming a word relinks its kanji
+	word, _ := wordRepo.GetByID(ctx, 2)
+	word.Kanji = "食う"
+	if err := wordRepo.Update(ctx, word); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, total, _ := wordRepo.List(ctx, 1, 10, "id", "asc", models.WordFilter{Character: "食"}); total != 2 {
+		t.Errorf("List() of 食 after update = %d words, want 2", total)
+	}
+	if _, total, _ := wordRepo.List(ctx, 1, 10, "id", "asc", models.WordFilter{Character: "飲"}); total != 0 {
+		t.Errorf("List() of 飲 after update = %d words, want 0", total)
+	}
+
+	// A word in the trash keeps its links for a restore but is not listed
+	if err := wordRepo.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, total, _ := wordRepo.List(ctx, 1, 10, "id", "asc", models.WordFilter{Character: "食"}); total != 1 {
+		t.Errorf("List() of 食 after delete = %d words, want 1", total)
+	}
+	var links int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM word_kanji WHERE word_id = 2`).Scan(&links); err != nil || links != 1 {
+		t.Errorf("word_kanji rows of a deleted word = %d, %v, want 1", links, err)
+	}
+
+	if _, err := implementations.NewTrashRepository(db).Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM word_kanji WHERE word_id = 2`).Scan(&links); err != nil || links != 0 {
+		t.Errorf("word_kanji rows of a purged word = %d, %v", links, err)
+	}
+}
+
+func TestKanjiMigrationLinksExistingWords(t *testing.T) {
+	db, wordRepo, _ := newSQLiteFixture(t)
+	ctx := context.Background()
+	files := sqliteFixtureMigrations(t)
+
+	// Revert the kanji tables, rename a word behind the repository's back
+	// and let the migration link it again
+	if _, err := db.MigrateTo(files, 8); err != nil {
+		t.Fatalf("error migrating down: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE words SET kanji = '日曜日' WHERE id = 4`); err != nil {
+		t.Fatalf("error renaming word: %v", err)
+	}
+	if _, err := db.MigrateUp(files); err != nil {
+		t.Fatalf("error migrating up: %v", err)
+	}
+
+	rows, err := db.Query(`SELECT character, position FROM word_kanji WHERE word_id = 4 ORDER BY position`)
+	if err != nil {
+		t.Fatalf("error listing links: %v", err)
+	}
+	defer rows.Close()
+	var links []string
+	for rows.Next() {
+		var character string
+		var position int
+		if err := rows.Scan(&character, &position); err != nil {
+			t.Fatalf("error scanning link: %v", err)
+		}
+		links = append(links, fmt.Sprintf("%s%d", character, position))
+	}
+	rows.Close()
+	if strings.Join(links, " ") != "日1 曜2" {
+		t.Errorf("links of 日曜日 = %v, want [日1 曜2]", links)
+	}
+
+	if _, total, _ := wordRepo.List(ctx, 1, 10, "id", "asc", models.WordFilter{Character: "食"}); total != 1 {
+		t.Errorf("List() of 食 after migrating = %d words, want 1", total)
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/internal/service"
+)
+
+func TestLanguageMigration(t *testing.T) {
+	db, wordRepo, groupRepo := newSQLiteFixture(t)
+	ctx := context.Background()
+
+	languages, err := implementations.NewLanguageRepository(db).List(ctx)
+	if err != nil || len(languages) != 2 {
+		t.Fatalf("List() of languages = %v, %v, want Japanese and Spanish", languages, err)
+	}
+
+	word, err := wordRepo.GetByID(ctx, 1)
+	if err != nil || word.Language != "ja" {
+		t.Fatalf("GetByID() = %+v, %v, want a Japanese word", word, err)
+	}
+	group, err := groupRepo.GetByID(ctx, 1)
+	if err != nil || group.Language != "ja" {
+		t.Fatalf("GetByID() of group = %+v, %v, want a Japanese group", group, err)
+	}
+
+	spanish := &models.Word{Language: "es", Kanji: "hablar", English: "to speak", Parts: map[string]any{}}
+	if err := wordRepo.Create(ctx, spanish); err != nil {
+		t.Fatalf("error creating Spanish word: %v", err)
+	}
+
+	// The compatibility view only holds the Japanese words
+	var kanji string
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*), MIN(kanji) FROM words`).Scan(&count, &kanji); err != nil || count != 4 {
+		t.Errorf("words view = %d words, %v, want 4", count, err)
+	}
+
+	filter := models.WordFilter{Conditions: []models.WordCondition{{Field: "language", Op: models.FilterEq, Values: []any{"es"}}}}
+	if words, total, err := wordRepo.List(ctx, 1, 10, "", "", filter); err != nil || total != 1 || words[0].ID != spanish.ID {
+		t.Errorf("List() of Spanish words = %d words, %v, want 1", total, err)
+	}
+
+	// Groups only take words of their language
+	if err := groupRepo.AddWord(ctx, 1, spanish.ID); err == nil {
+		t.Error("AddWord() of a Spanish word to a Japanese group succeeded")
+	}
+	verbs := &models.Group{Name: "Verbos", Language: "es"}
+	if err := groupRepo.Create(ctx, verbs); err != nil {
+		t.Fatalf("error creating Spanish group: %v", err)
+	}
+	if err := groupRepo.AddWord(ctx, verbs.ID, spanish.ID); err != nil {
+		t.Errorf("AddWord() of a Spanish word to a Spanish group error = %v", err)
+	}
+	if groups, total, err := groupRepo.List(ctx, 1, 10, "name", "asc", "es"); err != nil || total != 1 || groups[0].ID != verbs.ID {
+		t.Errorf("List() of Spanish groups = %d groups, %v, want 1", total, err)
+	}
+
+	// Reverting keeps the Japanese words under the former columns
+	if _, err := db.MigrateTo(sqliteFixtureMigrations(t), 10); err != nil {
+		t.Fatalf("error migrating down: %v", err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM words WHERE kanji <> ''`).Scan(&count); err != nil || count != 4 {
+		t.Errorf("words after migrating down = %d, %v, want 4", count, err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM groups`).Scan(&count); err != nil || count != 1 {
+		t.Errorf("groups after migrating down = %d, %v, want 1", count, err)
+	}
+}
+
+func TestWordSortKeys(t *testing.T) {
+	_, wordRepo, _ := newSQLiteFixture(t)
+	ctx := context.Background()
+	packs := service.DefaultLanguagePacks()
+
+	for _, term := range []string{"ñu", "nube", "oso"} {
+		word := &models.Word{Language: "es", Kanji: term, English: term, Parts: map[string]any{}}
+		if _, err := packs.PrepareWord(word); err != nil {
+			t.Fatalf("error preparing %s: %v", term, err)
+		}
+		if err := wordRepo.Create(ctx, word); err != nil {
+			t.Fatalf("error creating %s: %v", term, err)
+		}
+	}
+
+	// Terms sort by their key, ñ after n
+	filter := models.WordFilter{Conditions: []models.WordCondition{{Field: "language", Op: models.FilterEq, Values: []any{"es"}}}}
+	words, _, err := wordRepo.List(ctx, 1, 10, "term", "asc", filter)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var got []string
+	for _, word := range words {
+		got = append(got, word.Kanji)
+	}
+	if len(got) != 3 || got[0] != "nube" || got[1] != "ñu" || got[2] != "oso" {
+		t.Errorf("List() sorted by term = %v, want nube, ñu, oso", got)
+	}
+
+	// Words stored without a key sort by their term until the keys are
+	// backfilled
+	word, err := wordRepo.GetByID(ctx, 1)
+	if err != nil || word.SortKey != "食べる" {
+		t.Fatalf("GetByID() sort key = %q, %v, want the term", word.SortKey, err)
+	}
+	changed, err := wordRepo.SetSortKey(ctx, 1, "たべる")
+	if err != nil || !changed {
+		t.Errorf("SetSortKey() = %v, %v, want a change", changed, err)
+	}
+	if changed, _ := wordRepo.SetSortKey(ctx, 1, "たべる"); changed {
+		t.Error("SetSortKey() with the same key reported a change")
+	}
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"backend-go/internal/repository/sqlite"
+)
+
+func writeMigration(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("error writing %s: %v", name, err)
+	}
+}
+
+func newMigrationsFixture(t *testing.T) (*sqlite.Database, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeMigration(t, dir, "000001_notes.up.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT);")
+	writeMigration(t, dir, "000001_notes.down.sql", "DROP TABLE notes;")
+	writeMigration(t, dir, "000002_tags.up.sql", "CREATE TABLE tags (id INTEGER PRIMARY KEY, name TEXT);")
+	writeMigration(t, dir, "000002_tags.down.sql", "DROP TABLE tags;")
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("error opening database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, dir
+}
+
+func tableExists(t *testing.T, db *sqlite.Database, name string) bool {
+	t.Helper()
+	var count int
+	if err := db.DB.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&count); err != nil {
+		t.Fatalf("error checking table %s: %v", name, err)
+	}
+	return count > 0
+}
+
+func TestMigrations_UpIsTrackedAndRunsOnce(t *testing.T) {
+	db, dir := newMigrationsFixture(t)
+
+	applied, err := db.MigrateUp(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("error migrating up: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("expected 2 migrations applied, got %d", len(applied))
+	}
+
+	// A second run must not re-execute the non-idempotent CREATE TABLEs
+	applied, err = db.MigrateUp(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("error re-running migrations: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("expected no migrations applied on second run, got %d", len(applied))
+	}
+
+	statuses, err := db.MigrationStatus(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("error getting status: %v", err)
+	}
+	for _, status := range statuses {
+		if !status.Applied || status.AppliedAt == nil {
+			t.Errorf("expected migration %d to be applied", status.Version)
+		}
+	}
+}
+
+func TestMigrations_DownAndTo(t *testing.T) {
+	db, dir := newMigrationsFixture(t)
+	if _, err := db.MigrateUp(os.DirFS(dir)); err != nil {
+		t.Fatalf("error migrating up: %v", err)
+	}
+
+	if _, err := db.MigrateDown(os.DirFS(dir), 1); err != nil {
+		t.Fatalf("error migrating down: %v", err)
+	}
+	if tableExists(t, db, "tags") {
+		t.Error("expected tags table to be dropped")
+	}
+	if !tableExists(t, db, "notes") {
+		t.Error("expected notes table to remain")
+	}
+
+	if _, err := db.MigrateTo(os.DirFS(dir), 0); err != nil {
+		t.Fatalf("error migrating to 0: %v", err)
+	}
+	if tableExists(t, db, "notes") {
+		t.Error("expected notes table to be dropped")
+	}
+
+	ran, err := db.MigrateTo(os.DirFS(dir), 2)
+	if err != nil {
+		t.Fatalf("error migrating to 2: %v", err)
+	}
+	if len(ran) != 2 || !tableExists(t, db, "tags") {
+		t.Errorf("expected both migrations re-applied, got %d", len(ran))
+	}
+
+	if _, err := db.MigrateTo(os.DirFS(dir), 7); err == nil {
+		t.Error("expected error for unknown version")
+	}
+}
+
+func TestMigrations_RefuseChecksumDrift(t *testing.T) {
+	db, dir := newMigrationsFixture(t)
+	if _, err := db.MigrateUp(os.DirFS(dir)); err != nil {
+		t.Fatalf("error migrating up: %v", err)
+	}
+
+	writeMigration(t, dir, "000001_notes.up.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT, title TEXT);")
+
+	err := db.RunMigrations(os.DirFS(dir))
+	if err == nil || !strings.Contains(err.Error(), "changed since it was applied") {
+		t.Errorf("expected checksum drift error, got %v", err)
+	}
+
+	statuses, err := db.MigrationStatus(os.DirFS(dir))
+	if err != nil {
+		t.Fatalf("error getting status: %v", err)
+	}
+	if !statuses[0].Drifted || statuses[1].Drifted {
+		t.Errorf("expected only migration 1 to be flagged as drifted, got %+v", statuses)
+	}
+}
+
+func TestMigrations_RejectUnpairedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeMigration(t, dir, "000001_notes.up.sql", "CREATE TABLE notes (id INTEGER PRIMARY KEY);")
+
+	if _, err := sqlite.LoadMigrations(os.DirFS(dir)); err == nil {
+		t.Error("expected error for migration without down file")
+	}
+
+	writeMigration(t, dir, "000001_notes.down.sql", "DROP TABLE notes;")
+	writeMigration(t, dir, "000002_legacy.sql", "SELECT 1;")
+	if _, err := sqlite.LoadMigrations(os.DirFS(dir)); err == nil {
+		t.Error("expected error for legacy migration file name")
+	}
+}
@@ -0,0 +1,245 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/internal/service"
+)
+
+func newRevisionFixture(t *testing.T) (*sqlite.Database, *service.WordService) {
+	t.Helper()
+	db, wordRepo, _ := newSQLiteFixture(t)
+	wordService := service.NewWordService(
+		wordRepo,
+		implementations.NewSentenceRepository(db),
+		implementations.NewLanguageRepository(db),
+		implementations.NewRevisionRepository(db),
+		service.DefaultLanguagePacks(),
+	)
+	return db, wordService
+}
+
+func TestWordRevisions(t *testing.T) {
+	db, wordService := newRevisionFixture(t)
+	ctx := context.Background()
+
+	history, err := wordService.GetWordHistory(ctx, 1, 1, 10)
+	if err != nil || history.TotalItems != 1 || history.Revisions[0].Action != models.RevisionCreate || history.Revisions[0].Before != nil {
+		t.Fatalf("GetWordHistory() = %+v, %v, want the creation", history, err)
+	}
+
+	update := func(author, reason, english string) {
+		t.Helper()
+		word, err := wordService.GetWord(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetWord() error = %v", err)
+		}
+		word.English = english
+		change := models.WithChange(ctx, models.Change{Author: author, Reason: reason})
+		if _, err := wordService.UpdateWord(change, word); err != nil {
+			t.Fatalf("UpdateWord() error = %v", err)
+		}
+	}
+	update("sato", "more precise gloss", "to eat (a meal)")
+	update("suzuki", "", "to eat")
+	// Saving the word unchanged leaves no trace
+	update("suzuki", "", "to eat")
+
+	history, err = wordService.GetWordHistory(ctx, 1, 1, 10)
+	if err != nil || history.TotalItems != 3 {
+		t.Fatalf("GetWordHistory() = %+v, %v, want 3 revisions", history, err)
+	}
+	latest, sato := history.Revisions[0], history.Revisions[1]
+	if latest.Author != "suzuki" || sato.Author != "sato" || sato.Reason != "more precise gloss" {
+		t.Errorf("GetWordHistory() authors = %q, %q (%q)", latest.Author, sato.Author, sato.Reason)
+	}
+	var before, after models.Word
+	if err := json.Unmarshal(latest.Before, &before); err != nil || before.English != "to eat (a meal)" {
+		t.Errorf("before = %+v, %v, want sato's gloss", before, err)
+	}
+	if err := json.Unmarshal(latest.After, &after); err != nil || after.English != "to eat" || after.Kanji != "食べる" {
+		t.Errorf("after = %+v, %v, want the original gloss", after, err)
+	}
+
+	// Reverting restores sato's gloss and records who reverted it
+	revert := models.WithChange(ctx, models.Change{Author: "sato"})
+	word, _, err := wordService.RevertWord(revert, 1, sato.ID)
+	if err != nil || word.English != "to eat (a meal)" {
+		t.Fatalf("RevertWord() = %+v, %v, want sato's gloss", word, err)
+	}
+	history, err = wordService.GetWordHistory(ctx, 1, 1, 10)
+	if err != nil || history.TotalItems != 4 {
+		t.Fatalf("GetWordHistory() after revert = %+v, %v, want 4 revisions", history, err)
+	}
+	reverted := history.Revisions[0]
+	if reverted.Action != models.RevisionRevert || reverted.RevertedTo == nil || *reverted.RevertedTo != sato.ID ||
+		reverted.Author != "sato" || reverted.Reason != fmt.Sprintf("revert to revision %d", sato.ID) {
+		t.Errorf("revert revision = %+v", reverted)
+	}
+
+	// Revisions belong to one word
+	if _, _, err := wordService.RevertWord(ctx, 2, sato.ID); !errors.Is(err, service.ErrRevisionNotFound) {
+		t.Errorf("RevertWord() of another word's revision error = %v, want ErrRevisionNotFound", err)
+	}
+
+	// The history outlives the word, but a deletion has nothing to revert to
+	if err := wordService.DeleteWord(ctx, 4); err != nil {
+		t.Fatalf("DeleteWord() error = %v", err)
+	}
+	history, err = wordService.GetWordHistory(ctx, 4, 1, 10)
+	if err != nil || history.TotalItems != 2 || history.Revisions[0].Action != models.RevisionDelete || history.Revisions[0].After != nil {
+		t.Fatalf("GetWordHistory() of a deleted word = %+v, %v", history, err)
+	}
+	if _, _, err := wordService.RevertWord(ctx, 4, history.Revisions[0].ID); !errors.Is(err, service.ErrNothingToRevert) {
+		t.Errorf("RevertWord() to a deletion error = %v, want ErrNothingToRevert", err)
+	}
+	if _, err := wordService.GetWordHistory(ctx, 42, 1, 10); !errors.Is(err, service.ErrWordNotFound) {
+		t.Errorf("GetWordHistory() of an unknown word error = %v, want ErrWordNotFound", err)
+	}
+
+	// The history cannot be rewritten
+	if _, err := db.Exec(`UPDATE word_revisions SET author = 'nobody'`); err == nil {
+		t.Error("updating word_revisions succeeded")
+	}
+	if _, err := db.Exec(`DELETE FROM word_revisions`); err == nil {
+		t.Error("deleting from word_revisions succeeded")
+	}
+}
+
+func TestGroupAndActivityRevisions(t *testing.T) {
+	db, wordRepo, groupRepo := newSQLiteFixture(t)
+	ctx := context.Background()
+	revisionRepo := implementations.NewRevisionRepository(db)
+
+	groupService := service.NewGroupService(db, groupRepo, wordRepo, implementations.NewLanguageRepository(db), revisionRepo)
+	if err := groupService.UpdateGroup(ctx, &models.Group{ID: 1, Name: "Core Verbs"}); err != nil {
+		t.Fatalf("UpdateGroup() error = %v", err)
+	}
+	history, err := groupService.GetGroupHistory(ctx, 1, 1, 10)
+	if err != nil || history.TotalItems != 2 {
+		t.Fatalf("GetGroupHistory() = %+v, %v, want 2 revisions", history, err)
+	}
+	group, err := groupService.RevertGroup(ctx, 1, history.Revisions[1].ID)
+	if err != nil || group.Name != "Verbs" || group.WordsCount != 3 {
+		t.Errorf("RevertGroup() = %+v, %v, want Verbs with its 3 words", group, err)
+	}
+
+	// Moving a group is part of its history
+	basics := &models.Group{Name: "Basics"}
+	if err := groupService.CreateGroup(ctx, basics); err != nil {
+		t.Fatalf("CreateGroup() error = %v", err)
+	}
+	if _, err := groupService.MoveGroup(ctx, 1, &basics.ID, nil); err != nil {
+		t.Fatalf("MoveGroup() error = %v", err)
+	}
+	history, err = groupService.GetGroupHistory(ctx, 1, 1, 10)
+	if err != nil || history.TotalItems != 4 {
+		t.Fatalf("GetGroupHistory() = %+v, %v, want 4 revisions", history, err)
+	}
+	var before, after models.Group
+	if err := json.Unmarshal(history.Revisions[0].Before, &before); err != nil {
+		t.Fatalf("error reading revision: %v", err)
+	}
+	if err := json.Unmarshal(history.Revisions[0].After, &after); err != nil {
+		t.Fatalf("error reading revision: %v", err)
+	}
+	if before.ParentID != nil || after.ParentID == nil || *after.ParentID != basics.ID || after.Position != 0 {
+		t.Errorf("move revision = %s to %s, want group 1 moved under %d", history.Revisions[0].Before, history.Revisions[0].After, basics.ID)
+	}
+
+	activityService := service.NewStudyActivityService(implementations.NewStudyActivityRepository(db), implementations.NewStudySessionRepository(db), revisionRepo)
+	activity := &models.StudyActivity{Name: "Typing", URL: "http://localhost:8081"}
+	if err := activityService.CreateActivity(ctx, activity); err != nil {
+		t.Fatalf("CreateActivity() error = %v", err)
+	}
+	activity.URL = "http://localhost:9000"
+	if err := activityService.UpdateActivity(ctx, activity); err != nil {
+		t.Fatalf("UpdateActivity() error = %v", err)
+	}
+	history, err = activityService.GetActivityHistory(ctx, activity.ID, 1, 10)
+	if err != nil || history.TotalItems != 2 {
+		t.Fatalf("GetActivityHistory() = %+v, %v, want 2 revisions", history, err)
+	}
+	restored, err := activityService.RevertActivity(ctx, activity.ID, history.Revisions[1].ID)
+	if err != nil || restored.URL != "http://localhost:8081" {
+		t.Errorf("RevertActivity() = %+v, %v, want the first URL", restored, err)
+	}
+	// The fixture's activity was inserted without a history
+	if history, err := activityService.GetActivityHistory(ctx, 1, 1, 10); err != nil || history.TotalItems != 0 {
+		t.Errorf("GetActivityHistory() of an activity without revisions = %+v, %v", history, err)
+	}
+}
+
+func TestRevisionHandlers(t *testing.T) {
+	_, wordService := newRevisionFixture(t)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(handlers.RecordChange())
+	handler := handlers.NewWordHandler(wordService)
+	r.PUT("/api/words/:id", handler.UpdateWord)
+	r.GET("/api/words/:id/history", handler.GetWordHistory)
+	r.POST("/api/words/:id/revert/:revision_id", handler.RevertWord)
+
+	body, _ := json.Marshal(map[string]any{"kanji": "飲む", "romaji": "nomu", "english": "to drink; to swallow", "parts": map[string]any{"verb_type": "godan"}})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/api/words/2", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Author", "tanaka")
+	req.Header.Set("X-Change-Reason", "add a sense")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/words/2/history", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data struct {
+			Revisions []models.Revision `json:"revisions"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if !assert.Len(t, response.Data.Revisions, 2) {
+		return
+	}
+	assert.Equal(t, "tanaka", response.Data.Revisions[0].Author)
+	assert.Equal(t, "add a sense", response.Data.Revisions[0].Reason)
+	created := response.Data.Revisions[1].ID
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"revert", http.MethodPost, fmt.Sprintf("/api/words/2/revert/%d", created), http.StatusOK},
+		{"unknown revision", http.MethodPost, "/api/words/2/revert/999", http.StatusNotFound},
+		{"invalid revision id", http.MethodPost, "/api/words/2/revert/abc", http.StatusBadRequest},
+		{"unknown word history", http.MethodGet, "/api/words/999/history", http.StatusNotFound},
+		{"unknown word update", http.MethodPut, "/api/words/999", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
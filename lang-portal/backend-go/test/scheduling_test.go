@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/internal/service"
+)
+
+// failingScheduleRepository fails to store any schedule
+type failingScheduleRepository struct {
+	*implementations.ScheduleRepository
+}
+
+func (r failingScheduleRepository) Upsert(ctx context.Context, schedule *models.WordSchedule) error {
+	return errors.New("schedule full")
+}
+
+func newSchedulingFixture(t *testing.T) (*sqlite.Database, *implementations.ScheduleRepository, *implementations.GroupSettingsRepository, *implementations.GroupRepository) {
+	t.Helper()
+	db, _, groupRepo := newSQLiteFixture(t)
+	return db, implementations.NewScheduleRepository(db), implementations.NewGroupSettingsRepository(db), groupRepo
+}
+
+func TestSchedulingService_SetGroupSchedulerRollsBack(t *testing.T) {
+	db, scheduleRepo, settingsRepo, groupRepo := newSchedulingFixture(t)
+	ctx := context.Background()
+	scheduling := service.NewSchedulingService(db, failingScheduleRepository{scheduleRepo}, settingsRepo, groupRepo)
+
+	if _, err := scheduling.SetGroupScheduler(ctx, 1, "leitner"); err == nil {
+		t.Fatal("SetGroupScheduler() succeeded without storing the schedules")
+	}
+	if name, err := scheduling.GetGroupScheduler(ctx, 1); err != nil || name != service.DefaultScheduler {
+		t.Errorf("GetGroupScheduler() after a failed replay = %q, %v, want %q", name, err, service.DefaultScheduler)
+	}
+}
+
+func TestSchedulingService_ReplayAll(t *testing.T) {
+	db, scheduleRepo, settingsRepo, groupRepo := newSchedulingFixture(t)
+	ctx := context.Background()
+	scheduling := service.NewSchedulingService(db, scheduleRepo, settingsRepo, groupRepo)
+
+	// 食べる is also in a Leitner group, where it was reviewed last
+	food := &models.Group{Name: "Food"}
+	if err := groupRepo.Create(ctx, food); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := groupRepo.AddWord(ctx, food.ID, 1); err != nil {
+		t.Fatalf("AddWord() error = %v", err)
+	}
+	if err := settingsRepo.SetScheduler(ctx, food.ID, "leitner"); err != nil {
+		t.Fatalf("SetScheduler() error = %v", err)
+	}
+	for _, query := range []string{
+		`INSERT INTO study_sessions (id, group_id, study_activity_id) VALUES (2, 2, 1)`,
+		`INSERT INTO word_review_items (word_id, study_session_id, correct, created_at) VALUES (1, 2, true, datetime('now', '+1 minute'))`,
+	} {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("error adding reviews: %v", err)
+		}
+	}
+
+	// Replaying twice, or after either group was replayed on its own,
+	// leaves the same schedules; replaying the fixture's group leaves 食べる
+	// to the Leitner group
+	checkSchedules := func(replayed string) {
+		t.Helper()
+		for wordID, want := range map[int64]string{1: "leitner", 2: service.DefaultScheduler} {
+			schedule, err := scheduleRepo.Get(ctx, wordID)
+			if err != nil || schedule == nil || schedule.Algorithm != want {
+				t.Errorf("schedule of word %d after %s = %+v, %v, want %s", wordID, replayed, schedule, err, want)
+			}
+		}
+	}
+	for _, replayFirst := range []int64{0, 1, food.ID} {
+		if replayFirst != 0 {
+			if _, err := scheduling.ReplayGroup(ctx, replayFirst); err != nil {
+				t.Fatalf("ReplayGroup(%d) error = %v", replayFirst, err)
+			}
+			checkSchedules(fmt.Sprintf("ReplayGroup(%d)", replayFirst))
+		}
+		result, err := scheduling.ReplayAll(ctx)
+		if err != nil || result.ReviewsReplayed != 3 || result.WordsRescheduled != 2 {
+			t.Fatalf("ReplayAll() = %+v, %v, want 3 reviews of 2 words", result, err)
+		}
+		checkSchedules("ReplayAll()")
+	}
+}
+
+func TestStudySessionService_AddReviewRollsBack(t *testing.T) {
+	db, scheduleRepo, settingsRepo, groupRepo := newSchedulingFixture(t)
+	ctx := context.Background()
+	scheduling := service.NewSchedulingService(db, failingScheduleRepository{scheduleRepo}, settingsRepo, groupRepo)
+	sessionService := service.NewStudySessionService(db, implementations.NewStudySessionRepository(db), groupRepo, scheduling)
+
+	countReviews := func() int {
+		t.Helper()
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM word_review_items`).Scan(&count); err != nil {
+			t.Fatalf("error counting reviews: %v", err)
+		}
+		return count
+	}
+	before := countReviews()
+
+	if _, err := sessionService.AddReview(ctx, 1, 1, true); err == nil {
+		t.Fatal("AddReview() succeeded without storing the schedule")
+	}
+	if after := countReviews(); after != before {
+		t.Errorf("AddReview() left %d reviews after failing, want %d", after, before)
+	}
+}
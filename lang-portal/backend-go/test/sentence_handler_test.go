@@ -0,0 +1,74 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/service"
+)
+
+func setupSentenceTest(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	wordRepo := service.NewMockWordRepository()
+	word := &models.Word{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{}}
+	if err := wordRepo.Create(context.Background(), word); err != nil {
+		t.Fatalf("Failed to create test word: %v", err)
+	}
+
+	sentenceService := service.NewSentenceService(service.NewMockSentenceRepository(), wordRepo)
+	handler := handlers.NewSentenceHandler(sentenceService)
+
+	r.GET("/api/sentences", handler.ListSentences)
+	r.GET("/api/sentences/:id", handler.GetSentence)
+	r.POST("/api/sentences", handler.CreateSentence)
+	r.PUT("/api/sentences/:id", handler.UpdateSentence)
+	r.DELETE("/api/sentences/:id", handler.DeleteSentence)
+	r.GET("/api/words/:id/sentences", handler.GetWordSentences)
+
+	return r
+}
+
+func TestSentenceHandler(t *testing.T) {
+	r := setupSentenceTest(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{"create", http.MethodPost, "/api/sentences", `{"japanese": "魚を食べる。", "english": "I eat fish.", "word_ids": [1]}`, http.StatusCreated},
+		{"create without english", http.MethodPost, "/api/sentences", `{"japanese": "魚を食べる。"}`, http.StatusBadRequest},
+		{"create with unknown word", http.MethodPost, "/api/sentences", `{"japanese": "魚を食べる。", "english": "I eat fish.", "word_ids": [42]}`, http.StatusBadRequest},
+		{"get", http.MethodGet, "/api/sentences/1", "", http.StatusOK},
+		{"get unknown", http.MethodGet, "/api/sentences/42", "", http.StatusNotFound},
+		{"list", http.MethodGet, "/api/sentences?q=fish", "", http.StatusOK},
+		{"word sentences", http.MethodGet, "/api/words/1/sentences", "", http.StatusOK},
+		{"unknown word sentences", http.MethodGet, "/api/words/42/sentences", "", http.StatusNotFound},
+		{"update", http.MethodPut, "/api/sentences/1", `{"japanese": "肉を食べる。", "english": "I eat meat.", "word_ids": [1]}`, http.StatusOK},
+		{"update unknown", http.MethodPut, "/api/sentences/42", `{"japanese": "肉を食べる。", "english": "I eat meat."}`, http.StatusNotFound},
+		{"delete", http.MethodDelete, "/api/sentences/1", "", http.StatusNoContent},
+		{"delete again", http.MethodDelete, "/api/sentences/1", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
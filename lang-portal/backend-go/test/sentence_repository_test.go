@@ -0,0 +1,52 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite/implementations"
+)
+
+func TestSentenceRepository_WordLinks(t *testing.T) {
+	db, _, _ := newSQLiteFixture(t)
+	repo := implementations.NewSentenceRepository(db)
+	ctx := context.Background()
+
+	long := &models.Sentence{Japanese: "毎朝パンを食べてから学校に行く。", English: "Every morning I eat bread before going to school.", WordIDs: []int64{1, 3}}
+	short := &models.Sentence{Japanese: "パンを食べる。", English: "I eat bread.", Source: "Tatoeba", WordIDs: []int64{1}}
+	for _, sentence := range []*models.Sentence{long, short} {
+		if err := repo.Create(ctx, sentence); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	sentences, total, err := repo.ListByWord(ctx, 1, 1, 10)
+	if err != nil {
+		t.Fatalf("ListByWord() error = %v", err)
+	}
+	if total != 2 || len(sentences) != 2 || sentences[0].ID != short.ID {
+		t.Errorf("ListByWord() = %d sentences (total %d), want the shorter sentence first", len(sentences), total)
+	}
+	if len(sentences[1].WordIDs) != 2 {
+		t.Errorf("ListByWord() word ids = %v, want [1 3]", sentences[1].WordIDs)
+	}
+
+	long.WordIDs = []int64{3}
+	if err := repo.Update(ctx, long); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, total, _ := repo.ListByWord(ctx, 1, 1, 10); total != 1 {
+		t.Errorf("ListByWord() after unlinking = %d sentences, want 1", total)
+	}
+
+	if err := repo.Delete(ctx, short.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if sentence, err := repo.GetByID(ctx, short.ID); err != nil || sentence != nil {
+		t.Errorf("GetByID() after delete = %+v, %v", sentence, err)
+	}
+	if sentences, total, err := repo.List(ctx, "school", 1, 10); err != nil || total != 1 || sentences[0].ID != long.ID {
+		t.Errorf("List() = %d sentences, %v", total, err)
+	}
+}
@@ -0,0 +1,169 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/internal/service"
+)
+
+func TestSmartGroups(t *testing.T) {
+	db, wordRepo, groupRepo := newSQLiteFixture(t)
+	ctx := context.Background()
+	groupService := service.NewGroupService(db, groupRepo, wordRepo, implementations.NewLanguageRepository(db), implementations.NewRevisionRepository(db))
+	scheduleRepo := implementations.NewScheduleRepository(db)
+
+	// 行く was answered wrong a month ago, 食べる in the fixture just now
+	if _, err := db.Exec(`INSERT INTO word_review_items (word_id, study_session_id, correct, created_at) VALUES (3, 1, false, datetime('now', '-30 days'))`); err != nil {
+		t.Fatalf("error adding review: %v", err)
+	}
+	spanish := &models.Word{Language: "es", Kanji: "comer", English: "to eat", Parts: map[string]any{"topic": "food"}}
+	if err := wordRepo.Create(ctx, spanish); err != nil {
+		t.Fatalf("Failed to create test word: %v", err)
+	}
+	drinks := &models.Group{Name: "Drinks"}
+	if err := groupService.CreateGroup(ctx, drinks); err != nil {
+		t.Fatalf("CreateGroup() error = %v", err)
+	}
+	if _, err := groupService.AddWordsToGroup(ctx, drinks.ID, []int64{2}); err != nil {
+		t.Fatalf("AddWordsToGroup() error = %v", err)
+	}
+
+	condition := func(field string, op models.FilterOp, values ...any) models.WordCondition {
+		return models.WordCondition{Field: field, Op: op, Values: values}
+	}
+	recentMistakes := &models.Group{Name: "Recent mistakes", Filter: &models.WordFilter{
+		Conditions: []models.WordCondition{condition("days_since_wrong", models.FilterLte, 7.0)},
+	}}
+	food := &models.Group{Name: "Food but not drinks", Filter: &models.WordFilter{
+		ExcludeGroupIDs: []int64{drinks.ID},
+		Conditions:      []models.WordCondition{condition("parts.topic", models.FilterEq, "food")},
+	}}
+	for _, group := range []*models.Group{recentMistakes, food} {
+		if err := groupService.CreateGroup(ctx, group); err != nil {
+			t.Fatalf("CreateGroup(%q) error = %v", group.Name, err)
+		}
+	}
+
+	members := func(groupID int64) []int64 {
+		t.Helper()
+		words, _, err := groupRepo.ListWords(ctx, groupID, 1, 10, models.WordFilter{})
+		if err != nil {
+			t.Fatalf("ListWords(%d) error = %v", groupID, err)
+		}
+		ids := []int64{}
+		for _, word := range words {
+			ids = append(ids, word.ID)
+		}
+		return ids
+	}
+	wordsCount := func(groupID int64) int {
+		t.Helper()
+		group, err := groupRepo.GetByID(ctx, groupID)
+		if err != nil || group == nil {
+			t.Fatalf("GetByID(%d) = %v, %v", groupID, group, err)
+		}
+		return group.WordsCount
+	}
+
+	if got := members(recentMistakes.ID); len(got) != 1 || got[0] != 1 {
+		t.Errorf("members of recent mistakes = %v, want [1]", got)
+	}
+	if got := members(food.ID); len(got) != 1 || got[0] != 1 {
+		t.Errorf("members of food but not drinks = %v, want [1]", got)
+	}
+
+	// Membership and the words count follow the groups the filter names
+	if _, err := groupService.RemoveWordsFromGroup(ctx, drinks.ID, []int64{2}); err != nil {
+		t.Fatalf("RemoveWordsFromGroup() error = %v", err)
+	}
+	if got := members(food.ID); len(got) != 2 {
+		t.Errorf("members of food but not drinks after a removal = %v, want [1 2]", got)
+	}
+	if count := wordsCount(food.ID); count != 2 {
+		t.Errorf("words count of food but not drinks = %d, want 2", count)
+	}
+	groups, _, err := groupRepo.List(ctx, 1, 10, "name", "asc", "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, group := range groups {
+		if group.ID == food.ID && (group.WordsCount != 2 || !group.Smart()) {
+			t.Errorf("List() smart group = %+v, want 2 words", group)
+		}
+	}
+
+	// Smart groups work wherever a group id does
+	if member, err := groupRepo.HasWord(ctx, recentMistakes.ID, 1); err != nil || !member {
+		t.Errorf("HasWord() = %v, %v, want true", member, err)
+	}
+	words, _, err := groupService.GetGroupWords(ctx, recentMistakes.ID, 1, "kanji", "asc", models.WordFilter{})
+	if err != nil || len(words.Words) != 1 || words.Words[0].Kanji != "食べる" {
+		t.Errorf("GetGroupWords() = %+v, %v, want 食べる", words, err)
+	}
+	due, err := scheduleRepo.ListDue(ctx, food.ID, "", time.Now(), 10)
+	if err != nil || len(due) != 2 {
+		t.Errorf("ListDue() = %d words, %v, want 2", len(due), err)
+	}
+	sessionService := service.NewStudySessionService(db, implementations.NewStudySessionRepository(db), groupRepo, nil)
+	if _, err := sessionService.CreateSession(ctx, service.CreateSessionParams{GroupID: food.ID, StudyActivityID: 1}); err != nil {
+		t.Errorf("CreateSession() error = %v", err)
+	}
+	if sessions, err := groupService.GetGroupStudySessions(ctx, food.ID, 1, 10); err != nil || sessions.TotalItems != 1 {
+		t.Errorf("GetGroupStudySessions() = %+v, %v, want one session", sessions, err)
+	}
+
+	// Changing the filter changes the words at once
+	food.Filter.Conditions = append(food.Filter.Conditions, condition("parts.jlpt", models.FilterEq, 5.0))
+	if err := groupService.UpdateGroup(ctx, food); err != nil {
+		t.Fatalf("UpdateGroup() error = %v", err)
+	}
+	if got := members(food.ID); len(got) != 1 || got[0] != 2 {
+		t.Errorf("members after changing the filter = %v, want [2]", got)
+	}
+
+	tests := []struct {
+		name    string
+		run     func() error
+		wantErr error
+	}{
+		{"add words", func() error {
+			_, err := groupService.AddWordsToGroup(ctx, food.ID, []int64{3})
+			return err
+		}, service.ErrSmartGroup},
+		{"move words out", func() error {
+			_, err := groupService.MoveWords(ctx, recentMistakes.ID, drinks.ID, []int64{1})
+			return err
+		}, service.ErrSmartGroup},
+		{"turn a group smart", func() error {
+			return groupService.UpdateGroup(ctx, &models.Group{ID: drinks.ID, Name: "Drinks", Filter: &models.WordFilter{}})
+		}, service.ErrInvalidGroup},
+		{"name a smart group", func() error {
+			return groupService.CreateGroup(ctx, &models.Group{Name: "Nested", Filter: &models.WordFilter{GroupID: food.ID}})
+		}, service.ErrInvalidFilter},
+		{"name an unknown group", func() error {
+			return groupService.CreateGroup(ctx, &models.Group{Name: "Unknown", Filter: &models.WordFilter{ExcludeGroupIDs: []int64{42}}})
+		}, service.ErrInvalidFilter},
+		{"unknown field", func() error {
+			return groupService.CreateGroup(ctx, &models.Group{Name: "Levels", Filter: &models.WordFilter{
+				Conditions: []models.WordCondition{condition("level", models.FilterEq, 3.0)},
+			}})
+		}, service.ErrInvalidFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Copying keeps what a smart group matches in a group of its own
+	if copied, err := groupService.CopyWords(ctx, recentMistakes.ID, drinks.ID, []int64{1}); err != nil || copied != 1 {
+		t.Errorf("CopyWords() = %d, %v, want 1", copied, err)
+	}
+}
@@ -21,7 +21,7 @@ func setupActivityTest() (*gin.Engine, *service.StudyActivityService) {
 
 	mockRepo := service.NewMockStudyActivityRepository()
 	sessionRepo := service.NewMockStudySessionRepository()
-	activityService := service.NewStudyActivityService(mockRepo, sessionRepo)
+	activityService := service.NewStudyActivityService(mockRepo, sessionRepo, service.NewMockRevisionRepository())
 	handler := handlers.NewStudyActivityHandler(activityService)
 
 	// Setup routes
@@ -0,0 +1,312 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"backend-go/internal/api/handlers"
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/internal/service"
+)
+
+func newTrashFixture(t *testing.T) (*sqlite.Database, *implementations.WordRepository, *implementations.GroupRepository, *service.TrashService) {
+	t.Helper()
+	db, wordRepo, groupRepo := newSQLiteFixture(t)
+	trashService := service.NewTrashService(
+		implementations.NewTrashRepository(db),
+		wordRepo,
+		groupRepo,
+		implementations.NewStudyActivityRepository(db),
+	)
+	return db, wordRepo, groupRepo, trashService
+}
+
+func TestTrash_DeleteAndRestoreWord(t *testing.T) {
+	db, wordRepo, groupRepo, trashService := newTrashFixture(t)
+	ctx := context.Background()
+
+	if err := wordRepo.Delete(ctx, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := wordRepo.Delete(ctx, 2); err == nil {
+		t.Error("Delete() of a word in the trash succeeded")
+	}
+
+	// The word is gone from every read but keeps its reviews
+	if word, err := wordRepo.GetByID(ctx, 2); err != nil || word != nil {
+		t.Errorf("GetByID() of a deleted word = %+v, %v", word, err)
+	}
+	if _, total, _ := wordRepo.List(ctx, 1, 10, "id", "asc", models.WordFilter{}); total != 3 {
+		t.Errorf("List() total = %d, want 3", total)
+	}
+	if _, total, _ := groupRepo.ListWords(ctx, 1, 1, 10, models.WordFilter{}); total != 2 {
+		t.Errorf("ListWords() total = %d, want 2", total)
+	}
+	if group, _ := groupRepo.GetByID(ctx, 1); group == nil || group.WordsCount != 2 {
+		t.Errorf("GetByID() of the group = %+v, want 2 words", group)
+	}
+	var reviews int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM word_review_items WHERE word_id = 2`).Scan(&reviews); err != nil || reviews != 1 {
+		t.Errorf("reviews of a deleted word = %d, %v, want 1", reviews, err)
+	}
+
+	trash, err := trashService.ListTrash(ctx, "", 1, 10)
+	if err != nil || trash.TotalItems != 1 {
+		t.Fatalf("ListTrash() = %+v, %v, want one item", trash, err)
+	}
+	if item := trash.Items[0]; item.Type != models.RevisionWord || item.ID != 2 || item.Name != "飲む" || item.DeletedAt.IsZero() {
+		t.Errorf("ListTrash() item = %+v", item)
+	}
+	if trash, _ := trashService.ListTrash(ctx, "group", 1, 10); trash.TotalItems != 0 {
+		t.Errorf("ListTrash() of groups = %d items, want 0", trash.TotalItems)
+	}
+
+	restored, err := trashService.Restore(ctx, "word", 2)
+	if word, ok := restored.(*models.Word); err != nil || !ok || word.Kanji != "飲む" {
+		t.Fatalf("Restore() = %+v, %v, want 飲む", restored, err)
+	}
+	if group, _ := groupRepo.GetByID(ctx, 1); group == nil || group.WordsCount != 3 {
+		t.Errorf("GetByID() of the group after restore = %+v, want 3 words", group)
+	}
+	if _, err := trashService.Restore(ctx, "word", 2); !errors.Is(err, service.ErrTrashItemNotFound) {
+		t.Errorf("Restore() of a word not in the trash error = %v, want ErrTrashItemNotFound", err)
+	}
+	if _, err := trashService.Restore(ctx, "sentence", 1); !errors.Is(err, service.ErrInvalidTrashType) {
+		t.Errorf("Restore() of a sentence error = %v, want ErrInvalidTrashType", err)
+	}
+
+	history, _, err := implementations.NewRevisionRepository(db).List(ctx, models.RevisionWord, 2, 1, 10)
+	if err != nil || len(history) != 3 || history[0].Action != models.RevisionRestore || history[1].Action != models.RevisionDelete {
+		t.Errorf("word history = %d revisions, %v, want create, delete and restore", len(history), err)
+	}
+}
+
+func TestTrash_Purge(t *testing.T) {
+	db, wordRepo, groupRepo, trashService := newTrashFixture(t)
+	ctx := context.Background()
+	activityRepo := implementations.NewStudyActivityRepository(db)
+
+	if err := wordRepo.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() of the word error = %v", err)
+	}
+	if err := groupRepo.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() of the group error = %v", err)
+	}
+	if err := activityRepo.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() of the activity error = %v", err)
+	}
+	if groups, total, _ := groupRepo.List(ctx, 1, 10, "name", "asc", ""); total != 0 || len(groups) != 0 {
+		t.Errorf("List() of groups = %d, want none", total)
+	}
+	if activities, _ := activityRepo.List(ctx); len(activities) != 0 {
+		t.Errorf("List() of activities = %d, want none", len(activities))
+	}
+
+	// Nothing has been in the trash for a day yet
+	result, err := trashService.Purge(ctx, 24*time.Hour)
+	if err != nil || *result != (models.PurgeResult{}) {
+		t.Fatalf("Purge() = %+v, %v, want nothing purged", result, err)
+	}
+
+	result, err = trashService.Purge(ctx, -time.Hour)
+	if err != nil || *result != (models.PurgeResult{Words: 1, Groups: 1, StudyActivities: 1}) {
+		t.Fatalf("Purge() = %+v, %v, want one of each", result, err)
+	}
+	for _, check := range []struct {
+		query string
+		want  int
+	}{
+		{`SELECT COUNT(*) FROM terms`, 3},
+		{`SELECT COUNT(*) FROM word_groups`, 0},
+		{`SELECT COUNT(*) FROM study_sessions`, 0},
+		{`SELECT COUNT(*) FROM word_review_items`, 0},
+		{`SELECT COUNT(*) FROM word_revisions WHERE word_id = 1`, 2},
+	} {
+		var count int
+		if err := db.QueryRow(check.query).Scan(&count); err != nil || count != check.want {
+			t.Errorf("%s = %d, %v, want %d", check.query, count, err, check.want)
+		}
+	}
+
+	if _, err := trashService.Restore(ctx, "group", 1); !errors.Is(err, service.ErrTrashItemNotFound) {
+		t.Errorf("Restore() of a purged group error = %v, want ErrTrashItemNotFound", err)
+	}
+}
+
+func TestTrash_PurgeRelations(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, ctx context.Context, wordRepo *implementations.WordRepository, groupRepo *implementations.GroupRepository)
+		want  models.PurgeResult
+		check func(t *testing.T, ctx context.Context, groupRepo *implementations.GroupRepository)
+	}{
+		{
+			name: "groups under a purged group move to the top",
+			setup: func(t *testing.T, ctx context.Context, _ *implementations.WordRepository, groupRepo *implementations.GroupRepository) {
+				parent := &models.Group{Name: "Unit 1"}
+				if err := groupRepo.Create(ctx, parent); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if err := groupRepo.Create(ctx, &models.Group{Name: "Lesson 1", ParentID: &parent.ID}); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if err := groupRepo.Delete(ctx, parent.ID); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+			},
+			want: models.PurgeResult{Groups: 1},
+			check: func(t *testing.T, ctx context.Context, groupRepo *implementations.GroupRepository) {
+				lesson, err := groupRepo.GetByID(ctx, 3)
+				if err != nil || lesson == nil || lesson.ParentID != nil || lesson.Position != 1 {
+					t.Errorf("GetByID() of the lesson = %+v, %v, want it second at the top", lesson, err)
+				}
+			},
+		},
+		{
+			name: "smart groups stop excluding a purged group",
+			setup: func(t *testing.T, ctx context.Context, _ *implementations.WordRepository, groupRepo *implementations.GroupRepository) {
+				all := &models.Group{Name: "All"}
+				if err := groupRepo.Create(ctx, all); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if _, err := groupRepo.AddWords(ctx, all.ID, []int64{1, 4}, 0); err != nil {
+					t.Fatalf("AddWords() error = %v", err)
+				}
+				smart := &models.Group{Name: "Not verbs", Filter: &models.WordFilter{GroupID: all.ID, ExcludeGroupIDs: []int64{1}}}
+				if err := groupRepo.Create(ctx, smart); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if err := groupRepo.Delete(ctx, 1); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+			},
+			want: models.PurgeResult{Groups: 1},
+			check: func(t *testing.T, ctx context.Context, groupRepo *implementations.GroupRepository) {
+				smart, err := groupRepo.GetByID(ctx, 3)
+				if err != nil || smart == nil || smart.Filter == nil || smart.Filter.GroupID != 2 || len(smart.Filter.ExcludeGroupIDs) != 0 {
+					t.Errorf("GetByID() of the smart group = %+v, %v, want no excluded groups", smart, err)
+				}
+				if _, total, err := groupRepo.ListWords(ctx, 3, 1, 10, models.WordFilter{}); err != nil || total != 2 {
+					t.Errorf("ListWords() of the smart group = %d, %v, want 2", total, err)
+				}
+			},
+		},
+		{
+			name: "a group a smart group draws from stays in the trash",
+			setup: func(t *testing.T, ctx context.Context, _ *implementations.WordRepository, groupRepo *implementations.GroupRepository) {
+				smart := &models.Group{Name: "Food verbs", Filter: &models.WordFilter{GroupID: 1}}
+				if err := groupRepo.Create(ctx, smart); err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if err := groupRepo.Delete(ctx, 1); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+			},
+			want: models.PurgeResult{},
+			check: func(t *testing.T, ctx context.Context, groupRepo *implementations.GroupRepository) {
+				if restored, err := groupRepo.Restore(ctx, 1); err != nil || !restored {
+					t.Errorf("Restore() of the group = %v, %v, want it restored", restored, err)
+				}
+				if _, total, err := groupRepo.ListWords(ctx, 2, 1, 10, models.WordFilter{}); err != nil || total != 3 {
+					t.Errorf("ListWords() of the smart group = %d, %v, want 3", total, err)
+				}
+			},
+		},
+		{
+			name: "groups are renumbered without the purged words",
+			setup: func(t *testing.T, ctx context.Context, wordRepo *implementations.WordRepository, _ *implementations.GroupRepository) {
+				if err := wordRepo.Delete(ctx, 2); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+			},
+			want: models.PurgeResult{Words: 1},
+			check: func(t *testing.T, ctx context.Context, groupRepo *implementations.GroupRepository) {
+				words, _, err := groupRepo.ListWords(ctx, 1, 1, 10, models.WordFilter{})
+				if err != nil || len(words) != 2 || words[0].ID != 1 || words[1].ID != 3 {
+					t.Errorf("ListWords() = %d words, %v, want 食べる and 行く", len(words), err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, wordRepo, groupRepo, trashService := newTrashFixture(t)
+			ctx := context.Background()
+			tt.setup(t, ctx, wordRepo, groupRepo)
+
+			result, err := trashService.Purge(ctx, -time.Hour)
+			if err != nil || *result != tt.want {
+				t.Fatalf("Purge() = %+v, %v, want %+v", result, err, tt.want)
+			}
+			tt.check(t, ctx, groupRepo)
+
+			var gaps int
+			query := `
+				SELECT COUNT(*) FROM word_groups wg
+				WHERE position >= (SELECT COUNT(*) FROM word_groups WHERE group_id = wg.group_id)`
+			if err := db.QueryRow(query).Scan(&gaps); err != nil || gaps != 0 {
+				t.Errorf("word positions past the end of their group = %d, %v, want none", gaps, err)
+			}
+		})
+	}
+}
+
+func TestTrashHandlers(t *testing.T) {
+	_, wordRepo, _, trashService := newTrashFixture(t)
+	if err := wordRepo.Delete(context.Background(), 3); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	handler := handlers.NewTrashHandler(trashService)
+	r.GET("/api/trash", handler.ListTrash)
+	r.POST("/api/trash/:type/:id/restore", handler.RestoreItem)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/trash?type=word", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data struct {
+			Items []models.TrashItem `json:"items"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Data.Items, 1) {
+		assert.Equal(t, "行く", response.Data.Items[0].Name)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"invalid type filter", http.MethodGet, "/api/trash?type=sentence", http.StatusBadRequest},
+		{"restore", http.MethodPost, "/api/trash/word/3/restore", http.StatusOK},
+		{"restore again", http.MethodPost, "/api/trash/word/3/restore", http.StatusNotFound},
+		{"invalid type", http.MethodPost, "/api/trash/sentence/3/restore", http.StatusBadRequest},
+		{"invalid id", http.MethodPost, "/api/trash/word/abc/restore", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
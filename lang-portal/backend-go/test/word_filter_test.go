@@ -0,0 +1,161 @@
+package test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/migrations"
+)
+
+// sqliteFixtureMigrations are the real migrations, leaving out the FTS5
+// index which needs the sqlite_fts5 build tag
+func sqliteFixtureMigrations(t *testing.T) fs.FS {
+	t.Helper()
+	files := fstest.MapFS{}
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		t.Fatalf("error reading migrations: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), "_words_fts.") || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		data, err := fs.ReadFile(migrations.FS, entry.Name())
+		if err != nil {
+			t.Fatalf("error reading %s: %v", entry.Name(), err)
+		}
+		files[entry.Name()] = &fstest.MapFile{Data: data}
+	}
+	return files
+}
+
+// newSQLiteFixture migrates a fresh database with sqliteFixtureMigrations
+// and adds a few words, a group and reviews
+func newSQLiteFixture(t *testing.T) (*sqlite.Database, *implementations.WordRepository, *implementations.GroupRepository) {
+	t.Helper()
+	files := sqliteFixtureMigrations(t)
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("error opening database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.MigrateUp(files); err != nil {
+		t.Fatalf("error migrating up: %v", err)
+	}
+
+	ctx := context.Background()
+	wordRepo := implementations.NewWordRepository(db)
+	groupRepo := implementations.NewGroupRepository(db)
+	words := []*models.Word{
+		{Kanji: "食べる", Reading: "たべる", Romaji: "taberu", English: "to eat", Parts: map[string]any{"verb_type": "ichidan", "topic": "food"}},
+		{Kanji: "飲む", Reading: "のむ", Romaji: "nomu", English: "to drink", Parts: map[string]any{"verb_type": "godan", "topic": "food", "jlpt": 5}},
+		{Kanji: "行く", Reading: "いく", Romaji: "iku", English: "to go", Parts: map[string]any{"verb_type": "godan", "jlpt": 4}},
+		{Kanji: "高い", Reading: "たかい", Romaji: "takai", English: "expensive", Parts: map[string]any{"adjective_type": "i-adjective"}},
+	}
+	for _, word := range words {
+		if err := wordRepo.Create(ctx, word); err != nil {
+			t.Fatalf("error creating test word: %v", err)
+		}
+	}
+	group := &models.Group{Name: "Verbs"}
+	if err := groupRepo.Create(ctx, group); err != nil {
+		t.Fatalf("error creating test group: %v", err)
+	}
+	for _, id := range []int64{1, 2, 3} {
+		if err := groupRepo.AddWord(ctx, group.ID, id); err != nil {
+			t.Fatalf("error adding word to group: %v", err)
+		}
+	}
+
+	// 食べる is answered wrong once, 飲む right once
+	for _, review := range []string{
+		`INSERT INTO study_activities (id, name, url) VALUES (1, 'Flashcards', 'http://localhost')`,
+		`INSERT INTO study_sessions (id, group_id, study_activity_id) VALUES (1, 1, 1)`,
+		`INSERT INTO word_review_items (word_id, study_session_id, correct) VALUES (1, 1, false), (2, 1, true)`,
+	} {
+		if _, err := db.Exec(review); err != nil {
+			t.Fatalf("error adding reviews: %v", err)
+		}
+	}
+
+	return db, wordRepo, groupRepo
+}
+
+func TestWordRepository_ListFilter(t *testing.T) {
+	_, wordRepo, groupRepo := newSQLiteFixture(t)
+	ctx := context.Background()
+
+	condition := func(field string, op models.FilterOp, values ...any) models.WordCondition {
+		return models.WordCondition{Field: field, Op: op, Values: values}
+	}
+	tests := []struct {
+		name   string
+		filter models.WordFilter
+		want   []string
+	}{
+		{"no filter", models.WordFilter{}, []string{"行く", "食べる", "飲む", "高い"}},
+		{"indexed parts column", models.WordFilter{Conditions: []models.WordCondition{
+			condition("parts.topic", models.FilterEq, "food"),
+			condition("parts.verb_type", models.FilterEq, "godan"),
+		}}, []string{"飲む"}},
+		{"json_extract number", models.WordFilter{Conditions: []models.WordCondition{
+			condition("parts.jlpt", models.FilterGte, 5.0),
+		}}, []string{"飲む"}},
+		{"not equal includes missing keys", models.WordFilter{Conditions: []models.WordCondition{
+			condition("parts.topic", models.FilterNe, "food"),
+		}}, []string{"行く", "高い"}},
+		{"in", models.WordFilter{Conditions: []models.WordCondition{
+			condition("parts.verb_type", models.FilterIn, "ichidan", "godan"),
+		}}, []string{"行く", "食べる", "飲む"}},
+		{"exists", models.WordFilter{Conditions: []models.WordCondition{
+			condition("parts.adjective_type", models.FilterExists, true),
+		}}, []string{"高い"}},
+		{"accuracy and group", models.WordFilter{GroupID: 1, Conditions: []models.WordCondition{
+			condition("accuracy", models.FilterLt, 60.0),
+		}}, []string{"行く", "食べる"}},
+		{"like", models.WordFilter{Conditions: []models.WordCondition{
+			condition("english", models.FilterLike, "to d%"),
+		}}, []string{"飲む"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, total, err := wordRepo.List(ctx, 1, 10, "kanji", "asc", tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var got []string
+			for _, word := range words {
+				got = append(got, word.Kanji)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || total != len(tt.want) {
+				t.Errorf("List() = %v (total %d), want %v", got, total, tt.want)
+			}
+		})
+	}
+
+	words, total, err := groupRepo.ListWords(ctx, 1, 1, 10, models.WordFilter{Conditions: []models.WordCondition{
+		condition("wrong_count", models.FilterEq, 0.0),
+	}})
+	if err != nil {
+		t.Fatalf("ListWords() error = %v", err)
+	}
+	if total != 2 || len(words) != 2 || words[0].Kanji != "飲む" || words[1].Kanji != "行く" {
+		t.Errorf("ListWords() = %d words (total %d), want 飲む and 行く", len(words), total)
+	}
+
+	_, _, err = wordRepo.List(ctx, 1, 10, "kanji", "asc", models.WordFilter{Conditions: []models.WordCondition{
+		condition("w.id; DROP TABLE words", models.FilterEq, 1.0),
+	}})
+	if err == nil {
+		t.Error("List() accepted a field outside the allow-list")
+	}
+}
@@ -20,7 +20,7 @@ func setupWordTest() (*gin.Engine, *service.WordService) {
 	r := gin.New()
 
 	mockRepo := service.NewMockWordRepository()
-	wordService := service.NewWordService(mockRepo)
+	wordService := service.NewWordService(mockRepo, service.NewMockSentenceRepository(), service.NewMockLanguageRepository(), service.NewMockRevisionRepository(), service.DefaultLanguagePacks())
 	handler := handlers.NewWordHandler(wordService)
 
 	// Setup routes
@@ -85,6 +85,37 @@ func TestWordHandler_CreateWord(t *testing.T) {
 	}
 }
 
+func TestWordHandler_CreateWordRomaji(t *testing.T) {
+	r, _ := setupWordTest()
+
+	// Romaji is filled in from a kana-only word
+	body, _ := json.Marshal(models.Word{Kanji: "たべる", English: "to eat", Parts: map[string]any{}})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/words", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response struct {
+		Data     models.Word `json:"data"`
+		Warnings []string    `json:"warnings"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "taberu", response.Data.Romaji)
+	assert.Empty(t, response.Warnings)
+
+	// Romaji that does not match the reading is stored with a warning
+	body, _ = json.Marshal(models.Word{Kanji: "たべる", Romaji: "nomu", English: "to eat", Parts: map[string]any{}})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/words", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	response.Warnings = nil
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "nomu", response.Data.Romaji)
+	assert.Len(t, response.Warnings, 1)
+}
+
 func TestWordHandler_GetWord(t *testing.T) {
 	r, wordService := setupWordTest()
 
@@ -98,7 +129,7 @@ func TestWordHandler_GetWord(t *testing.T) {
 			"topic":     "food",
 		},
 	}
-	err := wordService.CreateWord(nil, word)
+	_, err := wordService.CreateWord(nil, word)
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -139,4 +170,30 @@ func TestWordHandler_GetWord(t *testing.T) {
 			}
 		})
 	}
-} 
\ No newline at end of file
+} 
+func TestWordHandler_CreateWordLanguage(t *testing.T) {
+	r, _ := setupWordTest()
+
+	// The language-agnostic field names are accepted and returned along the
+	// Japanese ones
+	body := `{"language": "es", "term": "hablar", "gloss": "to speak", "parts": {}}`
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/words", bytes.NewBufferString(body))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response struct {
+		Data map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "es", response.Data["language"])
+	assert.Equal(t, "hablar", response.Data["term"])
+	assert.Equal(t, "hablar", response.Data["kanji"])
+	assert.Equal(t, "to speak", response.Data["gloss"])
+	assert.Equal(t, "", response.Data["transliteration"])
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/words", bytes.NewBufferString(`{"language": "xx", "term": "x", "gloss": "x", "parts": {}}`))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -78,7 +78,7 @@ func TestWordRepository_List(t *testing.T) {
 	}
 
 	// Test listing words
-	retrievedWords, total, err := repo.List(ctx, 1, 10, "kanji", "asc")
+	retrievedWords, total, err := repo.List(ctx, 1, 10, "kanji", "asc", models.WordFilter{})
 	if err != nil {
 		t.Errorf("error listing words: %v", err)
 	}
@@ -161,4 +161,38 @@ func TestWordRepository_Delete(t *testing.T) {
 	if deletedWord != nil {
 		t.Fatal("expected word to be deleted, got non-nil")
 	}
-} 
\ No newline at end of file
+}
+
+func TestWordRepository_GetByKanjiRomaji(t *testing.T) {
+	_, wordRepo, _ := newSQLiteFixture(t)
+	ctx := context.Background()
+
+	// The same spelling in another language is another word
+	spanish := &models.Word{Language: "es", Kanji: "食べる", Romaji: "taberu", English: "comer", Parts: map[string]any{}}
+	if err := wordRepo.Create(ctx, spanish); err != nil {
+		t.Fatalf("error creating test word: %v", err)
+	}
+
+	tests := []struct {
+		language string
+		want     int64
+	}{
+		{"", 1},
+		{"ja", 1},
+		{"es", spanish.ID},
+		{"fr", 0},
+	}
+	for _, tt := range tests {
+		word, err := wordRepo.GetByKanjiRomaji(ctx, "食べる", "taberu", tt.language)
+		if err != nil {
+			t.Fatalf("GetByKanjiRomaji(%q) error = %v", tt.language, err)
+		}
+		var got int64
+		if word != nil {
+			got = word.ID
+		}
+		if got != tt.want {
+			t.Errorf("GetByKanjiRomaji(%q) = word %d, want word %d", tt.language, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+//go:build sqlite_fts5
+
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"backend-go/internal/domain/models"
+	"backend-go/internal/repository/sqlite"
+	"backend-go/internal/repository/sqlite/implementations"
+	"backend-go/migrations"
+)
+
+// newSearchFixture migrates a fresh database with every migration,
+// including the FTS5 index, and adds words to search
+func newSearchFixture(t *testing.T) (*sqlite.Database, *implementations.WordRepository, []*models.Word) {
+	t.Helper()
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("error opening database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.MigrateUp(migrations.FS); err != nil {
+		t.Fatalf("error migrating up: %v", err)
+	}
+
+	wordRepo := implementations.NewWordRepository(db)
+	words := []*models.Word{
+		{Kanji: "夕食", Romaji: "yuushoku", English: "dinner, the large meal that people eat together in the evening", Parts: map[string]any{"topic": "food"}},
+		{Kanji: "食べる", Romaji: "taberu", English: "to eat", Parts: map[string]any{"verb_type": "ichidan", "topic": "food"}},
+		{Kanji: "飲む", Romaji: "nomu", English: "to drink", Parts: map[string]any{"verb_type": "godan", "topic": "drinks"}},
+		{Language: "es", Kanji: "comer", English: "to eat", Parts: map[string]any{}},
+	}
+	for _, word := range words {
+		if err := wordRepo.Create(context.Background(), word); err != nil {
+			t.Fatalf("error creating test word: %v", err)
+		}
+	}
+	return db, wordRepo, words
+}
+
+func TestWordRepository_Search(t *testing.T) {
+	_, wordRepo, _ := newSearchFixture(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		query    string
+		language string
+		want     []string
+	}{
+		{"shorter matches rank first", "eat", "ja", []string{"食べる", "夕食"}},
+		{"every language", "eat", "", []string{"comer", "食べる", "夕食"}},
+		{"Spanish only", "eat", "es", []string{"comer"}},
+		{"romaji prefix", "tab", "", []string{"食べる"}},
+		{"kanji prefix", "食べ", "", []string{"食べる"}},
+		{"parts values", "ichidan", "", []string{"食べる"}},
+		{"parts values are prefix-matched", "drink", "", []string{"飲む"}},
+		{"every term must match", "eat food", "", []string{"食べる", "夕食"}},
+		{"FTS5 syntax is literal", `"eat" OR (nomu`, "", []string{}},
+		{"blank query", "  ", "", []string{}},
+		// unicode61 does not segment Japanese, so only prefixes of a whole
+		// term match
+		{"mid-word Japanese", "べる", "", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, total, err := wordRepo.Search(ctx, tt.query, tt.language, 1, 10)
+			if err != nil {
+				t.Fatalf("Search(%q) error = %v", tt.query, err)
+			}
+			got := []string{}
+			for _, word := range words {
+				got = append(got, word.Kanji)
+			}
+			if !slices.Equal(got, tt.want) || total != len(tt.want) {
+				t.Errorf("Search(%q) = %v (%d in total), want %v", tt.query, got, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordRepository_SearchFollowsChanges(t *testing.T) {
+	db, wordRepo, words := newSearchFixture(t)
+	ctx := context.Background()
+	taberu, nomu := words[1], words[2]
+
+	search := func(query string) []int64 {
+		t.Helper()
+		found, _, err := wordRepo.Search(ctx, query, "", 1, 10)
+		if err != nil {
+			t.Fatalf("Search(%q) error = %v", query, err)
+		}
+		ids := []int64{}
+		for _, word := range found {
+			ids = append(ids, word.ID)
+		}
+		return ids
+	}
+
+	// An update replaces the indexed text
+	taberu.English = "to consume"
+	taberu.Parts = map[string]any{"verb_type": "ichidan"}
+	if err := wordRepo.Update(ctx, taberu); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := search("consume"); !slices.Equal(got, []int64{taberu.ID}) {
+		t.Errorf("Search(consume) after an update = %v, want [%d]", got, taberu.ID)
+	}
+	if got := search("food"); slices.Contains(got, taberu.ID) {
+		t.Errorf("Search(food) after an update = %v, still finds the old parts", got)
+	}
+
+	// Words in the trash are not found, and are again once restored
+	if err := wordRepo.Delete(ctx, nomu.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got := search("nomu"); len(got) != 0 {
+		t.Errorf("Search(nomu) of a deleted word = %v, want none", got)
+	}
+	if _, err := wordRepo.Restore(ctx, nomu.ID); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if got := search("nomu"); !slices.Equal(got, []int64{nomu.ID}) {
+		t.Errorf("Search(nomu) of a restored word = %v, want [%d]", got, nomu.ID)
+	}
+
+	// Purging a word removes it from the index
+	if err := wordRepo.Delete(ctx, nomu.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := implementations.NewTrashRepository(db).Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	var indexed int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM words_fts WHERE rowid = ?`, nomu.ID).Scan(&indexed); err != nil {
+		t.Fatalf("error counting indexed words: %v", err)
+	}
+	if indexed != 0 {
+		t.Errorf("purged word is still indexed %d times", indexed)
+	}
+}